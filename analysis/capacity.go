@@ -0,0 +1,307 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauern/remy"
+)
+
+// Thresholds used to find hot servers.
+const (
+	// HotHeapUtilization is the share of the heap in use above which a server is hot.
+	HotHeapUtilization = 0.85
+	// CriticalHeapUtilization is the share of the heap in use above which a server is close to running out.
+	CriticalHeapUtilization = 0.95
+	// HotProcessorLoad is the JVM processor load above which a server is hot.
+	HotProcessorLoad = 0.8
+)
+
+// Groups servers with no machine or cluster are reported under.
+const (
+	NoMachine  = "(no machine)"
+	Standalone = "(standalone)"
+)
+
+// Trend is the rate heap use and open sockets changed over several samples of a server, fitted by least squares.
+type Trend struct {
+	Samples  int           `json:"samples"`
+	Duration time.Duration `json:"duration"`
+	// HeapUsedPerHour is the change in bytes of heap in use per hour.
+	HeapUsedPerHour float64 `json:"heapUsedPerHour"`
+	// SocketsPerHour is the change in open sockets per hour.
+	SocketsPerHour float64 `json:"socketsPerHour"`
+	// TimeToFull is how long until the heap is full at the current rate, when heap use is growing.
+	TimeToFull *time.Duration `json:"timeToFull,omitempty"`
+}
+
+// ServerCapacity is the heap, processor load and sockets of a server, from its latest sample.
+type ServerCapacity struct {
+	Name            string   `json:"name"`
+	Machine         string   `json:"machine"`
+	Cluster         string   `json:"cluster"`
+	State           string   `json:"state"`
+	HeapSize        int      `json:"heapSize"`
+	HeapUsed        int      `json:"heapUsed"`
+	HeapUtilization *float64 `json:"heapUtilization"`
+	ProcessorLoad   float64  `json:"processorLoad"`
+	Sockets         float64  `json:"sockets"`
+	Hot             bool     `json:"hot"`
+	Trend           *Trend   `json:"trend,omitempty"`
+}
+
+// CapacityGroup is the total heap and sockets of the servers on a machine or in a cluster.
+type CapacityGroup struct {
+	Name            string   `json:"name"`
+	Servers         []string `json:"servers"`
+	HeapSize        int      `json:"heapSize"`
+	HeapUsed        int      `json:"heapUsed"`
+	HeapUtilization *float64 `json:"heapUtilization"`
+	Sockets         float64  `json:"sockets"`
+	// ProcessorLoad is the highest JVM processor load of the servers.
+	ProcessorLoad float64 `json:"processorLoad"`
+}
+
+// Percentiles summarizes the distribution of a metric across servers, by nearest rank.
+type Percentiles struct {
+	P50 *float64 `json:"p50"`
+	P90 *float64 `json:"p90"`
+	P99 *float64 `json:"p99"`
+	Max *float64 `json:"max"`
+}
+
+// CapacityReport is the capacity of every server, grouped by machine and cluster, with the percentiles of heap
+// utilization across the domain and the hot servers found.
+type CapacityReport struct {
+	Servers         []ServerCapacity `json:"servers"`
+	Machines        []CapacityGroup  `json:"machines"`
+	Clusters        []CapacityGroup  `json:"clusters"`
+	HeapUtilization Percentiles      `json:"heapUtilization"`
+	Findings        []Finding        `json:"findings"`
+}
+
+// NewServerCapacity derives the capacity of a server.  Servers that aren't running report no heap, so have no
+// utilization.
+func NewServerCapacity(s remy.Server) ServerCapacity {
+	c := ServerCapacity{
+		Name:          s.Name,
+		Machine:       s.CurrentMachine,
+		Cluster:       s.ClusterName,
+		State:         s.State,
+		HeapSize:      s.HeapSizeCurrent,
+		HeapUsed:      s.HeapSizeCurrent - s.HeapFreeCurrent,
+		ProcessorLoad: s.JvmProcessorLoad,
+		Sockets:       s.OpenSocketsCurrentCount,
+	}
+	if c.Machine == "" {
+		c.Machine = NoMachine
+	}
+	if c.Cluster == "" {
+		c.Cluster = Standalone
+	}
+	c.HeapUtilization = ratio(c.HeapUsed, c.HeapSize)
+	c.Hot = (c.HeapUtilization != nil && *c.HeapUtilization >= HotHeapUtilization) || c.ProcessorLoad >= HotProcessorLoad
+	return c
+}
+
+// AnalyzeCapacity derives the capacity of every server in the latest of samples, taken interval apart and oldest
+// first, and groups them by machine and cluster.  With more than one sample, the trend of each server's heap use and
+// sockets is estimated too.
+func AnalyzeCapacity(samples [][]remy.Server, interval time.Duration) *CapacityReport {
+	r := &CapacityReport{Servers: []ServerCapacity{}, Machines: []CapacityGroup{}, Clusters: []CapacityGroup{}, Findings: []Finding{}}
+	if len(samples) == 0 {
+		return r
+	}
+	history := make(map[string][]remy.Server)
+	for _, sample := range samples {
+		for _, s := range sample {
+			history[s.Name] = append(history[s.Name], s)
+		}
+	}
+
+	machines, clusters := make(map[string]*CapacityGroup), make(map[string]*CapacityGroup)
+	group := func(groups map[string]*CapacityGroup, name string, c ServerCapacity) {
+		g := groups[name]
+		if g == nil {
+			g = &CapacityGroup{Name: name}
+			groups[name] = g
+		}
+		g.Servers = append(g.Servers, c.Name)
+		g.HeapSize += c.HeapSize
+		g.HeapUsed += c.HeapUsed
+		g.Sockets += c.Sockets
+		g.ProcessorLoad = math.Max(g.ProcessorLoad, c.ProcessorLoad)
+	}
+	var utilizations []float64
+	for _, s := range samples[len(samples)-1] {
+		c := NewServerCapacity(s)
+		if len(history[s.Name]) > 1 && interval > 0 {
+			c.Trend = trend(history[s.Name], interval)
+		}
+		r.Servers = append(r.Servers, c)
+		group(machines, c.Machine, c)
+		group(clusters, c.Cluster, c)
+		if c.HeapUtilization != nil {
+			utilizations = append(utilizations, *c.HeapUtilization)
+		}
+		r.Findings = append(r.Findings, diagnoseServer(c)...)
+	}
+	r.Machines, r.Clusters = sortGroups(machines), sortGroups(clusters)
+	r.HeapUtilization = percentiles(utilizations)
+	return r
+}
+
+// trend fits a line to heap use and sockets over samples of a server taken interval apart.
+func trend(samples []remy.Server, interval time.Duration) *Trend {
+	var heap, sockets []float64
+	for _, s := range samples {
+		heap = append(heap, float64(s.HeapSizeCurrent-s.HeapFreeCurrent))
+		sockets = append(sockets, s.OpenSocketsCurrentCount)
+	}
+	perHour := float64(time.Hour) / float64(interval)
+	t := &Trend{
+		Samples:         len(samples),
+		Duration:        time.Duration(len(samples)-1) * interval,
+		HeapUsedPerHour: slope(heap) * perHour,
+		SocketsPerHour:  slope(sockets) * perHour,
+	}
+	latest := samples[len(samples)-1]
+	if free := latest.HeapFreeCurrent; t.HeapUsedPerHour > 0 && latest.HeapSizeCurrent > 0 {
+		full := time.Duration(float64(free) / t.HeapUsedPerHour * float64(time.Hour))
+		t.TimeToFull = &full
+	}
+	return t
+}
+
+// slope is the least squares slope of values against their index.
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	d := n*sumXX - sumX*sumX
+	if d == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / d
+}
+
+// percentiles is the nearest rank percentiles of values, or nils when there are none.
+func percentiles(values []float64) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := func(p float64) *float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return &sorted[i]
+	}
+	return Percentiles{P50: rank(0.5), P90: rank(0.9), P99: rank(0.99), Max: &sorted[len(sorted)-1]}
+}
+
+// sortGroups lists groups by name, with their servers sorted too.
+func sortGroups(groups map[string]*CapacityGroup) []CapacityGroup {
+	sorted := []CapacityGroup{}
+	for _, g := range groups {
+		sort.Strings(g.Servers)
+		g.HeapUtilization = ratio(g.HeapUsed, g.HeapSize)
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// diagnoseServer flags a hot server, and one whose heap use is growing.
+func diagnoseServer(c ServerCapacity) []Finding {
+	var findings []Finding
+	add := func(severity Severity, problem, explanation string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Name: c.Name, Problem: problem, Explanation: fmt.Sprintf(explanation, args...)})
+	}
+
+	if c.HeapUtilization != nil && *c.HeapUtilization >= HotHeapUtilization {
+		severity := Warning
+		if *c.HeapUtilization >= CriticalHeapUtilization {
+			severity = Critical
+		}
+		add(severity, "heap nearly full",
+			"%v of the %v heap is in use.  If it stays this high after a full garbage collection, raise the maximum heap "+
+				"size (-Xmx) or move applications to another server.", percent(c.HeapUtilization), bytesize(c.HeapSize))
+	}
+	if c.ProcessorLoad >= HotProcessorLoad {
+		add(Warning, "processor load high",
+			"The JVM is using %v of the processors on %v.  Servers sharing the machine compete for CPU; consider moving "+
+				"one to a less loaded machine.", percent(&c.ProcessorLoad), c.Machine)
+	}
+	if c.Trend != nil && c.Trend.TimeToFull != nil {
+		add(Info, "heap use growing",
+			"Heap use grew %v per hour over %v samples; at that rate the heap is full in %v.  Garbage collection makes "+
+				"heap use saw-toothed, so sample over a longer period before resizing.",
+			bytesize(int(c.Trend.HeapUsedPerHour)), c.Trend.Samples, c.Trend.TimeToFull.Round(time.Minute))
+	}
+	return findings
+}
+
+// bytesize formats a number of bytes in MiB.
+func bytesize(n int) string {
+	return strconv.FormatFloat(float64(n)/(1<<20), 'f', 0, 64) + "MiB"
+}
+
+// String formats the report for the console: tables of servers, machines and clusters, the percentiles of heap
+// utilization, then the problems found.
+func (r *CapacityReport) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("Servers\n")
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  SERVER\tMACHINE\tCLUSTER\tHEAP\tHEAP USED\tCPU\tSOCKETS\tHEAP TREND\t")
+	for _, c := range r.Servers {
+		name := c.Name
+		if c.Hot {
+			name += " *"
+		}
+		heapTrend := "n/a"
+		if c.Trend != nil {
+			heapTrend = bytesize(int(c.Trend.HeapUsedPerHour)) + "/h"
+		}
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", name, c.Machine, c.Cluster, bytesize(c.HeapSize),
+			percent(c.HeapUtilization), percent(&c.ProcessorLoad), c.Sockets, heapTrend)
+	}
+	tw.Flush()
+
+	groups := func(title, column string, groups []CapacityGroup) {
+		buffer.WriteString("\n" + title + "\n")
+		tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "  %v\tSERVERS\tHEAP\tHEAP USED\tPEAK CPU\tSOCKETS\t\n", column)
+		for _, g := range groups {
+			fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t\n", g.Name, len(g.Servers), bytesize(g.HeapSize),
+				percent(g.HeapUtilization), percent(&g.ProcessorLoad), g.Sockets)
+		}
+		tw.Flush()
+	}
+	groups("Machines", "MACHINE", r.Machines)
+	groups("Clusters", "CLUSTER", r.Clusters)
+
+	p := r.HeapUtilization
+	buffer.WriteString(fmt.Sprintf("\nHeap utilization: p50 %v, p90 %v, p99 %v, max %v\n\n", percent(p.P50), percent(p.P90),
+		percent(p.P99), percent(p.Max)))
+	if len(r.Findings) == 0 {
+		buffer.WriteString("No hot servers\n")
+	}
+	for _, f := range r.Findings {
+		buffer.WriteString(fmt.Sprintf("%v\n    %v\n", f, f.Explanation))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+const mib = 1 << 20
+
+func server(name, machine, cluster string, heapUsed int, load float64) remy.Server {
+	return remy.Server{Name: name, State: "RUNNING", CurrentMachine: machine, ClusterName: cluster, HeapSizeCurrent: 1000 * mib,
+		HeapFreeCurrent: (1000 - heapUsed) * mib, JvmProcessorLoad: load, OpenSocketsCurrentCount: 10}
+}
+
+func TestAnalyzeCapacity(t *testing.T) {
+	servers := []remy.Server{
+		{Name: "AdminServer", State: "SHUTDOWN"},
+		server("ms1", "machine-1", "cluster1", 500, 0.1),
+		server("ms2", "machine-1", "cluster1", 900, 0.2),
+		server("ms3", "machine-2", "", 960, 0.9),
+	}
+	r := AnalyzeCapacity([][]remy.Server{servers}, 0)
+
+	assert.Len(t, r.Servers, 4)
+	assert.Nil(t, r.Servers[0].HeapUtilization)
+	assert.Equal(t, NoMachine, r.Servers[0].Machine)
+	assert.Equal(t, 0.5, *r.Servers[1].HeapUtilization)
+	assert.False(t, r.Servers[1].Hot)
+	assert.True(t, r.Servers[2].Hot)
+	assert.Nil(t, r.Servers[1].Trend)
+
+	assert.Equal(t, []string{NoMachine, "machine-1", "machine-2"}, []string{r.Machines[0].Name, r.Machines[1].Name, r.Machines[2].Name})
+	m := r.Machines[1]
+	assert.Equal(t, []string{"ms1", "ms2"}, m.Servers)
+	assert.Equal(t, 20.0, m.Sockets)
+	assert.Equal(t, 0.7, *m.HeapUtilization)
+	assert.Equal(t, 0.2, m.ProcessorLoad)
+	assert.Equal(t, []string{Standalone, "cluster1"}, []string{r.Clusters[0].Name, r.Clusters[1].Name})
+	assert.Equal(t, []string{"AdminServer", "ms3"}, r.Clusters[0].Servers)
+
+	assert.Equal(t, 0.9, *r.HeapUtilization.P50)
+	assert.Equal(t, 0.96, *r.HeapUtilization.P90)
+	assert.Equal(t, 0.96, *r.HeapUtilization.Max)
+
+	var found []string
+	for _, f := range r.Findings {
+		found = append(found, f.String())
+	}
+	assert.Equal(t, []string{
+		"warning ms2: heap nearly full",
+		"critical ms3: heap nearly full",
+		"warning ms3: processor load high",
+	}, found)
+	assert.Contains(t, r.String(), "ms3 *")
+}
+
+func TestAnalyzeCapacityTrend(t *testing.T) {
+	samples := [][]remy.Server{
+		{server("ms1", "machine-1", "cluster1", 100, 0)},
+		{server("ms1", "machine-1", "cluster1", 200, 0)},
+		{server("ms1", "machine-1", "cluster1", 300, 0)},
+	}
+	r := AnalyzeCapacity(samples, time.Minute)
+	trend := r.Servers[0].Trend
+	assert.Equal(t, 3, trend.Samples)
+	assert.Equal(t, 2*time.Minute, trend.Duration)
+	assert.Equal(t, float64(6000*mib), trend.HeapUsedPerHour)
+	assert.Equal(t, 0.0, trend.SocketsPerHour)
+	assert.Equal(t, 7*time.Minute, *trend.TimeToFull)
+	assert.Equal(t, "info ms1: heap use growing", r.Findings[0].String())
+}
+
+func TestPercentiles(t *testing.T) {
+	assert.Nil(t, percentiles(nil).P50)
+	p := percentiles([]float64{0.4, 0.1, 0.3, 0.2})
+	assert.Equal(t, 0.2, *p.P50)
+	assert.Equal(t, 0.4, *p.P90)
+	assert.Equal(t, 0.4, *p.Max)
+}
@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauern/remy"
+)
+
+// Thresholds used to diagnose cluster communication problems.
+const (
+	// MaxResendRatio is the share of fragments sent that had to be resent above which a member is warned about.
+	MaxResendRatio = 0.01
+	// CriticalResendRatio is the share of fragments resent above which the member's messaging is critical.
+	CriticalResendRatio = 0.05
+)
+
+// Verdict is the overall health of a cluster, from its worst Finding.
+type Verdict string
+
+// Verdicts of a ClusterHealth.
+const (
+	Healthy   Verdict = "healthy"
+	Degraded  Verdict = "degraded"
+	Unhealthy Verdict = "unhealthy"
+)
+
+// MemberMetrics are the metrics derived from the messaging counters of a cluster member.  When the cluster was
+// sampled twice, ResendRatio is over the Interval between them and the rates are per second; otherwise ResendRatio is
+// since the server started and there are no rates.
+type MemberMetrics struct {
+	Server           string `json:"server"`
+	State            string `json:"state"`
+	Health           string `json:"health"`
+	Master           bool   `json:"master"`
+	DropOutFrequency string `json:"dropOutFrequency"`
+	// ResendRatio is the share of fragments sent that were resent.
+	ResendRatio *float64      `json:"resendRatio"`
+	SendRate    *float64      `json:"sendRate,omitempty"`
+	ReceiveRate *float64      `json:"receiveRate,omitempty"`
+	ResendRate  *float64      `json:"resendRate,omitempty"`
+	Interval    time.Duration `json:"interval,omitempty"`
+}
+
+// ClusterHealth is the metrics of every member of a cluster, the problems found and a Verdict summarizing them.
+type ClusterHealth struct {
+	Name     string          `json:"name"`
+	Verdict  Verdict         `json:"verdict"`
+	Masters  int             `json:"masters"`
+	Members  []MemberMetrics `json:"members"`
+	Findings []Finding       `json:"findings"`
+}
+
+// ClusterHealthReport is the health of one or more clusters.
+type ClusterHealthReport struct {
+	Clusters []ClusterHealth `json:"clusters"`
+}
+
+// NewMemberMetrics derives the metrics of a cluster member.
+func NewMemberMetrics(m remy.ClusterServer) MemberMetrics {
+	return MemberMetrics{
+		Server:           m.Name,
+		State:            m.State,
+		Health:           m.Health,
+		Master:           m.IsClusterMaster,
+		DropOutFrequency: m.DropOutFrequency,
+		ResendRatio:      ratio(m.ResendRequestsCount, m.FragmentsSentCount),
+	}
+}
+
+// rates replaces the resend ratio with the one over interval since an earlier sample of the same member, and adds
+// the rates fragments were sent, received and resent.  A member whose counters went down was restarted in between,
+// so its ratio since starting is kept.
+func (m *MemberMetrics) rates(before, after remy.ClusterServer, interval time.Duration) {
+	sent := after.FragmentsSentCount - before.FragmentsSentCount
+	received := after.FragmentsReceivedCount - before.FragmentsReceivedCount
+	resent := after.ResendRequestsCount - before.ResendRequestsCount
+	if sent < 0 || received < 0 || resent < 0 || interval <= 0 {
+		return
+	}
+	perSecond := func(n int) *float64 {
+		r := float64(n) / interval.Seconds()
+		return &r
+	}
+	m.ResendRatio = ratio(resent, sent)
+	m.SendRate, m.ReceiveRate, m.ResendRate, m.Interval = perSecond(sent), perSecond(received), perSecond(resent), interval
+}
+
+// AnalyzeCluster derives the metrics of every member of the cluster after, and diagnoses problems with the members
+// and the cluster as a whole.  When before is an earlier sample of the same cluster, taken interval before, resends
+// are judged over the interval.
+func AnalyzeCluster(before, after *remy.Cluster, interval time.Duration) ClusterHealth {
+	h := ClusterHealth{Name: after.Name, Members: []MemberMetrics{}, Findings: []Finding{}}
+	earlier := make(map[string]remy.ClusterServer)
+	if before != nil {
+		for _, m := range before.Servers {
+			earlier[m.Name] = m
+		}
+	}
+	var states, healths []string
+	for _, member := range after.Servers {
+		m := NewMemberMetrics(member)
+		if prev, sampled := earlier[member.Name]; sampled {
+			m.rates(prev, member, interval)
+		}
+		if m.Master {
+			h.Masters++
+		}
+		h.Members = append(h.Members, m)
+		h.Findings = append(h.Findings, diagnoseMember(after.Name+"/"+member.Name, member, m)...)
+		states = append(states, member.State)
+		healths = append(healths, member.Health)
+	}
+
+	add := func(severity Severity, name, problem, explanation string, args ...interface{}) {
+		h.Findings = append(h.Findings, Finding{Severity: severity, Name: name, Problem: problem, Explanation: fmt.Sprintf(explanation, args...)})
+	}
+	switch {
+	case len(after.Servers) == 0:
+		add(Warning, after.Name, "no members", "The cluster has no member servers, so nothing targeted to it is running.")
+	case h.Masters == 0:
+		add(Warning, after.Name, "no cluster master",
+			"None of the %v members is the cluster master, so singleton services and whole server migration can't fail "+
+				"over.  Check the members can reach the migration basis (database or consensus leasing).", len(after.Servers))
+	case h.Masters > 1:
+		add(Critical, after.Name, "multiple cluster masters",
+			"%v members each think they are the cluster master.  The cluster is partitioned (split brain); check the "+
+				"network between members and the cluster's messaging mode.", h.Masters)
+	}
+	state, health := consensus(states, "RUNNING"), consensus(healths, "HEALTH_OK")
+	for _, member := range after.Servers {
+		name := after.Name + "/" + member.Name
+		if member.State != state {
+			add(Warning, name, "state differs from peers", "The member is %v while its peers are %v.", member.State, state)
+		}
+		if member.Health != health {
+			add(Warning, name, "health differs from peers", "The member is %v while its peers are %v.", member.Health, health)
+		}
+	}
+
+	h.Verdict = Healthy
+	for _, f := range h.Findings {
+		switch {
+		case f.Severity == Critical:
+			h.Verdict = Unhealthy
+		case f.Severity == Warning && h.Verdict == Healthy:
+			h.Verdict = Degraded
+		}
+	}
+	return h
+}
+
+// diagnoseMember flags messaging problems of a single cluster member.
+func diagnoseMember(name string, member remy.ClusterServer, m MemberMetrics) []Finding {
+	var findings []Finding
+	add := func(severity Severity, problem, explanation string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Name: name, Problem: problem, Explanation: fmt.Sprintf(explanation, args...)})
+	}
+
+	if m.ResendRatio != nil && *m.ResendRatio > MaxResendRatio {
+		severity := Warning
+		if *m.ResendRatio > CriticalResendRatio {
+			severity = Critical
+		}
+		over := "since the server started"
+		if m.Interval > 0 {
+			over = "in the last " + m.Interval.String()
+		}
+		add(severity, "high resend ratio",
+			"%v of fragments sent had to be resent %v.  Cluster messages are being lost; check for network congestion, "+
+				"multicast storms or long garbage collection pauses on the members.", percent(m.ResendRatio), over)
+	}
+	if member.DropOutFrequency != "" && member.DropOutFrequency != "Never" {
+		severity := Warning
+		if strings.HasPrefix(strings.ToLower(member.DropOutFrequency), "frequent") {
+			severity = Critical
+		}
+		add(severity, "dropping out of the cluster",
+			"The member drops out of the cluster %v: it misses heartbeats from its peers and loses its view of the "+
+				"cluster.  Check the network and for long garbage collection pauses.", member.DropOutFrequency)
+	}
+	return findings
+}
+
+// consensus is the most common of values, preferring preferred, then the first value seen, when there is a tie.
+func consensus(values []string, preferred string) string {
+	counts := make(map[string]int)
+	var best string
+	for _, v := range values {
+		counts[v]++
+	}
+	for _, v := range values {
+		switch {
+		case counts[v] > counts[best]:
+			best = v
+		case counts[v] == counts[best] && v == preferred:
+			best = v
+		}
+	}
+	return best
+}
+
+// String formats the report for the console: the verdict and a table of members for each cluster, then the
+// problems found.
+func (r *ClusterHealthReport) String() string {
+	var buffer bytes.Buffer
+	for _, h := range r.Clusters {
+		buffer.WriteString(fmt.Sprintf("Cluster %v: %v (%v members, %v masters)\n", h.Name, h.Verdict, len(h.Members), h.Masters))
+		tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  SERVER\tSTATE\tHEALTH\tMASTER\tDROP OUTS\tRESENT\tSENT/S\tRECEIVED/S\t")
+		for _, m := range h.Members {
+			fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", m.Server, m.State, m.Health, m.Master,
+				m.DropOutFrequency, percent(m.ResendRatio), rate(m.SendRate), rate(m.ReceiveRate))
+		}
+		tw.Flush()
+		if len(h.Findings) == 0 {
+			buffer.WriteString("  No problems found\n")
+		}
+		for _, f := range h.Findings {
+			buffer.WriteString(fmt.Sprintf("  %v\n      %v\n", f, f.Explanation))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+// rate formats a per-second rate, or n/a when the cluster was only sampled once.
+func rate(r *float64) string {
+	if r == nil {
+		return "n/a"
+	}
+	return strconv.FormatFloat(*r, 'f', 1, 64)
+}
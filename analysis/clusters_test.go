@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func member(name string, master bool, sent, resent int) remy.ClusterServer {
+	return remy.ClusterServer{Name: name, State: "RUNNING", Health: "HEALTH_OK", IsClusterMaster: master,
+		DropOutFrequency: "Never", FragmentsSentCount: sent, FragmentsReceivedCount: sent, ResendRequestsCount: resent}
+}
+
+func TestAnalyzeClusterHealthy(t *testing.T) {
+	c := &remy.Cluster{Name: "cluster1", Servers: []remy.ClusterServer{member("ms1", true, 1000, 1), member("ms2", false, 1000, 0)}}
+	h := AnalyzeCluster(nil, c, 0)
+	assert.Equal(t, Healthy, h.Verdict)
+	assert.Equal(t, 1, h.Masters)
+	assert.Empty(t, h.Findings)
+	assert.Equal(t, 0.001, *h.Members[0].ResendRatio)
+	assert.Nil(t, h.Members[0].SendRate)
+	assert.Contains(t, (&ClusterHealthReport{Clusters: []ClusterHealth{h}}).String(), "Cluster cluster1: healthy (2 members, 1 masters)")
+}
+
+func TestAnalyzeClusterRates(t *testing.T) {
+	before := &remy.Cluster{Name: "cluster1", Servers: []remy.ClusterServer{member("ms1", true, 1000, 0), member("ms2", false, 1000, 0)}}
+	after := &remy.Cluster{Name: "cluster1", Servers: []remy.ClusterServer{member("ms1", true, 1100, 10), member("ms2", false, 10, 0)}}
+	h := AnalyzeCluster(before, after, 10*time.Second)
+
+	assert.Equal(t, 0.1, *h.Members[0].ResendRatio, "resends should be judged over the interval")
+	assert.Equal(t, 10.0, *h.Members[0].SendRate)
+	assert.Equal(t, 1.0, *h.Members[0].ResendRate)
+	assert.Nil(t, h.Members[1].SendRate, "a restarted member has no rates")
+	assert.Equal(t, 0.0, *h.Members[1].ResendRatio)
+
+	assert.Len(t, h.Findings, 1)
+	assert.Equal(t, "critical cluster1/ms1: high resend ratio", h.Findings[0].String())
+	assert.Contains(t, h.Findings[0].Explanation, "in the last 10s")
+	assert.Equal(t, Unhealthy, h.Verdict)
+}
+
+func TestAnalyzeClusterProblems(t *testing.T) {
+	flapping := member("ms2", false, 1000, 0)
+	flapping.DropOutFrequency = "Occasionally"
+	down := member("ms3", false, 1000, 0)
+	down.State, down.Health = "SHUTDOWN", "HEALTH_WARN"
+	c := &remy.Cluster{Name: "cluster1", Servers: []remy.ClusterServer{member("ms1", false, 1000, 0), flapping, down}}
+	h := AnalyzeCluster(nil, c, 0)
+
+	var found []string
+	for _, f := range h.Findings {
+		found = append(found, f.String())
+	}
+	assert.Equal(t, []string{
+		"warning cluster1/ms2: dropping out of the cluster",
+		"warning cluster1: no cluster master",
+		"warning cluster1/ms3: state differs from peers",
+		"warning cluster1/ms3: health differs from peers",
+	}, found)
+	assert.Equal(t, Degraded, h.Verdict)
+
+	c.Servers[0].IsClusterMaster, c.Servers[1].IsClusterMaster = true, true
+	h = AnalyzeCluster(nil, c, 0)
+	assert.Equal(t, "critical cluster1: multiple cluster masters", h.Findings[1].String())
+	assert.Equal(t, Unhealthy, h.Verdict)
+}
+
+func TestConsensus(t *testing.T) {
+	assert.Equal(t, "RUNNING", consensus([]string{"SHUTDOWN", "RUNNING"}, "RUNNING"))
+	assert.Equal(t, "SHUTDOWN", consensus([]string{"SHUTDOWN", "SHUTDOWN", "RUNNING"}, "RUNNING"))
+	assert.Equal(t, "ADMIN", consensus([]string{"ADMIN", "SHUTDOWN"}, "RUNNING"))
+	assert.Equal(t, "", consensus(nil, "RUNNING"))
+}
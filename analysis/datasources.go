@@ -0,0 +1,214 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauern/remy"
+)
+
+// Thresholds used to diagnose connection pool problems.
+const (
+	// PoolSaturation is the peak utilization of a pool above which waiting for connections means it is too small.
+	PoolSaturation = 0.9
+	// MinCacheHitRatio is the prepared statement cache hit ratio below which evictions mean the cache is too small.
+	MinCacheHitRatio = 0.8
+	// MinCacheAccesses is the number of cache accesses needed before the hit ratio is judged.
+	MinCacheAccesses = 100
+	// MaxReserveFailureRate is the share of failed reserve requests above which they are worth a warning.
+	MaxReserveFailureRate = 0.01
+	// MaxWaitFailureRate is the share of failed waits for a connection above which they are critical.
+	MaxWaitFailureRate = 0.05
+)
+
+// PoolMetrics are the metrics derived from the counters of a data source instance, or the sum of every instance.
+type PoolMetrics struct {
+	Server string `json:"server"`
+	// Utilization is the share of the current capacity in use.
+	Utilization *float64 `json:"utilization"`
+	// PeakUtilization is the highest number of active connections over the highest capacity.
+	PeakUtilization *float64 `json:"peakUtilization"`
+	// CacheHitRatio is the share of prepared statement cache accesses that were hits.
+	CacheHitRatio *float64 `json:"cacheHitRatio"`
+	// WaitRate is the share of reserve requests that had to wait for a connection.
+	WaitRate *float64 `json:"waitRate"`
+	// WaitFailureRate is the share of waits for a connection that failed.
+	WaitFailureRate *float64 `json:"waitFailureRate"`
+	// ReserveFailureRate is the share of reserve requests that failed.
+	ReserveFailureRate *float64 `json:"reserveFailureRate"`
+	// Leaked is the number of connections leaked, i.e., reserved and never returned to the pool.
+	Leaked int `json:"leaked"`
+	// LeakedDelta and ActiveDelta are the change in leaked and active connections over Interval, when the data source
+	// was sampled twice.
+	LeakedDelta *int          `json:"leakedDelta,omitempty"`
+	ActiveDelta *int          `json:"activeDelta,omitempty"`
+	Interval    time.Duration `json:"interval,omitempty"`
+}
+
+// DataSourceAnalysis is the metrics of every instance of a data source, their total across the domain, and the
+// problems found.
+type DataSourceAnalysis struct {
+	Name      string        `json:"name"`
+	Total     PoolMetrics   `json:"total"`
+	Instances []PoolMetrics `json:"instances"`
+	Findings  []Finding     `json:"findings"`
+}
+
+// DataSourceReport is the analysis of one or more data sources.
+type DataSourceReport struct {
+	DataSources []DataSourceAnalysis `json:"datasources"`
+}
+
+// NewPoolMetrics derives the metrics of a data source instance.
+func NewPoolMetrics(inst remy.DataSourceInstance) PoolMetrics {
+	return PoolMetrics{
+		Server:             inst.Server,
+		Utilization:        ratio(inst.ActiveConnectionsCurrentCount, inst.CurrCapacity),
+		PeakUtilization:    ratio(inst.ActiveConnectionsHighCount, inst.CurrCapacityHighCount),
+		CacheHitRatio:      ratio(inst.PrepStmtCacheHitCount, inst.PrepStmtCacheAccessCount),
+		WaitRate:           ratio(inst.WaitingForConnectionTotal, inst.ReserveRequestCount),
+		WaitFailureRate:    ratio(inst.WaitingForConnectionFailureTotal, inst.WaitingForConnectionTotal),
+		ReserveFailureRate: ratio(inst.FailedReserveRequestCount, inst.ReserveRequestCount),
+		Leaked:             inst.LeakedConnectionCount,
+	}
+}
+
+// trend adds the change in leaked and active connections since an earlier sample of the same instance.
+func (m *PoolMetrics) trend(before, after remy.DataSourceInstance, interval time.Duration) {
+	leaked := after.LeakedConnectionCount - before.LeakedConnectionCount
+	active := after.ActiveConnectionsCurrentCount - before.ActiveConnectionsCurrentCount
+	m.LeakedDelta, m.ActiveDelta, m.Interval = &leaked, &active, interval
+}
+
+// sumInstances adds up the counters of every instance, so metrics can be derived across the whole domain.  High
+// counts are summed too, which overstates the peak when instances peaked at different times.
+func sumInstances(instances []remy.DataSourceInstance) remy.DataSourceInstance {
+	total := remy.DataSourceInstance{Server: "total"}
+	for _, inst := range instances {
+		total.ActiveConnectionsCurrentCount += inst.ActiveConnectionsCurrentCount
+		total.ActiveConnectionsHighCount += inst.ActiveConnectionsHighCount
+		total.CurrCapacity += inst.CurrCapacity
+		total.CurrCapacityHighCount += inst.CurrCapacityHighCount
+		total.PrepStmtCacheHitCount += inst.PrepStmtCacheHitCount
+		total.PrepStmtCacheAccessCount += inst.PrepStmtCacheAccessCount
+		total.WaitingForConnectionTotal += inst.WaitingForConnectionTotal
+		total.WaitingForConnectionFailureTotal += inst.WaitingForConnectionFailureTotal
+		total.ReserveRequestCount += inst.ReserveRequestCount
+		total.FailedReserveRequestCount += inst.FailedReserveRequestCount
+		total.LeakedConnectionCount += inst.LeakedConnectionCount
+	}
+	return total
+}
+
+// AnalyzeDataSource derives the metrics of every instance of the data source after, and diagnoses problems with each.
+// When before is an earlier sample of the same data source, taken interval before, leak trends are included too.
+func AnalyzeDataSource(before, after *remy.DataSource, interval time.Duration) DataSourceAnalysis {
+	a := DataSourceAnalysis{Name: after.Name, Total: NewPoolMetrics(sumInstances(after.Instances)), Findings: []Finding{}}
+	earlier := make(map[string]remy.DataSourceInstance)
+	if before != nil {
+		for _, inst := range before.Instances {
+			earlier[inst.Server] = inst
+		}
+		a.Total.trend(sumInstances(before.Instances), sumInstances(after.Instances), interval)
+	}
+	for _, inst := range after.Instances {
+		m := NewPoolMetrics(inst)
+		prev, sampled := earlier[inst.Server]
+		if sampled {
+			m.trend(prev, inst, interval)
+		}
+		a.Instances = append(a.Instances, m)
+		a.Findings = append(a.Findings, diagnoseInstance(after.Name+"/"+inst.Server, inst, m)...)
+	}
+	return a
+}
+
+// diagnoseInstance flags the likely misconfigurations and problems of a single data source instance.
+func diagnoseInstance(name string, inst remy.DataSourceInstance, m PoolMetrics) []Finding {
+	var findings []Finding
+	add := func(severity Severity, problem, explanation string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Name: name, Problem: problem, Explanation: fmt.Sprintf(explanation, args...)})
+	}
+
+	if inst.State != "" && inst.State != "Running" {
+		add(Critical, "not running", "The instance is %v, so every request for a connection on %v fails.", inst.State, inst.Server)
+	}
+	if m.PeakUtilization != nil && *m.PeakUtilization >= PoolSaturation &&
+		(inst.WaitingForConnectionTotal > 0 || inst.FailedReserveRequestCount > 0) {
+		add(Warning, "pool too small",
+			"Active connections peaked at %v of a capacity of %v, and %v requests had to wait for a connection (%v failed to "+
+				"get one).  Raise the pool's Maximum Capacity, or look for applications holding connections for too long.",
+			inst.ActiveConnectionsHighCount, inst.CurrCapacityHighCount, inst.WaitingForConnectionTotal, inst.FailedReserveRequestCount)
+	}
+	if m.CacheHitRatio != nil && *m.CacheHitRatio < MinCacheHitRatio && inst.PrepStmtCacheAccessCount >= MinCacheAccesses &&
+		inst.PrepStmtCacheDeleteCount > 0 {
+		add(Warning, "statement cache too small",
+			"Only %v of prepared statement cache accesses were hits, and %v statements were evicted from a cache holding %v.  "+
+				"Raise the Statement Cache Size so frequently used statements stay cached.",
+			percent(m.CacheHitRatio), inst.PrepStmtCacheDeleteCount, inst.PrepStmtCacheCurrentSize)
+	}
+	if m.WaitFailureRate != nil && inst.WaitingForConnectionFailureTotal > 0 {
+		severity := Warning
+		if *m.WaitFailureRate > MaxWaitFailureRate {
+			severity = Critical
+		}
+		add(severity, "connection waits failing",
+			"%v of %v waits for a connection (%v) failed, longest wait %vs.  Requests are timing out before a connection "+
+				"is free; the pool is exhausted or connections are held too long.",
+			inst.WaitingForConnectionFailureTotal, inst.WaitingForConnectionTotal, percent(m.WaitFailureRate), inst.WaitSecondsHighCount)
+	}
+	if m.ReserveFailureRate != nil && *m.ReserveFailureRate > MaxReserveFailureRate {
+		add(Warning, "reserve requests failing",
+			"%v of %v requests for a connection (%v) failed.  Check the database is reachable and the pool isn't exhausted.",
+			inst.FailedReserveRequestCount, inst.ReserveRequestCount, percent(m.ReserveFailureRate))
+	}
+	if inst.FailuresToReconnectCount > 0 {
+		add(Warning, "reconnects failing",
+			"The pool failed to reconnect to the database %v times.  The database or network was unavailable; check "+
+				"Test Connections On Reserve is enabled so broken connections aren't handed out.",
+			inst.FailuresToReconnectCount)
+	}
+	switch {
+	case m.LeakedDelta != nil && *m.LeakedDelta > 0:
+		add(Critical, "leaking connections",
+			"%v connections leaked in the last %v (%v in total), and active connections changed by %+d.  An application is "+
+				"reserving connections and not closing them; enable Inactive Connection Timeout and profile connection "+
+				"leaks to find it.",
+			*m.LeakedDelta, m.Interval, inst.LeakedConnectionCount, *m.ActiveDelta)
+	case inst.LeakedConnectionCount > 0:
+		add(Warning, "leaked connections",
+			"%v connections have leaked since the server started.  An application reserved connections without closing "+
+				"them; sample over an interval to see if it is still happening.",
+			inst.LeakedConnectionCount)
+	}
+	return findings
+}
+
+// String formats the report for the console: a table of metrics per data source instance, then the problems found.
+func (r *DataSourceReport) String() string {
+	var buffer bytes.Buffer
+	for _, a := range r.DataSources {
+		buffer.WriteString(fmt.Sprintf("Data source %v (%v instances)\n", a.Name, len(a.Instances)))
+		tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  SERVER\tUSED\tPEAK\tCACHE HITS\tWAITED\tWAITS FAILED\tRESERVES FAILED\tLEAKED\t")
+		for _, m := range append(a.Instances, a.Total) {
+			leaked := fmt.Sprint(m.Leaked)
+			if m.LeakedDelta != nil {
+				leaked += fmt.Sprintf(" (%+d)", *m.LeakedDelta)
+			}
+			fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", m.Server, percent(m.Utilization), percent(m.PeakUtilization),
+				percent(m.CacheHitRatio), percent(m.WaitRate), percent(m.WaitFailureRate), percent(m.ReserveFailureRate), leaked)
+		}
+		tw.Flush()
+		if len(a.Findings) == 0 {
+			buffer.WriteString("  No problems found\n")
+		}
+		for _, f := range a.Findings {
+			buffer.WriteString(fmt.Sprintf("  %v\n      %v\n", f, f.Explanation))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
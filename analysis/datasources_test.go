@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPoolMetrics(t *testing.T) {
+	m := NewPoolMetrics(remy.DataSourceInstance{
+		Server:                           "ms1",
+		ActiveConnectionsCurrentCount:    5,
+		CurrCapacity:                     10,
+		PrepStmtCacheHitCount:            90,
+		PrepStmtCacheAccessCount:         100,
+		ReserveRequestCount:              200,
+		FailedReserveRequestCount:        2,
+		WaitingForConnectionTotal:        20,
+		WaitingForConnectionFailureTotal: 5,
+	})
+	assert.Equal(t, 0.5, *m.Utilization)
+	assert.Nil(t, m.PeakUtilization, "no capacity high count means no peak utilization")
+	assert.Equal(t, 0.9, *m.CacheHitRatio)
+	assert.Equal(t, 0.1, *m.WaitRate)
+	assert.Equal(t, 0.25, *m.WaitFailureRate)
+	assert.Equal(t, 0.01, *m.ReserveFailureRate)
+}
+
+func TestAnalyzeHealthyDataSource(t *testing.T) {
+	ds := remytest.DefaultDomain().DataSources[0]
+	a := AnalyzeDataSource(nil, &ds, 0)
+	assert.Equal(t, "ds1", a.Name)
+	assert.Len(t, a.Instances, 2)
+	assert.Equal(t, "total", a.Total.Server)
+	assert.Equal(t, 0.2, *a.Total.Utilization)
+	assert.Empty(t, a.Findings)
+}
+
+func problems(findings []Finding) map[string]Severity {
+	m := make(map[string]Severity)
+	for _, f := range findings {
+		m[f.Problem] = f.Severity
+	}
+	return m
+}
+
+func TestAnalyzeDataSourceProblems(t *testing.T) {
+	inst := remy.DataSourceInstance{
+		Server:                           "ms1",
+		State:                            "Running",
+		ActiveConnectionsHighCount:       15,
+		CurrCapacityHighCount:            15,
+		ReserveRequestCount:              1000,
+		FailedReserveRequestCount:        50,
+		WaitingForConnectionTotal:        100,
+		WaitingForConnectionFailureTotal: 10,
+		PrepStmtCacheAccessCount:         1000,
+		PrepStmtCacheHitCount:            500,
+		PrepStmtCacheDeleteCount:         400,
+		LeakedConnectionCount:            3,
+	}
+	ds := remy.DataSource{Name: "ds1", Instances: []remy.DataSourceInstance{inst}}
+	a := AnalyzeDataSource(nil, &ds, 0)
+	assert.Equal(t, map[string]Severity{
+		"pool too small":            Warning,
+		"statement cache too small": Warning,
+		"connection waits failing":  Critical,
+		"reserve requests failing":  Warning,
+		"leaked connections":        Warning,
+	}, problems(a.Findings))
+	assert.Equal(t, "ds1/ms1", a.Findings[0].Name)
+	assert.Contains(t, a.Findings[0].Explanation, "peaked at 15 of a capacity of 15")
+}
+
+func TestAnalyzeDataSourceTrend(t *testing.T) {
+	before := remy.DataSource{Name: "ds1", Instances: []remy.DataSourceInstance{
+		{Server: "ms1", LeakedConnectionCount: 3, ActiveConnectionsCurrentCount: 4},
+		{Server: "ms2", LeakedConnectionCount: 1},
+	}}
+	after := remy.DataSource{Name: "ds1", Instances: []remy.DataSourceInstance{
+		{Server: "ms1", LeakedConnectionCount: 5, ActiveConnectionsCurrentCount: 6},
+		{Server: "ms2", LeakedConnectionCount: 1},
+		{Server: "ms3"},
+	}}
+	a := AnalyzeDataSource(&before, &after, 30*time.Second)
+	assert.Equal(t, 2, *a.Instances[0].LeakedDelta)
+	assert.Equal(t, 2, *a.Instances[0].ActiveDelta)
+	assert.Equal(t, 0, *a.Instances[1].LeakedDelta)
+	assert.Nil(t, a.Instances[2].LeakedDelta, "an instance without an earlier sample has no trend")
+	assert.Equal(t, 2, *a.Total.LeakedDelta)
+
+	assert.Equal(t, Critical, a.Findings[0].Severity)
+	assert.Equal(t, "leaking connections", a.Findings[0].Problem)
+	assert.Contains(t, a.Findings[0].Explanation, "2 connections leaked in the last 30s")
+	assert.Equal(t, "leaked connections", a.Findings[1].Problem)
+
+	r := &DataSourceReport{DataSources: []DataSourceAnalysis{a}}
+	out := r.String()
+	assert.Contains(t, out, "Data source ds1 (3 instances)")
+	assert.Contains(t, out, "5 (+2)")
+	assert.Contains(t, out, "critical ds1/ms1: leaking connections")
+}
@@ -0,0 +1,48 @@
+// Package analysis derives metrics from the run-time statistics remy collects, and diagnoses likely problems with an
+// explanation of each, so the raw counters don't have to be read by eye.
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Severity is how urgently a Finding should be looked at.
+type Severity string
+
+// Severities of a Finding, from least to most urgent.
+const (
+	Info     Severity = "info"
+	Warning  Severity = "warning"
+	Critical Severity = "critical"
+)
+
+// Finding is a likely problem, with the evidence for it and what to do about it.
+type Finding struct {
+	Severity    Severity `json:"severity"`
+	Name        string   `json:"name"`
+	Problem     string   `json:"problem"`
+	Explanation string   `json:"explanation"`
+}
+
+// String formats the Finding for the console, e.g., "warning ms1: pool too small".
+func (f Finding) String() string {
+	return fmt.Sprintf("%v %v: %v", f.Severity, f.Name, f.Problem)
+}
+
+// ratio is part/whole, or nil when whole is zero and the ratio has no meaning.
+func ratio(part, whole int) *float64 {
+	if whole <= 0 {
+		return nil
+	}
+	r := float64(part) / float64(whole)
+	return &r
+}
+
+// percent formats a ratio as a percentage, or n/a when there is none.
+func percent(r *float64) string {
+	if r == nil {
+		return "n/a"
+	}
+	return strconv.FormatFloat(*r*100, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,227 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauern/remy"
+)
+
+// Thresholds used to diagnose Oracle RAC load balancing.
+const (
+	// MaxShareSkew is how far, in absolute terms, a RAC instance's share of connections may drift from its share of the
+	// load balancing weight before it is flagged.  With two equally weighted instances, 0.15 allows a 35/65 split.
+	MaxShareSkew = 0.15
+	// MaxBorrowFailureRate is the share of failed runtime connection load balancing or affinity borrows worth a warning.
+	MaxBorrowFailureRate = 0.1
+)
+
+// RACNode is a single Oracle RAC instance, with its statistics summed across every WebLogic server's pool.
+type RACNode struct {
+	Instance string `json:"instance"`
+	// States are the distinct states reported by each WebLogic server, e.g., ["UP"].
+	States []string `json:"states"`
+	// Enabled is false if any WebLogic server reports the instance as disabled.
+	Enabled bool `json:"enabled"`
+	Servers int  `json:"servers"`
+	// Weight is the average of the current load balancing weight each server was given.
+	Weight             float64  `json:"weight"`
+	WeightShare        *float64 `json:"weightShare"`
+	ActiveConnections  int      `json:"activeConnections"`
+	ConnectionShare    *float64 `json:"connectionShare"`
+	ConnectionsTotal   int      `json:"connectionsTotal"`
+	ReserveRequests    int      `json:"reserveRequests"`
+	Capacity           int      `json:"capacity"`
+	Available          int      `json:"available"`
+	Unavailable        int      `json:"unavailable"`
+	Availability       *float64 `json:"availability"`
+	weightSum, weights float64
+}
+
+// BorrowMetrics are the runtime connection load balancing (RCLB) and affinity borrow success rates of a WebLogic
+// server's GridLink pool, or of every pool.
+type BorrowMetrics struct {
+	Server              string   `json:"server"`
+	RCLBSuccessRate     *float64 `json:"rclbSuccessRate"`
+	AffinitySuccessRate *float64 `json:"affinitySuccessRate"`
+	successfulRCLB      int
+	failedRCLB          int
+	successfulAffinity  int
+	failedAffinity      int
+}
+
+// RACReport pivots the RAC statistics of a GridLink data source by RAC instance rather than WebLogic server.
+type RACReport struct {
+	DataSource string          `json:"datasource"`
+	Nodes      []RACNode       `json:"nodes"`
+	Borrows    []BorrowMetrics `json:"borrows"`
+	Total      BorrowMetrics   `json:"total"`
+	Findings   []Finding       `json:"findings"`
+}
+
+func newBorrowMetrics(server string, successfulRCLB, failedRCLB, successfulAffinity, failedAffinity int) BorrowMetrics {
+	return BorrowMetrics{
+		Server:              server,
+		RCLBSuccessRate:     ratio(successfulRCLB, successfulRCLB+failedRCLB),
+		AffinitySuccessRate: ratio(successfulAffinity, successfulAffinity+failedAffinity),
+		successfulRCLB:      successfulRCLB,
+		failedRCLB:          failedRCLB,
+		successfulAffinity:  successfulAffinity,
+		failedAffinity:      failedAffinity,
+	}
+}
+
+// AnalyzeRAC pivots every instance of the data source by RAC instance, working out each node's share of the load
+// balancing weight and of the connections, and flags disabled nodes, skewed shares and failing borrows.
+func AnalyzeRAC(ds *remy.DataSource) *RACReport {
+	r := &RACReport{DataSource: ds.Name, Nodes: []RACNode{}, Borrows: []BorrowMetrics{}, Findings: []Finding{}}
+	nodes := make(map[string]*RACNode)
+	var names []string
+	var rclbOK, rclbFailed, affinityOK, affinityFailed int
+	for _, inst := range ds.Instances {
+		b := newBorrowMetrics(inst.Server, inst.SuccessfulRCLBBasedBorrowCount, inst.FailedRCLBBasedBorrowCount,
+			inst.SuccessfulAffinityBasedBorrowCount, inst.FailedAffinityBasedBorrowCount)
+		r.Borrows = append(r.Borrows, b)
+		rclbOK += b.successfulRCLB
+		rclbFailed += b.failedRCLB
+		affinityOK += b.successfulAffinity
+		affinityFailed += b.failedAffinity
+
+		for _, rac := range inst.RacInstances {
+			n, ok := nodes[rac.InstanceName]
+			if !ok {
+				n = &RACNode{Instance: rac.InstanceName, Enabled: true}
+				nodes[rac.InstanceName] = n
+				names = append(names, rac.InstanceName)
+			}
+			n.Servers++
+			n.Enabled = n.Enabled && rac.Enabled
+			if rac.State != "" && !contains(n.States, rac.State) {
+				n.States = append(n.States, rac.State)
+			}
+			n.weightSum += float64(rac.CurrentWeight)
+			n.weights++
+			n.ActiveConnections += rac.ActiveConnectionsCurrentCount
+			n.ConnectionsTotal += rac.ConnectionsTotalCount
+			n.ReserveRequests += rac.ReserveRequestCount
+			n.Capacity += rac.CurrCapacity
+			n.Available += rac.NumAvailable
+			n.Unavailable += rac.NumUnavailable
+		}
+	}
+	r.Total = newBorrowMetrics("total", rclbOK, rclbFailed, affinityOK, affinityFailed)
+
+	sort.Strings(names)
+	var totalWeight float64
+	var totalActive int
+	for _, name := range names {
+		n := nodes[name]
+		n.Weight = n.weightSum / n.weights
+		totalWeight += n.Weight
+		totalActive += n.ActiveConnections
+	}
+	for _, name := range names {
+		n := nodes[name]
+		if totalWeight > 0 {
+			share := n.Weight / totalWeight
+			n.WeightShare = &share
+		}
+		n.ConnectionShare = ratio(n.ActiveConnections, totalActive)
+		n.Availability = ratio(n.Available, n.Capacity)
+		r.Nodes = append(r.Nodes, *n)
+	}
+	r.Findings = diagnoseRAC(r)
+	return r
+}
+
+func diagnoseRAC(r *RACReport) []Finding {
+	findings := []Finding{}
+	add := func(severity Severity, name, problem, explanation string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Name: name, Problem: problem, Explanation: fmt.Sprintf(explanation, args...)})
+	}
+	if len(r.Nodes) == 0 {
+		add(Info, r.DataSource, "no RAC instances",
+			"No WebLogic server reported Oracle RAC statistics.  Only GridLink data sources, viewed one at a time, have them.")
+	}
+	for _, n := range r.Nodes {
+		name := r.DataSource + "/" + n.Instance
+		if !n.Enabled {
+			add(Critical, name, "RAC instance disabled",
+				"At least one WebLogic server has disabled %v, so its connections are going to the remaining instances.  "+
+					"Check the database instance and the ONS events WebLogic received for it.", n.Instance)
+		}
+		for _, state := range n.States {
+			if !strings.EqualFold(state, "UP") {
+				add(Warning, name, "RAC instance not up", "%v is reported as %v by at least one WebLogic server.", n.Instance, state)
+			}
+		}
+		if n.WeightShare != nil && n.ConnectionShare != nil && math.Abs(*n.ConnectionShare-*n.WeightShare) > MaxShareSkew {
+			add(Warning, name, "skewed share of connections",
+				"%v holds %v of active connections but was given %v of the load balancing weight.  Runtime connection load "+
+					"balancing isn't keeping up; check FAN is enabled and the service's load balancing goal is set.",
+				n.Instance, percent(n.ConnectionShare), percent(n.WeightShare))
+		}
+	}
+	for _, b := range append(r.Borrows, r.Total) {
+		if b.Server == "total" && len(r.Borrows) <= 1 {
+			continue
+		}
+		name := r.DataSource + "/" + b.Server
+		if b.RCLBSuccessRate != nil && 1-*b.RCLBSuccessRate > MaxBorrowFailureRate {
+			add(Warning, name, "RCLB borrows failing",
+				"%v of %v runtime connection load balanced borrows failed, so connections were handed out without regard "+
+					"to instance load.", b.failedRCLB, b.successfulRCLB+b.failedRCLB)
+		}
+		if b.AffinitySuccessRate != nil && 1-*b.AffinitySuccessRate > MaxBorrowFailureRate {
+			add(Warning, name, "affinity borrows failing",
+				"%v of %v affinity based borrows failed to get a connection to the instance the session or transaction "+
+					"was bound to, which costs cross-instance traffic in the RAC interconnect.",
+				b.failedAffinity, b.successfulAffinity+b.failedAffinity)
+		}
+	}
+	return findings
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the report for the console: a table per RAC instance, the borrow success rates per WebLogic server,
+// then the problems found.
+func (r *RACReport) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("RAC instances of %v\n", r.DataSource))
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  INSTANCE\tSTATE\tENABLED\tSERVERS\tWEIGHT\tWEIGHT SHARE\tACTIVE\tCONN SHARE\tCAPACITY\tAVAILABLE\tRESERVES\t")
+	for _, n := range r.Nodes {
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%.1f\t%v\t%v\t%v\t%v\t%v (%v)\t%v\t\n", n.Instance, strings.Join(n.States, ","), n.Enabled,
+			n.Servers, n.Weight, percent(n.WeightShare), n.ActiveConnections, percent(n.ConnectionShare), n.Capacity,
+			n.Available, percent(n.Availability), n.ReserveRequests)
+	}
+	tw.Flush()
+
+	buffer.WriteString("Borrow success rates\n")
+	tw = tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  SERVER\tRCLB\tAFFINITY\t")
+	for _, b := range append(r.Borrows, r.Total) {
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t\n", b.Server, percent(b.RCLBSuccessRate), percent(b.AffinitySuccessRate))
+	}
+	tw.Flush()
+
+	if len(r.Findings) == 0 {
+		buffer.WriteString("No problems found\n")
+	}
+	for _, f := range r.Findings {
+		buffer.WriteString(fmt.Sprintf("%v\n    %v\n", f, f.Explanation))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func gridLink(rac1Active, rac2Active int, rac2Enabled bool) *remy.DataSource {
+	instance := func(server string) remy.DataSourceInstance {
+		return remy.DataSourceInstance{
+			Server:                             server,
+			SuccessfulRCLBBasedBorrowCount:     90,
+			FailedRCLBBasedBorrowCount:         10,
+			SuccessfulAffinityBasedBorrowCount: 100,
+			RacInstances: []remy.RacInstance{
+				{InstanceName: "orcl1", State: "UP", Enabled: true, CurrentWeight: 50, ActiveConnectionsCurrentCount: rac1Active, CurrCapacity: 10, NumAvailable: 8, ReserveRequestCount: 100},
+				{InstanceName: "orcl2", State: "UP", Enabled: rac2Enabled, CurrentWeight: 50, ActiveConnectionsCurrentCount: rac2Active, CurrCapacity: 10, NumAvailable: 8, ReserveRequestCount: 100},
+			},
+		}
+	}
+	return &remy.DataSource{Name: "gl", Type: "GridLink", Instances: []remy.DataSourceInstance{instance("ms1"), instance("ms2")}}
+}
+
+func TestAnalyzeRAC(t *testing.T) {
+	r := AnalyzeRAC(gridLink(5, 5, true))
+	assert.Len(t, r.Nodes, 2)
+	n := r.Nodes[0]
+	assert.Equal(t, "orcl1", n.Instance)
+	assert.Equal(t, []string{"UP"}, n.States)
+	assert.True(t, n.Enabled)
+	assert.Equal(t, 2, n.Servers)
+	assert.Equal(t, 50.0, n.Weight)
+	assert.Equal(t, 0.5, *n.WeightShare)
+	assert.Equal(t, 10, n.ActiveConnections)
+	assert.Equal(t, 0.5, *n.ConnectionShare)
+	assert.Equal(t, 20, n.Capacity)
+	assert.Equal(t, 0.8, *n.Availability)
+
+	assert.Equal(t, 0.9, *r.Borrows[0].RCLBSuccessRate)
+	assert.Equal(t, 1.0, *r.Total.AffinitySuccessRate)
+	assert.Empty(t, r.Findings)
+	assert.Contains(t, r.String(), "No problems found")
+}
+
+func TestAnalyzeRACProblems(t *testing.T) {
+	ds := gridLink(9, 1, false)
+	ds.Instances[1].FailedRCLBBasedBorrowCount = 60
+	r := AnalyzeRAC(ds)
+	assert.Equal(t, map[string]Severity{
+		"RAC instance disabled":       Critical,
+		"skewed share of connections": Warning,
+		"RCLB borrows failing":        Warning,
+	}, problems(r.Findings))
+	assert.Contains(t, r.String(), "critical gl/orcl2: RAC instance disabled")
+	assert.Contains(t, r.String(), "warning gl/orcl1: skewed share of connections")
+	assert.Contains(t, r.String(), "warning gl/ms2: RCLB borrows failing")
+}
+
+func TestAnalyzeRACWithoutRACInstances(t *testing.T) {
+	r := AnalyzeRAC(&remy.DataSource{Name: "ds1", Instances: []remy.DataSourceInstance{{Server: "ms1"}}})
+	assert.Empty(t, r.Nodes)
+	assert.Equal(t, "no RAC instances", r.Findings[0].Problem)
+}
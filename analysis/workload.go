@@ -0,0 +1,228 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/klauern/remy"
+)
+
+// Constraint types reported in a ConstraintLoad.
+const (
+	MinThreads = "min"
+	MaxThreads = "max"
+)
+
+// ConstraintLoad is the requests queued behind a thread constraint of an application, summed across the servers it is
+// deployed to.  Wait times are the longest on any server, in milliseconds.
+type ConstraintLoad struct {
+	Application     string `json:"application"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	Servers         int    `json:"servers"`
+	Executing       int    `json:"executing"`
+	Pending         int    `json:"pending"`
+	Deferred        int    `json:"deferred"`
+	CurrentWaitTime int    `json:"currentWaitTime"`
+	MaxWaitTime     int    `json:"maxWaitTime"`
+}
+
+// WorkManagerLoad is the requests handled by a work manager of an application, summed across servers.
+type WorkManagerLoad struct {
+	Application string `json:"application"`
+	Name        string `json:"name"`
+	Servers     int    `json:"servers"`
+	Pending     int    `json:"pending"`
+	Completed   int    `json:"completed"`
+}
+
+// RequestClassShare is the thread use of a request class of an application, summed across servers, and its share of
+// the thread use of every request class in the domain.
+type RequestClassShare struct {
+	Application    string   `json:"application"`
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Servers        int      `json:"servers"`
+	Pending        int      `json:"pending"`
+	Completed      int      `json:"completed"`
+	TotalThreadUse int      `json:"totalThreadUse"`
+	ThreadShare    *float64 `json:"threadShare"`
+}
+
+// WorkloadReport ranks the thread constraints and work managers of every application by the requests queued behind
+// them, shows each request class's share of thread use, and flags constraints holding work back.
+type WorkloadReport struct {
+	Constraints    []ConstraintLoad    `json:"constraints"`
+	WorkManagers   []WorkManagerLoad   `json:"workManagers"`
+	RequestClasses []RequestClassShare `json:"requestClasses"`
+	Findings       []Finding           `json:"findings"`
+}
+
+// AnalyzeWorkload aggregates the work manager, thread constraint and request class statistics of full-format
+// applications across every server they run on.  Constraints are ranked by pending and deferred requests, then by
+// current and maximum wait time; work managers by pending requests; and request classes by thread use.
+func AnalyzeWorkload(apps []remy.Application) *WorkloadReport {
+	r := &WorkloadReport{
+		Constraints:    []ConstraintLoad{},
+		WorkManagers:   []WorkManagerLoad{},
+		RequestClasses: []RequestClassShare{},
+		Findings:       []Finding{},
+	}
+	for _, app := range apps {
+		constraints := make(map[string]*ConstraintLoad)
+		constraint := func(name, kind string) *ConstraintLoad {
+			key := kind + "/" + name
+			if constraints[key] == nil {
+				constraints[key] = &ConstraintLoad{Application: app.Name, Name: name, Type: kind}
+			}
+			c := constraints[key]
+			c.Servers++
+			return c
+		}
+		for _, mtc := range app.MinThreadsConstraints {
+			c := constraint(mtc.Name, MinThreads)
+			c.Executing += mtc.ExecutingRequests
+			c.Pending += mtc.PendingRequests
+			c.CurrentWaitTime = maxInt(c.CurrentWaitTime, mtc.CurrentWaitTime)
+			c.MaxWaitTime = maxInt(c.MaxWaitTime, mtc.MaxWaitTime)
+		}
+		for _, mtc := range app.MaxThreadsConstraints {
+			c := constraint(mtc.Name, MaxThreads)
+			c.Executing += mtc.ExecutingRequests
+			c.Deferred += mtc.DeferredRequests
+		}
+		for _, c := range constraints {
+			r.Constraints = append(r.Constraints, *c)
+		}
+
+		workManagers := make(map[string]*WorkManagerLoad)
+		var wmNames []string
+		for _, wm := range app.WorkManagers {
+			w := workManagers[wm.Name]
+			if w == nil {
+				w = &WorkManagerLoad{Application: app.Name, Name: wm.Name}
+				workManagers[wm.Name] = w
+				wmNames = append(wmNames, wm.Name)
+			}
+			w.Servers++
+			w.Pending += wm.PendingRequests
+			w.Completed += wm.CompletedRequests
+		}
+		for _, name := range wmNames {
+			r.WorkManagers = append(r.WorkManagers, *workManagers[name])
+		}
+
+		classes := make(map[string]*RequestClassShare)
+		var classNames []string
+		for _, rc := range app.RequestClasses {
+			c := classes[rc.Name]
+			if c == nil {
+				c = &RequestClassShare{Application: app.Name, Name: rc.Name, Type: rc.RequestClassType}
+				classes[rc.Name] = c
+				classNames = append(classNames, rc.Name)
+			}
+			c.Servers++
+			c.Pending += rc.PendingRequestCount
+			c.Completed += rc.CompletedCount
+			c.TotalThreadUse += rc.TotalThreadUse
+		}
+		for _, name := range classNames {
+			r.RequestClasses = append(r.RequestClasses, *classes[name])
+		}
+	}
+
+	totalThreadUse := 0
+	for _, c := range r.RequestClasses {
+		totalThreadUse += c.TotalThreadUse
+	}
+	for i := range r.RequestClasses {
+		r.RequestClasses[i].ThreadShare = ratio(r.RequestClasses[i].TotalThreadUse, totalThreadUse)
+	}
+
+	sort.SliceStable(r.Constraints, func(i, j int) bool {
+		a, b := r.Constraints[i], r.Constraints[j]
+		switch {
+		case a.Pending+a.Deferred != b.Pending+b.Deferred:
+			return a.Pending+a.Deferred > b.Pending+b.Deferred
+		case a.CurrentWaitTime != b.CurrentWaitTime:
+			return a.CurrentWaitTime > b.CurrentWaitTime
+		case a.MaxWaitTime != b.MaxWaitTime:
+			return a.MaxWaitTime > b.MaxWaitTime
+		case a.Application != b.Application:
+			return a.Application < b.Application
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		}
+		return a.Type < b.Type
+	})
+	sort.SliceStable(r.WorkManagers, func(i, j int) bool {
+		return r.WorkManagers[i].Pending > r.WorkManagers[j].Pending
+	})
+	sort.SliceStable(r.RequestClasses, func(i, j int) bool {
+		return r.RequestClasses[i].TotalThreadUse > r.RequestClasses[j].TotalThreadUse
+	})
+
+	for _, c := range r.Constraints {
+		if c.Type == MaxThreads && c.Deferred > 0 {
+			r.Findings = append(r.Findings, Finding{
+				Severity: Warning,
+				Name:     c.Application + "/" + c.Name,
+				Problem:  "max threads constraint deferring work",
+				Explanation: fmt.Sprintf("%v requests are deferred while %v execute across %v servers.  The constraint is "+
+					"holding work back; raise its Count, or the capacity of the resource it guards, such as the size of "+
+					"the connection pool it is tied to.", c.Deferred, c.Executing, c.Servers),
+			})
+		}
+	}
+	return r
+}
+
+// maxInt is the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// String formats the report for the console: tables of thread constraints, work managers and request classes, then
+// the problems found.
+func (r *WorkloadReport) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("Thread constraints\n")
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  APPLICATION\tCONSTRAINT\tTYPE\tSERVERS\tEXECUTING\tPENDING\tDEFERRED\tCURRENT WAIT\tMAX WAIT\t")
+	for _, c := range r.Constraints {
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t%v\t%vms\t%vms\t\n", c.Application, c.Name, c.Type, c.Servers,
+			c.Executing, c.Pending, c.Deferred, c.CurrentWaitTime, c.MaxWaitTime)
+	}
+	tw.Flush()
+
+	buffer.WriteString("\nWork managers\n")
+	tw = tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  APPLICATION\tWORK MANAGER\tSERVERS\tPENDING\tCOMPLETED\t")
+	for _, w := range r.WorkManagers {
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t\n", w.Application, w.Name, w.Servers, w.Pending, w.Completed)
+	}
+	tw.Flush()
+
+	buffer.WriteString("\nRequest classes\n")
+	tw = tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  APPLICATION\tREQUEST CLASS\tTYPE\tSERVERS\tPENDING\tCOMPLETED\tTHREAD USE\tSHARE\t")
+	for _, c := range r.RequestClasses {
+		fmt.Fprintf(tw, "  %v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", c.Application, c.Name, c.Type, c.Servers, c.Pending,
+			c.Completed, c.TotalThreadUse, percent(c.ThreadShare))
+	}
+	tw.Flush()
+
+	buffer.WriteString("\n")
+	if len(r.Findings) == 0 {
+		buffer.WriteString("No problems found\n")
+	}
+	for _, f := range r.Findings {
+		buffer.WriteString(fmt.Sprintf("%v\n    %v\n", f, f.Explanation))
+	}
+	return buffer.String()
+}
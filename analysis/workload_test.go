@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeWorkload(t *testing.T) {
+	apps := []remy.Application{
+		{
+			Name: "app1",
+			MinThreadsConstraints: []remy.MinThreadsConstraint{
+				{Name: "min", Server: "ms1", PendingRequests: 2, ExecutingRequests: 1, CurrentWaitTime: 40, MaxWaitTime: 100},
+				{Name: "min", Server: "ms2", PendingRequests: 3, ExecutingRequests: 1, CurrentWaitTime: 10, MaxWaitTime: 300},
+			},
+			MaxThreadsConstraints: []remy.MaxThreadsConstraint{
+				{Name: "max", Server: "ms1", ExecutingRequests: 10, DeferredRequests: 7},
+				{Name: "max", Server: "ms2", ExecutingRequests: 10, DeferredRequests: 1},
+			},
+			WorkManagers: []remy.WorkManager{
+				{Name: "default", Server: "ms1", PendingRequests: 1, CompletedRequests: 100},
+				{Name: "default", Server: "ms2", PendingRequests: 0, CompletedRequests: 50},
+			},
+			RequestClasses: []remy.RequestClass{
+				{Name: "fair", Server: "ms1", RequestClassType: "fairshare", TotalThreadUse: 100, CompletedCount: 10},
+				{Name: "fair", Server: "ms2", RequestClassType: "fairshare", TotalThreadUse: 50, CompletedCount: 5},
+			},
+		},
+		{
+			Name:                  "app2",
+			MaxThreadsConstraints: []remy.MaxThreadsConstraint{{Name: "idle", Server: "ms1", ExecutingRequests: 1}},
+			WorkManagers:          []remy.WorkManager{{Name: "busy", Server: "ms1", PendingRequests: 9}},
+			RequestClasses:        []remy.RequestClass{{Name: "bulk", Server: "ms1", TotalThreadUse: 50}},
+		},
+	}
+	r := AnalyzeWorkload(apps)
+
+	assert.Equal(t, []ConstraintLoad{
+		{Application: "app1", Name: "max", Type: MaxThreads, Servers: 2, Executing: 20, Deferred: 8},
+		{Application: "app1", Name: "min", Type: MinThreads, Servers: 2, Executing: 2, Pending: 5, CurrentWaitTime: 40, MaxWaitTime: 300},
+		{Application: "app2", Name: "idle", Type: MaxThreads, Servers: 1, Executing: 1},
+	}, r.Constraints)
+
+	assert.Len(t, r.WorkManagers, 2)
+	assert.Equal(t, "busy", r.WorkManagers[0].Name)
+	assert.Equal(t, WorkManagerLoad{Application: "app1", Name: "default", Servers: 2, Pending: 1, Completed: 150}, r.WorkManagers[1])
+
+	assert.Len(t, r.RequestClasses, 2)
+	assert.Equal(t, "fair", r.RequestClasses[0].Name)
+	assert.Equal(t, 150, r.RequestClasses[0].TotalThreadUse)
+	assert.Equal(t, 0.75, *r.RequestClasses[0].ThreadShare)
+	assert.Equal(t, 0.25, *r.RequestClasses[1].ThreadShare)
+
+	assert.Len(t, r.Findings, 1)
+	assert.Equal(t, "warning app1/max: max threads constraint deferring work", r.Findings[0].String())
+	assert.Contains(t, r.String(), "app1         max         max   2        20         0        8")
+}
+
+func TestAnalyzeWorkloadEmpty(t *testing.T) {
+	r := AnalyzeWorkload([]remy.Application{{Name: "app1"}})
+	assert.Empty(t, r.Constraints)
+	assert.Empty(t, r.Findings)
+	assert.Contains(t, r.String(), "No problems found")
+}
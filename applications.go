@@ -2,6 +2,7 @@ package remy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -151,17 +152,31 @@ func (a *Application) GoString() string {
 }
 
 // Applications returns all applications deployed in the domain and their run-time information, including the application type and their state and health.
-// - isfullFormat specifies whether to request the FULL format for an Application.  Much more data is brought back for
-//   each of the subytpes within an Application.  By default, this is false.
+// - opts.Full specifies whether to request the FULL format for an Application.  Much more data is brought back for
+//   each of the subytpes within an Application.  By default, this is false.  opts.ExcludeFields can leave the
+//   heaviest of them, such as WorkManagers, out.
 //
-// This function returns a listing of []Application's on the specified AdminServer, or an error denoting any issues
+// This function returns a listing of []Application's on the Client's AdminServer, or an error denoting any issues
 // making the callout.
-func (a *AdminServer) Applications(isFullFormat bool) ([]Application, error) {
-	url := fmt.Sprintf("%v%v/applications", a.AdminURL, MonitorPath)
-	if isFullFormat {
+func (c *Client) Applications(ctx context.Context, opts QueryOptions) (list []Application, err error) {
+	ctx, span := c.startSpan(ctx, "Applications", "applications", "")
+	defer func() { endSpan(span, err) }()
+	if opts, err = opts.resolve(Application{}); err != nil {
+		return nil, err
+	}
+	defer func() { opts.apply(&list) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeApplications(ctx, opts)
+	}
+	url := c.resourceURL("applications")
+	if opts.Full {
 		url = url + "?format=full"
 	}
-	w, err := requestAndUnmarshal(url, a)
+	w, err := c.requestAndUnmarshal(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -173,12 +188,19 @@ func (a *AdminServer) Applications(isFullFormat bool) ([]Application, error) {
 }
 
 // Application returns the run-time information of a specified application, including statistics for entity beans, application-scoped work managers, and data sources.
-// on how to get all of the []Application's on the server.
 // This will always return a full format, including all of the details in the underlying struct types.
 // It may also return an error if there were any issues calling out to the AdminServer
-func (a *AdminServer) Application(app string) (*Application, error) {
-	url := fmt.Sprintf("%v%v/applications/%v", a.AdminURL, MonitorPath, app)
-	w, err := requestAndUnmarshal(url, a)
+func (c *Client) Application(ctx context.Context, app string) (_ *Application, err error) {
+	ctx, span := c.startSpan(ctx, "Application", "applications", app)
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeApplication(ctx, app)
+	}
+	w, err := c.requestAndUnmarshal(ctx, c.resourceURL("applications", app))
 	if err != nil {
 		return nil, err
 	}
@@ -188,3 +210,16 @@ func (a *AdminServer) Application(app string) (*Application, error) {
 	}
 	return &application, nil
 }
+
+// Applications returns all applications deployed in the domain using a default Client.  See Client.Applications.
+//
+// Deprecated: Applications only chooses between the short and full format.  Use Client.Applications, whose QueryOptions
+// also select fields and page through the applications.
+func (a *AdminServer) Applications(isFullFormat bool) ([]Application, error) {
+	return NewClient(a).Applications(context.Background(), QueryOptions{Full: isFullFormat})
+}
+
+// Application returns the run-time information of a specified application using a default Client.  See Client.Application.
+func (a *AdminServer) Application(app string) (*Application, error) {
+	return NewClient(a).Application(context.Background(), app)
+}
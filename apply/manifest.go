@@ -0,0 +1,98 @@
+// Package apply reconciles a domain with a declarative manifest of its servers, JDBC data sources and application
+// deployments, along the lines of kubectl apply: a Plan of the creates, updates and deletes needed is computed against
+// the activated configuration, then carried out inside a single WebLogic edit session.
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Manifest is the desired configuration of a domain.  Only the resources listed are created or updated, and fields
+// left out are left as they are.  With pruning, resources of a kind listed in the Manifest but missing from it are
+// deleted; the AdminServer is never deleted.
+type Manifest struct {
+	Servers      []ServerSpec      `yaml:"servers,omitempty"`
+	DataSources  []DataSourceSpec  `yaml:"datasources,omitempty"`
+	Applications []ApplicationSpec `yaml:"applications,omitempty"`
+}
+
+// ServerSpec is a managed server.  An empty Cluster takes the server out of its cluster.
+type ServerSpec struct {
+	Name       string  `yaml:"name"`
+	Cluster    *string `yaml:"cluster,omitempty"`
+	ListenPort *int    `yaml:"listenPort,omitempty"`
+}
+
+// DataSourceSpec is a JDBC data source.  URL and Driver are required to create one.  Targets are server or cluster
+// names.
+type DataSourceSpec struct {
+	Name            string   `yaml:"name"`
+	URL             string   `yaml:"url,omitempty"`
+	Driver          string   `yaml:"driver,omitempty"`
+	JNDINames       []string `yaml:"jndiNames,omitempty"`
+	InitialCapacity *int     `yaml:"initialCapacity,omitempty"`
+	MinCapacity     *int     `yaml:"minCapacity,omitempty"`
+	MaxCapacity     *int     `yaml:"maxCapacity,omitempty"`
+	Targets         []string `yaml:"targets,omitempty"`
+}
+
+// ApplicationSpec is an application deployment.  SourcePath, a path on the AdminServer, is required to create one.
+// Targets are server or cluster names.
+type ApplicationSpec struct {
+	Name       string   `yaml:"name"`
+	SourcePath string   `yaml:"sourcePath,omitempty"`
+	Targets    []string `yaml:"targets,omitempty"`
+}
+
+// Load reads a Manifest from a YAML file.
+func Load(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, fmt.Errorf("unable to read manifest %v: %v", path, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %v: %v", path, err)
+	}
+	return &m, nil
+}
+
+// validate checks every resource is named, and named only once.
+func (m *Manifest) validate() error {
+	check := func(resource string, names []string) error {
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("every %v needs a name", resource)
+			}
+			if seen[name] {
+				return fmt.Errorf("%v %v is listed more than once", resource, name)
+			}
+			seen[name] = true
+		}
+		return nil
+	}
+	var servers, dataSources, applications []string
+	for _, s := range m.Servers {
+		servers = append(servers, s.Name)
+	}
+	for _, ds := range m.DataSources {
+		dataSources = append(dataSources, ds.Name)
+	}
+	for _, app := range m.Applications {
+		applications = append(applications, app.Name)
+	}
+	if err := check(ServerResource, servers); err != nil {
+		return err
+	}
+	if err := check(DataSourceResource, dataSources); err != nil {
+		return err
+	}
+	return check(ApplicationResource, applications)
+}
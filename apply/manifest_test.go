@@ -0,0 +1,53 @@
+package apply
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remy-apply")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "domain.yaml")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+servers:
+  - name: ms3
+    cluster: cluster1
+    listenPort: 7005
+  - name: ms4
+    cluster: ""
+datasources:
+  - name: ds2
+    url: jdbc:oracle:thin:@db:1521/ORCL
+    driver: oracle.jdbc.OracleDriver
+    maxCapacity: 30
+    targets: [cluster1]
+`), 0644))
+	m, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster1", *m.Servers[0].Cluster)
+	assert.Equal(t, 7005, *m.Servers[0].ListenPort)
+	assert.Equal(t, "", *m.Servers[1].Cluster)
+	assert.Nil(t, m.Servers[1].ListenPort)
+	assert.Equal(t, 30, *m.DataSources[0].MaxCapacity)
+	assert.Nil(t, m.DataSources[0].MinCapacity)
+	assert.Nil(t, m.Applications)
+
+	for _, invalid := range []string{
+		"servers:\n  - name: ms1\n  - name: ms1\n",
+		"servers:\n  - cluster: cluster1\n",
+		"server:\n  - name: ms1\n",
+	} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(invalid), 0644))
+		_, err = Load(path)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,408 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klauern/remy"
+)
+
+// Op is what an Action does to a resource.
+type Op string
+
+// The operations a Plan is made of.  Replace deletes and re-creates a resource that can't be changed in place, such
+// as a deployment whose source path moved.
+const (
+	Create  Op = "create"
+	Update  Op = "update"
+	Replace Op = "replace"
+	Delete  Op = "delete"
+)
+
+// Resources a Manifest configures.
+const (
+	ServerResource      = "server"
+	DataSourceResource  = "datasource"
+	ApplicationResource = "application"
+)
+
+// FieldChange is the change to a single field of a resource.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// Action is a single change to the domain.  The configuration to create or update the resource with is held in the
+// field for its Resource.
+type Action struct {
+	Op       Op            `json:"op"`
+	Resource string        `json:"resource"`
+	Name     string        `json:"name"`
+	Changes  []FieldChange `json:"changes,omitempty"`
+
+	Server     *remy.ServerConfig     `json:"-"`
+	DataSource *remy.DataSourceConfig `json:"-"`
+	Deployment *remy.DeploymentConfig `json:"-"`
+}
+
+// Plan is the Actions needed to bring a domain in line with a Manifest, in the order they are applied: servers,
+// data sources and deployments are created and updated first, then deployments, data sources and servers deleted.
+type Plan struct {
+	Actions []Action `json:"actions"`
+}
+
+// NewPlan compares the Manifest to the current configuration of the domain.  With prune, resources of each kind
+// listed in the Manifest that it leaves out are deleted.
+func NewPlan(m *Manifest, current *remy.DomainConfig, prune bool) (*Plan, error) {
+	p := &Plan{Actions: []Action{}}
+	resolve, err := targetResolver(m, current)
+	if err != nil {
+		return nil, err
+	}
+	p.planServers(m, current)
+	if err := p.planDataSources(m, current, resolve); err != nil {
+		return nil, err
+	}
+	if err := p.planApplications(m, current, resolve); err != nil {
+		return nil, err
+	}
+	if prune {
+		p.prune(m, current)
+	}
+	return p, nil
+}
+
+// targetResolver returns a function finding the Identity of a target name: a cluster, or a server that exists or
+// that the Manifest creates.
+func targetResolver(m *Manifest, current *remy.DomainConfig) (func(names []string) ([]remy.Identity, error), error) {
+	clusters := make(map[string]bool)
+	for _, c := range current.Clusters {
+		clusters[c] = true
+	}
+	servers := make(map[string]bool)
+	for _, s := range current.Servers {
+		servers[s.Name] = true
+	}
+	for _, s := range m.Servers {
+		servers[s.Name] = true
+		if s.Cluster != nil && *s.Cluster != "" && !clusters[*s.Cluster] {
+			return nil, fmt.Errorf("server %v: cluster %v does not exist", s.Name, *s.Cluster)
+		}
+	}
+	return func(names []string) ([]remy.Identity, error) {
+		ids := []remy.Identity{}
+		for _, name := range names {
+			switch {
+			case clusters[name]:
+				ids = append(ids, remy.ClusterIdentity(name))
+			case servers[name]:
+				ids = append(ids, remy.ServerIdentity(name))
+			default:
+				return nil, fmt.Errorf("target %v is not a server or cluster", name)
+			}
+		}
+		return ids, nil
+	}, nil
+}
+
+func (p *Plan) planServers(m *Manifest, current *remy.DomainConfig) {
+	existing := make(map[string]remy.ServerConfig)
+	for _, s := range current.Servers {
+		existing[s.Name] = s
+	}
+	for _, spec := range m.Servers {
+		have, exists := existing[spec.Name]
+		want := remy.ServerConfig{Name: spec.Name, ListenPort: have.ListenPort, Cluster: have.Cluster}
+		if spec.ListenPort != nil {
+			want.ListenPort = *spec.ListenPort
+		}
+		if spec.Cluster != nil {
+			want.Cluster = nil
+			if *spec.Cluster != "" {
+				want.Cluster = remy.ClusterIdentity(*spec.Cluster)
+			}
+		}
+		var changes changeList
+		changes.add("listenPort", port(have.ListenPort), port(want.ListenPort))
+		changes.add("cluster", have.Cluster.Name(), want.Cluster.Name())
+		p.add(exists, false, ServerResource, spec.Name, changes, Action{Server: &want})
+	}
+}
+
+func (p *Plan) planDataSources(m *Manifest, current *remy.DomainConfig, resolve func([]string) ([]remy.Identity, error)) error {
+	existing := make(map[string]remy.DataSourceConfig)
+	for _, ds := range current.DataSources {
+		existing[ds.Name] = ds
+	}
+	for _, spec := range m.DataSources {
+		have, exists := existing[spec.Name]
+		if !exists && (spec.URL == "" || spec.Driver == "") {
+			return fmt.Errorf("datasource %v: url and driver are required to create it", spec.Name)
+		}
+		want := have
+		want.Name = spec.Name
+		if !exists {
+			// WebLogic's defaults for a new data source
+			want.InitialCapacity, want.MinCapacity, want.MaxCapacity = 1, 1, 15
+		}
+		if spec.URL != "" {
+			want.URL = spec.URL
+		}
+		if spec.Driver != "" {
+			want.DriverName = spec.Driver
+		}
+		if spec.JNDINames != nil {
+			want.JNDINames = spec.JNDINames
+		}
+		if spec.InitialCapacity != nil {
+			want.InitialCapacity = *spec.InitialCapacity
+		}
+		if spec.MinCapacity != nil {
+			want.MinCapacity = *spec.MinCapacity
+		}
+		if spec.MaxCapacity != nil {
+			want.MaxCapacity = *spec.MaxCapacity
+		}
+		if spec.Targets != nil {
+			targets, err := resolve(spec.Targets)
+			if err != nil {
+				return fmt.Errorf("datasource %v: %v", spec.Name, err)
+			}
+			want.Targets = targets
+		}
+		var changes changeList
+		changes.add("url", have.URL, want.URL)
+		changes.add("driver", have.DriverName, want.DriverName)
+		changes.add("jndiNames", list(have.JNDINames), list(want.JNDINames))
+		changes.add("initialCapacity", capacity(exists, have.InitialCapacity), capacity(true, want.InitialCapacity))
+		changes.add("minCapacity", capacity(exists, have.MinCapacity), capacity(true, want.MinCapacity))
+		changes.add("maxCapacity", capacity(exists, have.MaxCapacity), capacity(true, want.MaxCapacity))
+		changes.add("targets", targetList(have.Targets), targetList(want.Targets))
+		p.add(exists, false, DataSourceResource, spec.Name, changes, Action{DataSource: &want})
+	}
+	return nil
+}
+
+func (p *Plan) planApplications(m *Manifest, current *remy.DomainConfig, resolve func([]string) ([]remy.Identity, error)) error {
+	existing := make(map[string]remy.DeploymentConfig)
+	for _, d := range current.Deployments {
+		existing[d.Name] = d
+	}
+	for _, spec := range m.Applications {
+		have, exists := existing[spec.Name]
+		if !exists && spec.SourcePath == "" {
+			return fmt.Errorf("application %v: sourcePath is required to deploy it", spec.Name)
+		}
+		want := have
+		want.Name = spec.Name
+		if spec.SourcePath != "" {
+			want.SourcePath = spec.SourcePath
+		}
+		if spec.Targets != nil {
+			targets, err := resolve(spec.Targets)
+			if err != nil {
+				return fmt.Errorf("application %v: %v", spec.Name, err)
+			}
+			want.Targets = targets
+		}
+		var changes changeList
+		changes.add("sourcePath", have.SourcePath, want.SourcePath)
+		changes.add("targets", targetList(have.Targets), targetList(want.Targets))
+		p.add(exists, have.SourcePath != want.SourcePath, ApplicationResource, spec.Name, changes, Action{Deployment: &want})
+	}
+	return nil
+}
+
+// add adds a Create for a resource that doesn't exist, and an Update (or Replace) for one that does when anything
+// changed.  a holds the configuration for the Action.
+func (p *Plan) add(exists, replace bool, resource, name string, changes changeList, a Action) {
+	a.Resource, a.Name, a.Changes = resource, name, changes
+	switch {
+	case !exists:
+		a.Op = Create
+	case len(changes) == 0:
+		return
+	case replace:
+		a.Op = Replace
+	default:
+		a.Op = Update
+	}
+	p.Actions = append(p.Actions, a)
+}
+
+// prune deletes the deployments, data sources and servers missing from a Manifest that lists their kind.
+func (p *Plan) prune(m *Manifest, current *remy.DomainConfig) {
+	if m.Applications != nil {
+		listed := make(map[string]bool)
+		for _, spec := range m.Applications {
+			listed[spec.Name] = true
+		}
+		for _, d := range current.Deployments {
+			if !listed[d.Name] {
+				p.Actions = append(p.Actions, Action{Op: Delete, Resource: ApplicationResource, Name: d.Name})
+			}
+		}
+	}
+	if m.DataSources != nil {
+		listed := make(map[string]bool)
+		for _, spec := range m.DataSources {
+			listed[spec.Name] = true
+		}
+		for _, ds := range current.DataSources {
+			if !listed[ds.Name] {
+				p.Actions = append(p.Actions, Action{Op: Delete, Resource: DataSourceResource, Name: ds.Name})
+			}
+		}
+	}
+	if m.Servers != nil {
+		listed := map[string]bool{current.AdminServerName: true}
+		for _, spec := range m.Servers {
+			listed[spec.Name] = true
+		}
+		for _, s := range current.Servers {
+			if !listed[s.Name] {
+				p.Actions = append(p.Actions, Action{Op: Delete, Resource: ServerResource, Name: s.Name})
+			}
+		}
+	}
+}
+
+// Empty reports whether the domain already matches the Manifest.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Count is the number of Actions with the given Op.
+func (p *Plan) Count(op Op) int {
+	n := 0
+	for _, a := range p.Actions {
+		if a.Op == op {
+			n++
+		}
+	}
+	return n
+}
+
+// String formats the Plan for the console as a diff: + for creates, ~ for updates, -/+ for replacements and - for
+// deletes, with the fields changed listed below each.
+func (p *Plan) String() string {
+	var buffer bytes.Buffer
+	if p.Empty() {
+		buffer.WriteString("No changes, the domain matches the manifest\n")
+		return buffer.String()
+	}
+	symbols := map[Op]string{Create: "+", Update: "~", Replace: "-/+", Delete: "-"}
+	for _, a := range p.Actions {
+		buffer.WriteString(fmt.Sprintf("%v %v %v\n", symbols[a.Op], a.Resource, a.Name))
+		for _, c := range a.Changes {
+			buffer.WriteString(fmt.Sprintf("    %v: %q -> %q\n", c.Field, c.From, c.To))
+		}
+	}
+	buffer.WriteString(fmt.Sprintf("Plan: %v to create, %v to update, %v to replace, %v to delete\n",
+		p.Count(Create), p.Count(Update), p.Count(Replace), p.Count(Delete)))
+	return buffer.String()
+}
+
+// Apply carries out the Plan inside a single edit session, activating it only when every Action succeeded.  On any
+// error the edit session is cancelled, leaving the domain unchanged.
+func Apply(ctx context.Context, client *remy.Client, p *Plan) error {
+	if p.Empty() {
+		return nil
+	}
+	if err := client.StartEdit(ctx); err != nil {
+		return fmt.Errorf("unable to start edit session: %v", err)
+	}
+	for _, a := range p.Actions {
+		if err := a.apply(ctx, client); err != nil {
+			return cancelEdit(ctx, client, fmt.Sprintf("unable to %v %v %v", a.Op, a.Resource, a.Name), err)
+		}
+	}
+	if err := client.Activate(ctx); err != nil {
+		return cancelEdit(ctx, client, "unable to activate changes", err)
+	}
+	return nil
+}
+
+// cancelEdit cancels the edit session after failing to make a change, returning the failure, and the failure to cancel,
+// if any, as the session then stays locked on the AdminServer.
+func cancelEdit(ctx context.Context, client *remy.Client, failed string, err error) error {
+	if cancelErr := client.CancelEdit(ctx); cancelErr != nil {
+		return fmt.Errorf("%v: %v; unable to cancel the edit session, which is still locked: %v", failed, err, cancelErr)
+	}
+	return fmt.Errorf("%v, cancelled edit session: %v", failed, err)
+}
+
+func (a *Action) apply(ctx context.Context, client *remy.Client) error {
+	if a.Op == Replace {
+		del, create := *a, *a
+		del.Op, create.Op = Delete, Create
+		if err := del.apply(ctx, client); err != nil {
+			return err
+		}
+		return create.apply(ctx, client)
+	}
+	switch a.Resource + " " + string(a.Op) {
+	case ServerResource + " " + string(Create):
+		return client.CreateServer(ctx, *a.Server)
+	case ServerResource + " " + string(Update):
+		return client.UpdateServer(ctx, *a.Server)
+	case ServerResource + " " + string(Delete):
+		return client.DeleteServer(ctx, a.Name)
+	case DataSourceResource + " " + string(Create):
+		return client.CreateDataSource(ctx, *a.DataSource)
+	case DataSourceResource + " " + string(Update):
+		return client.UpdateDataSource(ctx, *a.DataSource)
+	case DataSourceResource + " " + string(Delete):
+		return client.DeleteDataSource(ctx, a.Name)
+	case ApplicationResource + " " + string(Create):
+		return client.CreateDeployment(ctx, *a.Deployment)
+	case ApplicationResource + " " + string(Update):
+		return client.UpdateDeployment(ctx, *a.Deployment)
+	case ApplicationResource + " " + string(Delete):
+		return client.DeleteDeployment(ctx, a.Name)
+	}
+	return fmt.Errorf("unknown action %v %v", a.Op, a.Resource)
+}
+
+// changeList collects the fields that differ.
+type changeList []FieldChange
+
+func (c *changeList) add(field, from, to string) {
+	if from != to {
+		*c = append(*c, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func port(p int) string {
+	if p == 0 {
+		return ""
+	}
+	return strconv.Itoa(p)
+}
+
+// capacity formats a pool size, blank when the data source doesn't exist yet.
+func capacity(exists bool, n int) string {
+	if !exists {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func list(items []string) string {
+	sorted := append([]string(nil), items...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func targetList(ids []remy.Identity) string {
+	var names []string
+	for _, id := range ids {
+		names = append(names, id.Name())
+	}
+	return list(names)
+}
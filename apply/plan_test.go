@@ -0,0 +1,143 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func intp(i int) *int {
+	return &i
+}
+
+func strp(s string) *string {
+	return &s
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	current := remytest.ConfigFor(remytest.DefaultDomain())
+	m := &Manifest{
+		Servers:      []ServerSpec{{Name: "ms1", Cluster: strp("cluster1")}},
+		DataSources:  []DataSourceSpec{{Name: "ds1", MaxCapacity: intp(15), Targets: []string{"cluster1"}}},
+		Applications: []ApplicationSpec{{Name: "app1", Targets: []string{"cluster1"}}},
+	}
+	p, err := NewPlan(m, &current, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []Action{{Op: Delete, Resource: ServerResource, Name: "ms2"}}, p.Actions, "only the unlisted ms2 should be pruned, never the AdminServer")
+
+	p, err = NewPlan(m, &current, false)
+	assert.NoError(t, err)
+	assert.True(t, p.Empty())
+	assert.Contains(t, p.String(), "No changes")
+}
+
+func TestPlan(t *testing.T) {
+	current := remytest.ConfigFor(remytest.DefaultDomain())
+	m := &Manifest{
+		Servers: []ServerSpec{{Name: "ms2", Cluster: strp("")}, {Name: "ms3", Cluster: strp("cluster1"), ListenPort: intp(7010)}},
+		DataSources: []DataSourceSpec{
+			{Name: "ds1", MaxCapacity: intp(30)},
+			{Name: "ds2", URL: "jdbc:x", Driver: "d", Targets: []string{"ms3"}},
+		},
+		Applications: []ApplicationSpec{{Name: "app1", SourcePath: "/u01/apps/app1-v2.ear"}},
+	}
+	p, err := NewPlan(m, &current, false)
+	assert.NoError(t, err)
+	assert.Len(t, p.Actions, 5)
+
+	assert.Equal(t, Update, p.Actions[0].Op)
+	assert.Equal(t, []FieldChange{{Field: "cluster", From: "cluster1"}}, p.Actions[0].Changes)
+	assert.Nil(t, p.Actions[0].Server.Cluster)
+	assert.Equal(t, 7003, p.Actions[0].Server.ListenPort, "listen port should be left as it is")
+
+	assert.Equal(t, Create, p.Actions[1].Op)
+	assert.Equal(t, "ms3", p.Actions[1].Name)
+
+	assert.Equal(t, Update, p.Actions[2].Op)
+	assert.Equal(t, []FieldChange{{Field: "maxCapacity", From: "15", To: "30"}}, p.Actions[2].Changes)
+	assert.Equal(t, "jdbc:oracle:thin:@db:1521/ORCL", p.Actions[2].DataSource.URL)
+
+	assert.Equal(t, Create, p.Actions[3].Op)
+	assert.Equal(t, []remy.Identity{remy.ServerIdentity("ms3")}, p.Actions[3].DataSource.Targets)
+	assert.Equal(t, 15, p.Actions[3].DataSource.MaxCapacity)
+
+	assert.Equal(t, Replace, p.Actions[4].Op)
+
+	out := p.String()
+	assert.Contains(t, out, "~ server ms2\n    cluster: \"cluster1\" -> \"\"\n")
+	assert.Contains(t, out, "+ server ms3\n")
+	assert.Contains(t, out, "-/+ application app1\n")
+	assert.Contains(t, out, "Plan: 2 to create, 2 to update, 1 to replace, 0 to delete")
+}
+
+func TestPlanErrors(t *testing.T) {
+	current := remytest.ConfigFor(remytest.DefaultDomain())
+	for _, m := range []*Manifest{
+		{Servers: []ServerSpec{{Name: "ms3", Cluster: strp("cluster9")}}},
+		{DataSources: []DataSourceSpec{{Name: "ds2", URL: "jdbc:x"}}},
+		{DataSources: []DataSourceSpec{{Name: "ds1", Targets: []string{"nowhere"}}}},
+		{Applications: []ApplicationSpec{{Name: "app2"}}},
+	} {
+		_, err := NewPlan(m, &current, false)
+		assert.Error(t, err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+	ctx := context.Background()
+
+	m := &Manifest{
+		Servers:      []ServerSpec{{Name: "ms1"}, {Name: "ms3", Cluster: strp("cluster1")}},
+		DataSources:  []DataSourceSpec{{Name: "ds1", MaxCapacity: intp(30)}},
+		Applications: []ApplicationSpec{{Name: "app1", SourcePath: "/u01/apps/app1-v2.ear", Targets: []string{"ms3"}}},
+	}
+	current, err := client.Configuration(ctx)
+	assert.NoError(t, err)
+	p, err := NewPlan(m, current, true)
+	assert.NoError(t, err)
+	assert.NoError(t, Apply(ctx, client, p))
+
+	cfg := fake.Config()
+	assert.Equal(t, []string{"AdminServer", "ms1", "ms3"}, []string{cfg.Servers[0].Name, cfg.Servers[1].Name, cfg.Servers[2].Name})
+	assert.Len(t, cfg.Servers, 3)
+	assert.Equal(t, 30, cfg.DataSources[0].MaxCapacity)
+	assert.Equal(t, "/u01/apps/app1-v2.ear", cfg.Deployments[0].SourcePath)
+
+	current, err = client.Configuration(ctx)
+	assert.NoError(t, err)
+	p, err = NewPlan(m, current, true)
+	assert.NoError(t, err)
+	assert.True(t, p.Empty(), "applying again should change nothing, got %v", p)
+}
+
+func TestApplyCancelsOnError(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+
+	fake.InjectError(remy.EditPath+"/appDeployments", 500)
+	p := &Plan{Actions: []Action{
+		{Op: Create, Resource: ServerResource, Name: "ms3", Server: &remy.ServerConfig{Name: "ms3"}},
+		{Op: Create, Resource: ApplicationResource, Name: "app2", Deployment: &remy.DeploymentConfig{Name: "app2", SourcePath: "/tmp/app2.war"}},
+	}}
+	err := Apply(context.Background(), client, p)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "create application app2")
+	assert.Contains(t, err.Error(), "cancelled edit session")
+
+	fake.InjectError(remy.EditPath+"/changeManager/cancelEdit", 500)
+	err = Apply(context.Background(), client, p)
+	assert.Contains(t, err.Error(), "create application app2")
+	assert.Contains(t, err.Error(), "unable to cancel the edit session")
+
+	fake.ClearErrors()
+	assert.Len(t, fake.Config().Servers, 3, "nothing should be activated")
+}
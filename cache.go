@@ -0,0 +1,368 @@
+package remy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheHeader is set on each response a Cache answers, saying how it was answered: hit, stale, revalidated or miss.
+const CacheHeader = "X-Remy-Cache"
+
+// DefaultCacheTTLs are how long a Cache keeps responses fresh for each resource, as named by ResourceAttribute, unless
+// CacheOptions say otherwise.  Run-time statistics change constantly, but a dashboard refreshing every few seconds
+// doesn't need every refresh to reach the AdminServer.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"servers":      10 * time.Second,
+	"clusters":     10 * time.Second,
+	"datasources":  10 * time.Second,
+	"applications": 30 * time.Second,
+	"partitions":   30 * time.Second,
+	"domainConfig": time.Minute,
+	"management":   time.Minute,
+}
+
+// CachedResponse is a response kept by a CacheStore.
+type CachedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body"`
+	// Expires is when the response goes stale.
+	Expires time.Time `json:"expires"`
+}
+
+// CacheStore keeps the responses of a Cache by key.  Keys of the same domain share a prefix, so a change to the domain
+// can purge them all.  A CacheStore is used concurrently.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Put(key string, resp *CachedResponse)
+	Purge(prefix string)
+}
+
+// MemoryStore is a CacheStore keeping responses in memory, for as long as it is used.
+type MemoryStore struct {
+	mu        sync.Mutex
+	responses map[string]*CachedResponse
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{responses: make(map[string]*CachedResponse)}
+}
+
+// Get returns the response kept under key, if any.
+func (s *MemoryStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp, ok := s.responses[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *resp
+	return &copied, true
+}
+
+// Put keeps resp under key.
+func (s *MemoryStore) Put(key string, resp *CachedResponse) {
+	copied := *resp
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = &copied
+}
+
+// Purge drops every response whose key starts with prefix.
+func (s *MemoryStore) Purge(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.responses {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.responses, key)
+		}
+	}
+}
+
+// DiskStore is a CacheStore keeping each response as a JSON file in Dir, so separate runs of a command share them.
+// Credentials are scrubbed from the saved headers.  A response that can't be saved or read is simply not cached.
+type DiskStore struct {
+	Dir string
+}
+
+// Get returns the response saved under key, if any.
+func (s DiskStore) Get(key string) (*CachedResponse, bool) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Put saves resp under key, replacing the file whole so concurrent readers never see part of it.
+func (s DiskStore) Put(key string, resp *CachedResponse) {
+	copied := *resp
+	copied.Header = scrubHeader(resp.Header)
+	data, err := json.Marshal(&copied)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(s.Dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Purge removes every response whose key starts with prefix.
+func (s DiskStore) Purge(prefix string) {
+	files, _ := filepath.Glob(filepath.Join(s.Dir, prefix+"*.json"))
+	for _, f := range files {
+		os.Remove(f)
+	}
+}
+
+func (s DiskStore) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// CacheOptions are how a Cache keeps responses.
+type CacheOptions struct {
+	// Store keeps the responses.  Nil is a new MemoryStore.
+	Store CacheStore
+	// TTLs are how long responses stay fresh for each resource, as named by ResourceAttribute, e.g., servers.  Nil is
+	// DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+	// TTL is how long responses for resources not in TTLs stay fresh.  Zero doesn't cache them.
+	TTL time.Duration
+	// StaleWhileRevalidate is how long after a response goes stale it is still answered with, while a fresh one is
+	// fetched in the background.  Zero always waits for the fresh one.
+	StaleWhileRevalidate time.Duration
+}
+
+// Cache is an http.RoundTripper answering repeated reads of an AdminServer from the responses kept in a CacheStore,
+// keyed by the domain, URL and user.  Reads are GETs and searches; any other request is a change, and purges the
+// domain's responses once it succeeds.  A stale response with an ETag or Last-Modified header is revalidated with
+// If-None-Match or If-Modified-Since, where WebLogic provides them, rather than fetched again.  Only successful
+// responses are kept.
+type Cache struct {
+	Options CacheOptions
+	Next    http.RoundTripper
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+	now        func() time.Time
+}
+
+// NewCache creates a Cache sending the requests it can't answer to next.  If next is nil, http.DefaultTransport is used.
+func NewCache(opts CacheOptions, next http.RoundTripper) *Cache {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryStore()
+	}
+	if opts.TTLs == nil {
+		opts.TTLs = DefaultCacheTTLs
+	}
+	return &Cache{Options: opts, Next: next, refreshing: make(map[string]bool), now: time.Now}
+}
+
+// CacheResponses is a Middleware caching the responses passing through it.  See Cache.
+func CacheResponses(opts CacheOptions) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return NewCache(opts, next)
+	}
+}
+
+// WithCache caches the Client's responses.  See Cache.
+func WithCache(opts CacheOptions) ClientOption {
+	return WithMiddleware(CacheResponses(opts))
+}
+
+// resourceKey is the context key of the resource a request is made for.  See startSpan.
+type resourceKey struct{}
+
+// RoundTrip answers req from the CacheStore when it can, or else sends it to the next http.RoundTripper.
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	req = withBody(req.Context(), req, body)
+	if !isRead(req.Method, req.URL.Path) {
+		resp, err := c.Next.RoundTrip(req)
+		if err == nil && resp.StatusCode < 300 {
+			c.Options.Store.Purge(domainKey(req))
+		}
+		return resp, err
+	}
+
+	ttl := c.ttl(req.Context())
+	if ttl <= 0 {
+		return c.Next.RoundTrip(req)
+	}
+	key := cacheKey(req, body)
+	now := c.now()
+	cached, ok := c.Options.Store.Get(key)
+	switch {
+	case ok && now.Before(cached.Expires):
+		return cached.response(req, "hit"), nil
+	case ok && now.Before(cached.Expires.Add(c.Options.StaleWhileRevalidate)):
+		c.refresh(withBody(context.Background(), req, body), key, cached, ttl)
+		return cached.response(req, "stale"), nil
+	}
+	return c.fetch(req, key, cached, ttl)
+}
+
+// fetch sends req, revalidating cached if it can be, and keeps a successful response under key for ttl.
+func (c *Cache) fetch(req *http.Request, key string, cached *CachedResponse, ttl time.Duration) (*http.Response, error) {
+	if cached != nil {
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+	resp, err := c.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		cached.Expires = c.now().Add(ttl)
+		c.Options.Store.Put(key, cached)
+		return cached.response(req, "revalidated"), nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.Options.Store.Put(key, &CachedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: data,
+		Expires: c.now().Add(ttl)})
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	resp.Header.Set(CacheHeader, "miss")
+	return resp, nil
+}
+
+// refresh fetches req in the background, unless the response under key is already being refreshed.
+func (c *Cache) refresh(req *http.Request, key string, cached *CachedResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refreshing[key] {
+		return
+	}
+	c.refreshing[key] = true
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+		if resp, err := c.fetch(req, key, cached, ttl); err == nil {
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+	}()
+}
+
+// ttl is how long responses for the resource of the request made with ctx stay fresh.
+func (c *Cache) ttl(ctx context.Context) time.Duration {
+	resource, _ := ctx.Value(resourceKey{}).(string)
+	if ttl, ok := c.Options.TTLs[resource]; ok {
+		return ttl
+	}
+	return c.Options.TTL
+}
+
+// response is a new http.Response to req from the cached one.
+func (r *CachedResponse) response(req *http.Request, how string) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(CacheHeader, how)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %v", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// isRead reports whether a request for the url, or its path, only reads the AdminServer: a GET, or a search of one of
+// its REST trees.
+func isRead(method, url string) bool {
+	path := strings.SplitN(url, "?", 2)[0]
+	return method == http.MethodGet || method == http.MethodPost && strings.HasSuffix(path, "/search")
+}
+
+// withBody copies req with ctx and a fresh reader of body, so it can be changed and sent again.
+func withBody(ctx context.Context, req *http.Request, body []byte) *http.Request {
+	req = req.Clone(ctx)
+	if body != nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	return req
+}
+
+// domainKey is the prefix of the cache keys of the domain req is sent to.
+func domainKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.Host))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheKey is the key of req's response: its domain, then a hash of its credentials, method, URL and body.  The whole
+// Authorization header is hashed, not just the user, so a request with the wrong password never finds the responses
+// to one with the right password.
+func cacheKey(req *http.Request, body []byte) string {
+	credential := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	h := sha256.New()
+	for _, part := range []string{string(credential[:]), req.Method, req.URL.String()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return domainKey(req) + "-" + hex.EncodeToString(h.Sum(nil)[:16])
+}
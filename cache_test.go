@@ -0,0 +1,171 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// cachedClient is a Client for ts caching with opts, whose Cache's clock is *now.
+func cachedClient(ts *httptest.Server, user string, opts CacheOptions, now *time.Time) *Client {
+	return cachedClientAs(ts, user, "", opts, now)
+}
+
+// cachedClientAs is cachedClient with a password.
+func cachedClientAs(ts *httptest.Server, user, password string, opts CacheOptions, now *time.Time) *Client {
+	return NewClient(&AdminServer{AdminURL: ts.URL, Username: user, Password: password}, WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		c := NewCache(opts, next)
+		c.now = func() time.Time { return *now }
+		return c
+	}))
+}
+
+func TestCache(t *testing.T) {
+	var requests int32
+	r := CreateTestServerResourceRouters()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			next.ServeHTTP(w, req)
+		})
+	})
+	r.HandleFunc(MonitorPath+"/edit", func(w http.ResponseWriter, req *http.Request) {}).Methods("POST")
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+	now := time.Now()
+	store := NewMemoryStore()
+	client := cachedClient(ts, "weblogic", CacheOptions{Store: store}, &now)
+	ctx := context.Background()
+
+	first, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	requests = 0
+	second, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Zero(t, requests, "cached servers shouldn't be requested again")
+
+	_, err = cachedClient(ts, "monitor", CacheOptions{Store: store}, &now).Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests, "another user's servers are cached apart")
+	_, err = cachedClientAs(ts, "weblogic", "wrong", CacheOptions{Store: store}, &now).Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests, "a different password doesn't get the cached servers")
+
+	now = now.Add(DefaultCacheTTLs["servers"])
+	_, err = client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests, "expired servers are requested again")
+
+	_, err = client.send(ctx, http.MethodPost, ts.URL+MonitorPath+"/edit", []byte("{}"))
+	assert.NoError(t, err)
+	_, err = client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), requests, "a change purges the domain's responses")
+
+	_, err = client.Server(ctx, "ms1")
+	assert.Error(t, err)
+	_, err = client.Server(ctx, "ms1")
+	assert.Error(t, err)
+	assert.Equal(t, int32(7), requests, "failures aren't cached")
+}
+
+func TestCacheRevalidates(t *testing.T) {
+	var requests, notModified int32
+	r := mux.NewRouter()
+	r.HandleFunc(MonitorPath+"/servers", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(serversJSON))
+	})
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+	now := time.Now()
+	client := cachedClient(ts, "weblogic", CacheOptions{TTLs: map[string]time.Duration{}, TTL: time.Second}, &now)
+	ctx := context.Background()
+
+	_, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Second)
+	servers, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+	assert.Equal(t, int32(2), requests)
+	assert.Equal(t, int32(1), notModified)
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	var requests int32
+	refreshed := make(chan bool, 1)
+	r := mux.NewRouter()
+	r.HandleFunc(MonitorPath+"/servers", func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			defer func() { refreshed <- true }()
+		}
+		w.Write([]byte(serversJSON))
+	})
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+	store := NewMemoryStore()
+	now := time.Now()
+	cache := NewCache(CacheOptions{Store: store, StaleWhileRevalidate: time.Minute}, nil)
+	cache.now = func() time.Time { return now }
+	ctx := context.WithValue(context.Background(), resourceKey{}, "servers")
+	get := func() string {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+MonitorPath+"/servers", nil)
+		resp, err := cache.RoundTrip(req.WithContext(ctx))
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp.Header.Get(CacheHeader)
+	}
+
+	assert.Equal(t, "miss", get())
+	assert.Equal(t, "hit", get())
+	now = now.Add(DefaultCacheTTLs["servers"] + time.Second)
+	assert.Equal(t, "stale", get())
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stale servers weren't refreshed")
+	}
+	assert.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+MonitorPath+"/servers", nil)
+		cached, ok := store.Get(cacheKey(req, nil))
+		return ok && cached.Expires.After(now)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "hit", get())
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, "miss", get())
+}
+
+func TestDiskStore(t *testing.T) {
+	store := DiskStore{Dir: t.TempDir()}
+	header := http.Header{"Content-Type": {"application/json"}, "Set-Cookie": {"JSESSIONID=secret"}}
+	store.Put("d1-a", &CachedResponse{StatusCode: 200, Header: header, Body: []byte("{}"), Expires: time.Unix(100, 0)})
+	store.Put("d2-a", &CachedResponse{StatusCode: 200, Body: []byte("[]")})
+
+	resp, ok := store.Get("d1-a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("{}"), resp.Body)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Set-Cookie"))
+	assert.True(t, resp.Expires.Equal(time.Unix(100, 0)))
+
+	store.Purge("d1-")
+	_, ok = store.Get("d1-a")
+	assert.False(t, ok)
+	_, ok = store.Get("d2-a")
+	assert.True(t, ok)
+}
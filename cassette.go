@@ -0,0 +1,200 @@
+package remy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// credentialHeaders are never written to a cassette or a log, as they would leak the credentials used to talk to the
+// AdminServer.
+var credentialHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is a single recorded request/response pair.  Each Interaction is saved to its own JSON file (a cassette)
+// in the directory given to a Recorder, and served back by a Replayer.
+type Interaction struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"statusCode"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body"`
+	} `json:"response"`
+}
+
+// Recorder is an http.RoundTripper that sends every request through the next http.RoundTripper and saves the
+// request/response pair as a cassette in Dir.  Credentials are scrubbed from the saved files.
+type Recorder struct {
+	Dir  string
+	Next http.RoundTripper
+
+	mu sync.Mutex
+}
+
+// NewRecorder creates a Recorder saving cassettes to dir.  If next is nil, http.DefaultTransport is used.
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Next: next}
+}
+
+// Record is a Middleware that records every request passing through it to dir.  See Recorder.
+func Record(dir string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return NewRecorder(dir, next)
+	}
+}
+
+// RoundTrip sends req through the next http.RoundTripper and saves the resulting Interaction.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var in Interaction
+	in.Request.Method = req.Method
+	in.Request.URL = cassetteURL(req)
+	in.Request.Header = scrubHeader(req.Header)
+	in.Request.Body = string(reqBody)
+	in.Response.StatusCode = resp.StatusCode
+	in.Response.Header = scrubHeader(resp.Header)
+	in.Response.Body = string(body)
+
+	if err := r.save(cassetteName(req, reqBody), &in); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(name string, in *Interaction) error {
+	data, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return fmt.Errorf("unable to create cassette directory %v: %v", r.Dir, err)
+	}
+	return ioutil.WriteFile(filepath.Join(r.Dir, name), data, 0644)
+}
+
+// Replayer is an http.RoundTripper that serves responses from the cassettes saved by a Recorder in Dir.  It never
+// touches the network; a request without a matching cassette returns an error.
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer creates a Replayer serving cassettes from dir.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip finds the recorded Interaction matching req and returns its response.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	name := cassetteName(req, body)
+	data, err := ioutil.ReadFile(filepath.Join(r.Dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("no recorded interaction for %v %v in %v: %v", req.Method, cassetteURL(req), r.Dir, err)
+	}
+	var in Interaction
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("unable to read cassette %v: %v", name, err)
+	}
+	header := in.Response.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+		StatusCode:    in.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+		ContentLength: int64(len(in.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// cassetteURL is the host-independent part of the request URL, so cassettes recorded against one AdminServer can be
+// replayed with any --adminurl.
+func cassetteURL(req *http.Request) string {
+	return req.URL.RequestURI()
+}
+
+// cassetteName maps a request to the file name of its cassette, e.g.,
+// GET_management_tenant-monitoring_servers_format=full.json.  Requests with a body, such as the searches of the
+// WebLogic tree that all POST to the same URL, end with a hash of the body to tell them apart, e.g.,
+// POST_management_weblogic_latest_domainRuntime_search_3f2a9c0d41b7e865.json
+func cassetteName(req *http.Request, body []byte) string {
+	name := req.Method + "_" + strings.TrimPrefix(cassetteURL(req), "/")
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '=':
+			return r
+		}
+		return '_'
+	}, name)
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		name += "_" + hex.EncodeToString(sum[:8])
+	}
+	return name + ".json"
+}
+
+// readRequestBody reads the body of req, returning a copy of req that can still send it.
+func readRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil {
+		return req, nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	return withBody(req.Context(), req, body), body, nil
+}
+
+func scrubHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	scrubbed := make(http.Header, len(h))
+	for k, v := range h {
+		scrubbed[k] = append([]string(nil), v...)
+	}
+	for _, k := range credentialHeaders {
+		scrubbed.Del(k)
+	}
+	return scrubbed
+}
@@ -0,0 +1,88 @@
+package remy
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remy-cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	recording := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "secretpass"}, WithMiddleware(Record(dir)))
+	recorded, err := recording.Server(context.Background(), "adminserver")
+	assert.NoError(t, err)
+	_, err = recording.Servers(context.Background(), QueryOptions{Full: true})
+	assert.NoError(t, err)
+	ts.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.NoError(t, err)
+	// the probe of VersionPath is recorded too, so replaying finds the same tree
+	assert.Len(t, files, 3)
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		assert.NoError(t, err)
+		if strings.Contains(string(data), "Authorization") {
+			t.Errorf("cassette %v contains credentials", f)
+		}
+	}
+
+	replaying := NewClient(&AdminServer{AdminURL: "http://nowhere:7001"}, WithTransport(NewReplayer(dir)))
+	replayed, err := replaying.Server(context.Background(), "adminserver")
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	servers, err := replaying.Servers(context.Background(), QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+
+	_, err = replaying.Clusters(context.Background(), QueryOptions{})
+	assert.Error(t, err)
+}
+
+func TestRecordAndReplaySearches(t *testing.T) {
+	dir := t.TempDir()
+	r := mux.NewRouter()
+	r.HandleFunc(RuntimePath+"/search", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Write(body)
+	}).Methods("POST")
+	ts := httptest.NewServer(r)
+	searches := []string{`{"serverLifeCycleRuntimes": {"fields": ["name"]}}`, `{"serverRuntimes": {"fields": ["name"]}}`}
+	recording := NewClient(&AdminServer{AdminURL: ts.URL}, WithMiddleware(Record(dir)))
+	for _, search := range searches {
+		_, err := recording.send(context.Background(), http.MethodPost, ts.URL+RuntimePath+"/search", []byte(search))
+		assert.NoError(t, err)
+	}
+	ts.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2, "each search has its own cassette")
+	data, err := ioutil.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "fields", "the search is saved in its cassette")
+
+	replaying := NewClient(&AdminServer{AdminURL: "http://nowhere:7001"}, WithTransport(NewReplayer(dir)))
+	for _, search := range searches {
+		body, err := replaying.send(context.Background(), http.MethodPost, "http://nowhere:7001"+RuntimePath+"/search", []byte(search))
+		assert.NoError(t, err)
+		assert.Equal(t, search, string(body))
+	}
+	_, err = replaying.send(context.Background(), http.MethodPost, "http://nowhere:7001"+RuntimePath+"/search", []byte(`{}`))
+	assert.Error(t, err)
+}
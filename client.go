@@ -2,12 +2,22 @@ package remy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // See http://docs.oracle.com/cd/E23943_01/web.1111/e24682/toc.htm#RESTS149
@@ -22,10 +32,17 @@ const (
 
 // AdminServer contains the configurable details necessary to request resources from a particular AdminServer.
 // ServerUrl format should be similar to the following: "http(s)://[serverhost]:[adminport]"
+//
+// RateLimit, Burst and MaxInFlight protect a busy AdminServer from the requests of every Client for it in the process:
+// at most Burst requests are sent at once before the rest are held to RateLimit a second, and no more than MaxInFlight
+// are outstanding at a time.  Zero doesn't limit.  Time waiting counts towards WithTimeout.
 type AdminServer struct {
-	AdminURL string
-	Username string
-	Password string
+	AdminURL    string
+	Username    string
+	Password    string
+	RateLimit   float64 `toml:",omitempty"`
+	Burst       int     `toml:",omitempty"`
+	MaxInFlight int     `toml:",omitempty"`
 }
 
 // Wrapper handles all responses sent back from a WLS Rest endpoint.  These responses are wrapped by a similar body and item or items tag.
@@ -45,46 +62,236 @@ type Wrapper struct {
 	Messages []string `json:"messages,omitempty"`
 }
 
-// requestResource is a wrapper around an http.Client{} instance assuming the following:
-// - the request is a GET
-// - assumes a JSON Accept header
-// - set Basic Authentication based on the *AdminServer passed in
-//
-// returns the *http.Response or an error
-func requestResource(url string, e *AdminServer) (*http.Response, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// Middleware wraps an http.RoundTripper with additional behavior, such as tracing, metrics, caching or recording.
+// Middleware is applied to every request a Client makes against an AdminServer.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as an http.RoundTripper.  This makes writing
+// small Middleware functions less verbose.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ClientOption configures a Client when it is created with NewClient.
+type ClientOption func(*Client)
+
+// WithTransport sets the base http.RoundTripper the Client sends requests through.  When not set,
+// http.DefaultTransport is used.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = rt
 	}
-	req.Header.Add("Accept", "application/json")
-	req.SetBasicAuth(e.Username, e.Password)
-	return client.Do(req)
 }
 
-func requestAndUnmarshal(url string, e *AdminServer) (*Wrapper, error) {
-	resp, err := request(url, e)
+// WithMiddleware appends Middleware to the chain wrapping the Client's transport.  Middleware is applied in the order
+// given, so the first Middleware is the outermost, seeing each request first and each response last.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithTimeout sets the overall time limit for a single request made by the Client.  Zero means no timeout.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetries retries a request up to n more times when it fails to reach the AdminServer, or the AdminServer answers
+// with a 502, 503 or 504.  Retries back off exponentially, starting at 100ms.
+func WithRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.retries = n
+	}
+}
+
+// Client requests resources from the AdminServer it was created with.  Every request is sent through the configured
+// http.RoundTripper, wrapped by any Middleware passed to NewClient.  Requests reaching the transport wait for the
+// AdminServer's rate limit, if any, so responses a Middleware answers itself, such as a Cache's, aren't held up.
+type Client struct {
+	server     *AdminServer
+	transport  http.RoundTripper
+	middleware []Middleware
+	timeout    time.Duration
+	retries    int
+	logger     *slog.Logger
+	traceHTTP  bool
+	httpClient *http.Client
+	limiter    *rateLimiter
+
+	tracerProvider trace.TracerProvider
+
+	tree      Tree
+	probe     probe
+	partition string
+}
+
+// NewClient creates a Client for the given AdminServer, applying each ClientOption in order.
+func NewClient(server *AdminServer, opts ...ClientOption) *Client {
+	c := &Client{
+		server:    server,
+		transport: http.DefaultTransport,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	rt := c.transport
+	if c.limiter = rateLimiterFor(server); c.limiter != nil {
+		rt = c.limiter.roundTripper(rt)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	c.httpClient = &http.Client{Transport: rt, Timeout: c.timeout}
+	return c
+}
+
+// AdminServer returns the connection details this Client was created with.
+func (c *Client) AdminServer() *AdminServer {
+	return c.server
+}
+
+// resourceURL builds the full URL to a resource under the MonitorPath, e.g., resourceURL("servers", "ms1") for a
+// single server, or resourceURL("servers") for all of them.  Each part is path-escaped, as application names in
+// particular may contain spaces.
+func (c *Client) resourceURL(resource ...string) string {
+	parts := make([]string, len(resource))
+	for i := range resource {
+		parts[i] = url.PathEscape(resource[i])
+	}
+	return c.server.AdminURL + MonitorPath + "/" + strings.Join(parts, "/")
+}
+
+// requestResource sends a single request for the url through the Client's transport and Middleware chain.  A body is
+// sent as JSON, and anything but a GET carries the X-Requested-By header WebLogic requires of changes.
+func (c *Client) requestResource(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
 	if err != nil {
 		return nil, err
 	}
-	data, err := ioutil.ReadAll(resp.Body)
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	if method != http.MethodGet {
+		req.Header.Add("X-Requested-By", "remy")
+	}
+	req.SetBasicAuth(c.server.Username, c.server.Password)
+	req, span := c.startHTTPSpan(req)
+	resp, err := c.httpClient.Do(req)
+	endHTTPSpan(span, resp, err)
+	return resp, err
+}
+
+func (c *Client) requestAndUnmarshal(ctx context.Context, url string) (*Wrapper, error) {
+	data, err := c.request(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 	return unmarshalWrapper(data)
 }
 
-// Wrapper function for requestResource(), handling HTTP response codes before unmarshalling responses.
-func request(url string, e *AdminServer) (*http.Response, error) {
-	resp, err := requestResource(url, e)
+// request GETs the url.  See send.
+func (c *Client) request(ctx context.Context, url string) ([]byte, error) {
+	return c.send(ctx, http.MethodGet, url, nil)
+}
+
+// send is a wrapper for requestResource(), retrying reads, GETs and searches, as configured with WithRetries and
+// handling HTTP response codes before unmarshalling responses.  Changes are never retried, as they may have been made
+// before the failure.
+// Returns the body of a successful response.
+func (c *Client) send(ctx context.Context, method, url string, reqBody []byte) ([]byte, error) {
+	start := time.Now()
+	var (
+		resp    *http.Response
+		body    []byte
+		err     error
+		retries int
+		waited  int64
+	)
+	ctx = context.WithValue(ctx, rateLimitWaitKey{}, &waited)
+	for {
+		resp, err = c.requestResource(ctx, method, url, reqBody)
+		if err == nil {
+			body, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		if retries >= c.retries || !isRead(method, url) || !retryable(ctx, resp, err) {
+			break
+		}
+		retries++
+		c.logRetry(ctx, method, url, retries, resp, err)
+		select {
+		case <-time.After(backoff(retries)):
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	c.logRequest(ctx, method, url, resp, body, retries, time.Since(start), time.Duration(atomic.LoadInt64(&waited)), err)
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(RetriesAttribute.Int(retries))
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	}
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return resp, nil
+		return body, nil
 	}
-	body, _ := ioutil.ReadAll(resp.Body)
-	return nil, fmt.Errorf("Invalid Response Code: %v\nResponse: \n%v", resp.StatusCode, string(body))
+	return nil, &ResponseError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+// ResponseError is the error for a response from the AdminServer with a status code outside 2xx.  Check for it with
+// errors.As, or IsNotFound.
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error describes the status code and the body of the response.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("Invalid Response Code: %v\nResponse: \n%v", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is a ResponseError for a 404: the AdminServer, or its run-time beans, have no such
+// resource.
+func IsNotFound(err error) bool {
+	var re *ResponseError
+	return errors.As(err, &re) && re.StatusCode == http.StatusNotFound
+}
+
+// retryable determines whether a request is worth trying again: the AdminServer couldn't be reached, or it said it is
+// temporarily unable to answer.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff is how long to wait before the given retry: 100ms, 200ms, 400ms, and so on.
+func backoff(retry int) time.Duration {
+	return 100 * time.Millisecond << uint(retry-1)
 }
 
 // Take the raw response from the server and attempt to unmarshal it into the Wrapper type.
@@ -1,9 +1,13 @@
 package remy
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -20,8 +24,8 @@ func TestClientAuthenticatedCalls(t *testing.T) {
 	defer ts.Close()
 	t.Log(ts.URL)
 
-	_, err := requestResource(ts.URL,
-		&AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"})
+	client := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"})
+	_, err := client.requestResource(context.Background(), http.MethodGet, ts.URL, nil)
 	assert.NoError(t, err)
 }
 
@@ -34,7 +38,8 @@ func TestAcceptJsonHeaderCall(t *testing.T) {
 	defer ts.Close()
 	t.Log(ts.URL)
 
-	_, err := requestResource(ts.URL, &AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"})
+	client := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"})
+	_, err := client.requestResource(context.Background(), http.MethodGet, ts.URL, nil)
 	assert.NoError(t, err)
 }
 
@@ -101,3 +106,57 @@ func TestServerResourceRelatedCalls(t *testing.T) {
 		}
 	}
 }
+
+func TestClientMiddlewareOrder(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	client := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"},
+		WithMiddleware(trace("first"), trace("second")), WithTree(TenantMonitoring))
+
+	s, err := client.Servers(context.Background(), QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, s, 2)
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestClientWithTransport(t *testing.T) {
+	var requested string
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(singleServer)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	})
+	client := NewClient(&AdminServer{AdminURL: "http://adminhost:7001"}, WithTransport(transport), WithTree(TenantMonitoring))
+
+	server, err := client.Server(context.Background(), "adminserver")
+	assert.NoError(t, err)
+	assert.Equal(t, "adminserver", server.Name)
+	assert.Equal(t, "http://adminhost:7001"+MonitorPath+"/servers/adminserver", requested)
+}
+
+func TestClientInvalidResponseCode(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	client := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "pass"})
+	_, err := client.Server(context.Background(), "unknown")
+	var re *ResponseError
+	assert.True(t, errors.As(err, &re))
+	assert.Equal(t, http.StatusBadRequest, re.StatusCode)
+	assert.False(t, IsNotFound(err))
+	assert.True(t, IsNotFound(fmt.Errorf("unable to get server: %w", &ResponseError{StatusCode: http.StatusNotFound})))
+}
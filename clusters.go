@@ -2,6 +2,7 @@ package remy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -10,16 +11,19 @@ import (
 // each having their own ClusterMaster, deployed state, etc.
 type Cluster struct {
 	Name    string
-	Servers []struct {
-		Name                   string
-		State                  string
-		Health                 string
-		IsClusterMaster        bool   `json:"clusterMaster,omitempty"`
-		DropOutFrequency       string `json:",omitempty"`
-		ResendRequestsCount    int    `json:",omitempty"`
-		FragmentsSentCount     int    `json:",omitempty"`
-		FragmentsReceivedCount int    `json:",omitempty"`
-	} `json:"servers,omitempty"`
+	Servers []ClusterServer `json:"servers,omitempty"`
+}
+
+// ClusterServer is the run-time information for a member server of a Cluster.
+type ClusterServer struct {
+	Name                   string
+	State                  string
+	Health                 string
+	IsClusterMaster        bool   `json:"clusterMaster,omitempty"`
+	DropOutFrequency       string `json:",omitempty"`
+	ResendRequestsCount    int    `json:",omitempty"`
+	FragmentsSentCount     int    `json:",omitempty"`
+	FragmentsReceivedCount int    `json:",omitempty"`
 }
 
 // GoString creates a GoString of the Cluster type for use in command-line applications.
@@ -36,12 +40,26 @@ func (c *Cluster) GoString() string {
 }
 
 // Clusters returns all clusters configured in a domain and provides run-time information for each cluster and for each cluster's member servers, including all the member servers' state and health.
-func (a *AdminServer) Clusters(fullFormat bool) ([]Cluster, error) {
-	url := fmt.Sprintf("%v%v/clusters", a.AdminURL, MonitorPath)
-	if fullFormat {
+// opts determine the format, fields and page of the list.
+func (c *Client) Clusters(ctx context.Context, opts QueryOptions) (list []Cluster, err error) {
+	ctx, span := c.startSpan(ctx, "Clusters", "clusters", "")
+	defer func() { endSpan(span, err) }()
+	if opts, err = opts.resolve(Cluster{}); err != nil {
+		return nil, err
+	}
+	defer func() { opts.apply(&list) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeClusters(ctx, opts)
+	}
+	url := c.resourceURL("clusters")
+	if opts.Full {
 		url = url + "?format=full"
 	}
-	w, err := requestAndUnmarshal(url, a)
+	w, err := c.requestAndUnmarshal(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -53,9 +71,17 @@ func (a *AdminServer) Clusters(fullFormat bool) ([]Cluster, error) {
 }
 
 // Cluster returns run-time information for the specified cluster and its member servers, including the member servers' state and health.
-func (a *AdminServer) Cluster(clusterName string) (*Cluster, error) {
-	url := fmt.Sprintf("%v%v/clusters/%v", a.AdminURL, MonitorPath, clusterName)
-	w, err := requestAndUnmarshal(url, a)
+func (c *Client) Cluster(ctx context.Context, clusterName string) (_ *Cluster, err error) {
+	ctx, span := c.startSpan(ctx, "Cluster", "clusters", clusterName)
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeCluster(ctx, clusterName)
+	}
+	w, err := c.requestAndUnmarshal(ctx, c.resourceURL("clusters", clusterName))
 	if err != nil {
 		return nil, err
 	}
@@ -65,3 +91,16 @@ func (a *AdminServer) Cluster(clusterName string) (*Cluster, error) {
 	}
 	return &cluster, nil
 }
+
+// Clusters returns all clusters configured in the domain using a default Client.  See Client.Clusters.
+//
+// Deprecated: Clusters only chooses between the short and full format.  Use Client.Clusters, whose QueryOptions also
+// select fields and page through the clusters.
+func (a *AdminServer) Clusters(fullFormat bool) ([]Cluster, error) {
+	return NewClient(a).Clusters(context.Background(), QueryOptions{Full: fullFormat})
+}
+
+// Cluster returns run-time information for the specified cluster using a default Client.  See Client.Cluster.
+func (a *AdminServer) Cluster(clusterName string) (*Cluster, error) {
+	return NewClient(a).Cluster(context.Background(), clusterName)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/analysis"
+	"github.com/spf13/cobra"
+)
+
+// analyzeOptions holds the flags for the datasources analyze command.
+var analyzeOptions struct {
+	interval time.Duration
+	output   string
+}
+
+// racOptions holds the flags for the datasources rac command.
+var racOptions struct {
+	output string
+}
+
+// workloadOptions holds the flags for the applications workload command.
+var workloadOptions struct {
+	output string
+}
+
+// healthOptions holds the flags for the clusters health command, which samples twice by default.
+var healthOptions struct {
+	interval time.Duration
+	output   string
+}
+
+// capacityOptions holds the flags for the servers capacity command.
+var capacityOptions struct {
+	samples  int
+	interval time.Duration
+	output   string
+}
+
+// SamplesFlag is the flag for the number of samples taken to estimate trends
+const SamplesFlag = "samples"
+
+// AnalyzeDataSources derives pool metrics for every instance of the named data source, or all of them, and diagnoses
+// likely problems.  With --interval, the data sources are sampled twice to show whether connections are leaking now.
+func AnalyzeDataSources(cmd *cobra.Command, args []string) {
+	client := findLiveClient()
+	ctx := context.Background()
+	sample := func() []remy.DataSource {
+		if len(args) == 1 {
+			ds, err := client.DataSource(ctx, args[0])
+			if err != nil {
+				panic(fmt.Sprintf("Unable to get Datasource: %v", err))
+			}
+			return []remy.DataSource{*ds}
+		}
+		dataSources, err := client.DataSources(ctx, remy.QueryOptions{Full: true})
+		if err != nil {
+			panic(fmt.Sprintf("Unable to get Datasources: %v", err))
+		}
+		return dataSources
+	}
+
+	var before []remy.DataSource
+	if analyzeOptions.interval > 0 {
+		before = sample()
+		time.Sleep(analyzeOptions.interval)
+	}
+	report := &analysis.DataSourceReport{DataSources: []analysis.DataSourceAnalysis{}}
+	for _, ds := range sample() {
+		var earlier *remy.DataSource
+		for i := range before {
+			if before[i].Name == ds.Name {
+				earlier = &before[i]
+			}
+		}
+		ds := ds
+		report.DataSources = append(report.DataSources, analysis.AnalyzeDataSource(earlier, &ds, analyzeOptions.interval))
+	}
+	printReport(report, analyzeOptions.output)
+}
+
+// RAC shows the Oracle RAC statistics of a GridLink data source by RAC instance across every WebLogic server.
+func RAC(cmd *cobra.Command, args []string) {
+	ds, err := findClient().DataSource(context.Background(), args[0])
+	if err != nil {
+		panic(fmt.Sprintf("Unable to get Datasource: %v", err))
+	}
+	printReport(analysis.AnalyzeRAC(ds), racOptions.output)
+}
+
+// newRACCmd creates the datasources rac command and its flags.
+func newRACCmd() *cobra.Command {
+	racCmd := &cobra.Command{
+		Use:   "rac <GridLink datasource>",
+		Short: "Show a GridLink data source's load balancing by Oracle RAC instance",
+		Long: "Pivot the RAC statistics of a GridLink data source by RAC instance across every WebLogic server: load " +
+			"balancing weight, connections, capacity and availability per node, and RCLB and affinity borrow success " +
+			"rates.  Warns when a RAC instance is disabled or getting a skewed share of connections.",
+		Args: cobra.ExactArgs(1),
+		Run:  RAC,
+	}
+	racCmd.Flags().StringVarP(&racOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return racCmd
+}
+
+// newAnalyzeDataSourcesCmd creates the datasources analyze command and its flags.
+func newAnalyzeDataSourcesCmd() *cobra.Command {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze [datasource to analyze, blank for ALL]",
+		Short: "Diagnose connection pool problems",
+		Long: "Derive pool utilization, prepared statement cache hit ratio, wait and reserve failure rates and leaks for " +
+			"every instance of a data source and across the domain, and flag likely misconfigurations such as a pool or " +
+			"statement cache that is too small, or applications leaking connections.  Use --interval to sample twice and " +
+			"see whether connections are leaking now.",
+		Args: cobra.MaximumNArgs(1),
+		Run:  AnalyzeDataSources,
+	}
+	analyzeCmd.Flags().DurationVar(&analyzeOptions.interval, IntervalFlag, 0, "Time between two samples for leak trends (0 takes one sample)")
+	analyzeCmd.Flags().StringVarP(&analyzeOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return analyzeCmd
+}
+
+// Workload ranks the thread constraints, work managers and request classes of the named applications, or all of them,
+// by the requests queued behind them, and flags max threads constraints deferring work.
+func Workload(cmd *cobra.Command, args []string) {
+	client := findClient()
+	ctx := context.Background()
+	var apps []remy.Application
+	if len(args) == 0 {
+		var err error
+		if apps, err = client.Applications(ctx, remy.QueryOptions{Full: true}); err != nil {
+			panic(fmt.Sprintf("Unable to get Applications: %v", err))
+		}
+	}
+	for _, name := range args {
+		app, err := client.Application(ctx, name)
+		if err != nil {
+			panic(fmt.Sprintf("Unable to get Application: %v", err))
+		}
+		apps = append(apps, *app)
+	}
+	printReport(analysis.AnalyzeWorkload(apps), workloadOptions.output)
+}
+
+// newWorkloadCmd creates the applications workload command and its flags.
+func newWorkloadCmd() *cobra.Command {
+	workloadCmd := &cobra.Command{
+		Use:   "workload [applications to report on, blank for ALL]",
+		Short: "Report work manager and thread constraint saturation",
+		Long: "Aggregate the work manager, thread constraint and request class statistics of applications across every " +
+			"server, ranking the constraints with the most pending and deferred requests and the longest waits, showing " +
+			"each request class's share of thread use, and flagging max threads constraints that are deferring work.",
+		Run: Workload,
+	}
+	workloadCmd.Flags().StringVarP(&workloadOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return workloadCmd
+}
+
+// ClusterHealth samples the named clusters, or all of them, twice --interval apart, and reports the health of each
+// cluster's messaging, masters and members with a verdict.
+func ClusterHealth(cmd *cobra.Command, args []string) {
+	client := findLiveClient()
+	ctx := context.Background()
+	sample := func() []remy.Cluster {
+		if len(args) == 0 {
+			clusters, err := client.Clusters(ctx, remy.QueryOptions{Full: true})
+			if err != nil {
+				panic(fmt.Sprintf("Unable to get Clusters: %v", err))
+			}
+			return clusters
+		}
+		var clusters []remy.Cluster
+		for _, name := range args {
+			c, err := client.Cluster(ctx, name)
+			if err != nil {
+				panic(fmt.Sprintf("Unable to get Cluster: %v", err))
+			}
+			clusters = append(clusters, *c)
+		}
+		return clusters
+	}
+
+	var before []remy.Cluster
+	if healthOptions.interval > 0 {
+		before = sample()
+		time.Sleep(healthOptions.interval)
+	}
+	report := &analysis.ClusterHealthReport{Clusters: []analysis.ClusterHealth{}}
+	for _, c := range sample() {
+		var earlier *remy.Cluster
+		for i := range before {
+			if before[i].Name == c.Name {
+				earlier = &before[i]
+			}
+		}
+		c := c
+		report.Clusters = append(report.Clusters, analysis.AnalyzeCluster(earlier, &c, healthOptions.interval))
+	}
+	printReport(report, healthOptions.output)
+}
+
+// newClusterHealthCmd creates the clusters health command and its flags.
+func newClusterHealthCmd() *cobra.Command {
+	healthCmd := &cobra.Command{
+		Use:   "health [clusters to check, blank for ALL]",
+		Short: "Check the health of cluster communication",
+		Long: "Sample the clusters twice to compute the rates fragments are sent, received and resent by each member, and " +
+			"flag members with a high resend ratio or that drop out of the cluster, clusters with no or several masters, " +
+			"and members whose state or health differs from their peers, with a verdict for each cluster.",
+		Run: ClusterHealth,
+	}
+	healthCmd.Flags().DurationVar(&healthOptions.interval, IntervalFlag, 10*time.Second, "Time between the two samples (0 takes one sample)")
+	healthCmd.Flags().StringVarP(&healthOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return healthCmd
+}
+
+// Capacity reports the heap, processor load and sockets of every server grouped by machine and cluster.  With
+// --samples above 1, the servers are sampled --interval apart to estimate heap and socket trends.
+func Capacity(cmd *cobra.Command, args []string) {
+	client := findLiveClient()
+	ctx := context.Background()
+	n := capacityOptions.samples
+	if n < 1 {
+		n = 1
+	}
+	var samples [][]remy.Server
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			time.Sleep(capacityOptions.interval)
+		}
+		servers, err := client.Servers(ctx, remy.QueryOptions{Full: true})
+		if err != nil {
+			panic(fmt.Sprintf("Unable to get Servers: %v", err))
+		}
+		samples = append(samples, servers)
+	}
+	printReport(analysis.AnalyzeCapacity(samples, capacityOptions.interval), capacityOptions.output)
+}
+
+// newCapacityCmd creates the servers capacity command and its flags.
+func newCapacityCmd() *cobra.Command {
+	capacityCmd := &cobra.Command{
+		Use:   "capacity",
+		Short: "Report JVM heap, processor and socket capacity across the domain",
+		Long: "Group servers by machine and cluster with their heap use, processor load and open sockets, show the " +
+			"percentiles of heap utilization across the domain and highlight hot servers.  Use --samples and --interval " +
+			"to estimate how fast heap use and sockets are growing, to plan heap sizing and which servers to co-locate.",
+		Args: cobra.NoArgs,
+		Run:  Capacity,
+	}
+	capacityCmd.Flags().IntVar(&capacityOptions.samples, SamplesFlag, 1, "Number of samples to take for trends (1 takes no trends)")
+	capacityCmd.Flags().DurationVar(&capacityOptions.interval, IntervalFlag, 30*time.Second, "Time between samples")
+	capacityCmd.Flags().StringVarP(&capacityOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return capacityCmd
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/apply"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ManifestFlag is the flag for the YAML manifest of the desired domain configuration
+	ManifestFlag = "file"
+
+	// PruneFlag deletes resources of each kind listed in the manifest that it leaves out
+	PruneFlag = "prune"
+)
+
+// applyOptions holds the flags for the plan and apply commands.
+var applyOptions struct {
+	manifest string
+	prune    bool
+	output   string
+}
+
+// findPlan compares the --file manifest to the activated configuration of the domain, as the client reads it.
+func findPlan(client *remy.Client) *apply.Plan {
+	m, err := apply.Load(applyOptions.manifest)
+	if err != nil {
+		panic(err.Error())
+	}
+	current, err := client.Configuration(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("Unable to get the domain configuration: %v", err))
+	}
+	p, err := apply.NewPlan(m, current, applyOptions.prune)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to plan changes: %v", err))
+	}
+	return p
+}
+
+// Plan shows the changes apply would make, without making them.
+func Plan(cmd *cobra.Command, args []string) {
+	printReport(findPlan(findLiveClient()), applyOptions.output)
+}
+
+// Apply shows the changes needed to bring the domain in line with the manifest, then makes them in one edit session.
+func Apply(cmd *cobra.Command, args []string) {
+	client := findLiveClient()
+	p := findPlan(client)
+	fmt.Print(p.String())
+	if p.Empty() {
+		return
+	}
+	if err := apply.Apply(context.Background(), client, p); err != nil {
+		panic(err.Error())
+	}
+	fmt.Println("Changes activated")
+}
+
+// newApplyCmds creates the plan and apply commands and their flags.
+func newApplyCmds() []*cobra.Command {
+	planCmd := &cobra.Command{
+		Use:   "plan --file domain.yaml",
+		Short: "Show the changes apply would make to the domain",
+		Long: "Compare a YAML manifest of servers, JDBC data sources and application deployments to the activated " +
+			"configuration of the domain, and show the creates, updates and deletes needed without making them",
+		Run: Plan,
+	}
+	planCmd.Flags().StringVarP(&applyOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+
+	applyCmd := &cobra.Command{
+		Use:   "apply --file domain.yaml",
+		Short: "Reconcile the domain with a manifest",
+		Long: "Compare a YAML manifest of servers, JDBC data sources and application deployments to the activated " +
+			"configuration of the domain, show the changes needed and make them inside one edit session.  The edit " +
+			"session is cancelled if any change fails.  Requires the WLS 12.2.1 RESTful management API.",
+		Run: Apply,
+	}
+
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVar(&applyOptions.manifest, ManifestFlag, "", "YAML manifest of the desired configuration")
+		c.Flags().BoolVar(&applyOptions.prune, PruneFlag, false, "Delete servers, data sources and applications left out of the manifest")
+		if err := c.MarkFlagRequired(ManifestFlag); err != nil {
+			panic(err.Error())
+		}
+	}
+	return []*cobra.Command{planCmd, applyCmd}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	wls "github.com/klauern/remy"
+)
+
+const (
+	// NoCacheFlag is the flag for sending every request to the AdminServer rather than reusing cached responses
+	NoCacheFlag = "no-cache"
+
+	// CacheTTLFlag is the flag for how long cached responses stay fresh, overriding the default for each resource
+	CacheTTLFlag = "cache-ttl"
+
+	// StaleWhileRevalidateFlag is the flag for how long serve answers with stale responses while fetching fresh ones
+	StaleWhileRevalidateFlag = "stale-while-revalidate"
+)
+
+// NoCache sends every request to the AdminServer.
+var NoCache bool
+
+// CacheTTL is how long cached responses stay fresh.  Zero keeps the wls.DefaultCacheTTLs.
+var CacheTTL time.Duration
+
+// cacheOptions are the CacheOptions of the cache flags, and whether to cache at all.  Responses are cached on disk, so
+// separate runs share them.  Recording and replaying cassettes need every request to reach the transport, so they
+// aren't cached.
+func cacheOptions() (wls.CacheOptions, bool) {
+	if NoCache || RecordDir != "" || ReplayDir != "" {
+		return wls.CacheOptions{}, false
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return wls.CacheOptions{}, false
+	}
+	opts := wls.CacheOptions{Store: wls.DiskStore{Dir: filepath.Join(dir, "remy")}}
+	if CacheTTL > 0 {
+		opts.TTLs = map[string]time.Duration{}
+		opts.TTL = CacheTTL
+	}
+	return opts, true
+}
+
+// findLiveClient is findClient for commands whose every request has to reach the AdminServer: those sampling the
+// domain over time, and plan and apply, which must diff the manifest against the live configuration.
+func findLiveClient() *wls.Client {
+	NoCache = true
+	return findClient()
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -37,6 +38,27 @@ const (
 	// UsernameFlag is the flag for specifying/overriding the Username to log in to AdminServer with
 	UsernameFlag = "username"
 
+	// RateLimitFlag is the flag for the most requests a second sent to the AdminServer, with the same key in profiles
+	RateLimitFlag = "ratelimit"
+
+	// BurstFlag is the flag for the requests sent to the AdminServer at once before RateLimitFlag holds the rest back
+	BurstFlag = "burst"
+
+	// MaxInFlightFlag is the flag for the most requests outstanding against the AdminServer at a time
+	MaxInFlightFlag = "maxinflight"
+
+	// ProfilesKey is the table of named domain profiles in the configuration file, each with its own adminurl,
+	// username and password, e.g., [profiles.prod1]
+	ProfilesKey = "profiles"
+
+	// NotifyKey is the table of notifiers and alert rules in the configuration file, e.g., [notify.notifiers.ops]
+	// and [[notify.rules]]
+	NotifyKey = "notify"
+
+	// ServeKey is the table of the serve command's listen address, cache, roles and tokens in the configuration
+	// file, e.g., [serve.roles.ops] and [[serve.tokens]]
+	ServeKey = "serve"
+
 	// RemyKey is the key used to get or set the encryption key used in encrypting a password
 	RemyKey = "remykey"
 
@@ -60,6 +82,39 @@ const (
 
 	// HomeSetFlag is the flag used in the 'config' command to set whether to generate/update the ~/.wlsrest.toml configuration file
 	HomeSetFlag = "home"
+
+	// RecordFlag is the flag for saving every request/response pair made to the AdminServer as cassettes in a directory
+	RecordFlag = "record"
+
+	// ReplayFlag is the flag for serving every request from cassettes in a directory instead of a live AdminServer
+	ReplayFlag = "replay"
+
+	// LogLevelFlag is the flag for the minimum level (debug, info, warn, error) of log messages written to stderr
+	LogLevelFlag = "log-level"
+
+	// LogFormatFlag is the flag for the format (text or json) of log messages written to stderr
+	LogFormatFlag = "log-format"
+
+	// TraceHTTPFlag is the flag for logging the full headers and bodies of every request and response
+	TraceHTTPFlag = "trace-http"
+
+	// RetriesFlag is the flag for how many times to retry a request the AdminServer failed to answer
+	RetriesFlag = "retries"
+
+	// TraceExporterFlag is the flag for where to export OpenTelemetry spans: none, stdout or otlp
+	TraceExporterFlag = "trace-exporter"
+
+	// OTLPEndpointFlag is the flag for the host:port of the OTLP/HTTP collector spans are exported to
+	OTLPEndpointFlag = "otlp-endpoint"
+
+	// OutputFlag is the flag for the format of a command's report: text or json
+	OutputFlag = "output"
+
+	// ServerVersionFlag is the flag for the version command to also show the AdminServer's version and capabilities
+	ServerVersionFlag = "server"
+
+	// PartitionFlag is the flag for the domain partition to scope servers, datasources and applications to
+	PartitionFlag = "partition"
 )
 
 // FullFormat determines whether to request fully-formatted responses from the REST endpoint.  For single-instance requests, this is always
@@ -72,27 +127,70 @@ var FlagLocalConfig bool
 // FlagHomeConfig determines whether to generate/update the $HOME ~/ folder's .wlstrest.cfg file or not
 var FlagHomeConfig bool
 
+// RecordDir is the directory to save request/response cassettes to.  Blank disables recording.
+var RecordDir string
+
+// ReplayDir is the directory to replay request/response cassettes from.  Blank sends requests to the AdminServer.
+var ReplayDir string
+
+// LogLevel is the minimum level of log messages written to stderr.
+var LogLevel string
+
+// LogFormat is the format, text or json, of log messages written to stderr.
+var LogFormat string
+
+// TraceHTTP logs the full headers and bodies of every request and response, at debug level.
+var TraceHTTP bool
+
+// Retries is the number of times to retry a request the AdminServer failed to answer.
+var Retries int
+
+// TraceExporter is where to export OpenTelemetry spans: none, stdout or otlp.
+var TraceExporter string
+
+// OTLPEndpoint is the host:port of the OTLP/HTTP collector spans are exported to with --trace-exporter=otlp.
+var OTLPEndpoint string
+
+// ExitCode is the status remy exits with once the command, and its PersistentPostRun, have finished.  Commands set it
+// rather than calling os.Exit, so spans are still flushed.
+var ExitCode int
+
+// PartitionName is the domain partition servers, datasources and applications are scoped to.  Blank is the whole
+// domain.
+var PartitionName string
+
 // Servers takes a Viper Command and it's argument list, and calls the underlying wls.Servers service to retrieve server
 // information.
 func Servers(cmd *cobra.Command, args []string) {
-	env := findConfiguration()
+	client := findClient()
+	ctx := context.Background()
 	if len(args) > 2 {
 		panic(fmt.Sprintf("Too many arguments.  enter 'help servers' command to find out how to call this"))
 	}
 	if len(args) == 1 {
 		fmt.Printf("Finding Server information for %v\n", args[0])
-		server, err := env.Server(args[0])
+		server, err := client.Server(ctx, args[0])
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Servers: %v", err))
 		}
 		fmt.Printf("Server %v:\n%#v", args[0], server)
 	}
 	if len(args) == 0 {
-		fmt.Printf("Finding all Servers\nUsing Full Format? %v\n", FullFormat)
-		servers, err := env.Servers(FullFormat)
+		fmt.Printf("Finding all Servers\nUsing Full Format? %v\n", listFullFormat())
+		var servers []wls.Server
+		var err error
+		if ListDetail {
+			servers, err = client.ServerDetails(ctx, listDetailOptions())
+			err = warnDetailErrors(err)
+		} else {
+			servers, err = client.Servers(ctx, listQueryOptions())
+		}
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Servers: %v", err))
 		}
+		if applyListFlags(&servers) {
+			return
+		}
 		for i := range servers {
 			fmt.Printf("%#v\n", &servers[i])
 		}
@@ -101,80 +199,157 @@ func Servers(cmd *cobra.Command, args []string) {
 
 // Clusters takes a viper.Command object and arguments to call the AdminServer to retrieve Cluster information
 func Clusters(cmd *cobra.Command, args []string) {
-	env := findConfiguration()
+	client := findClient()
+	ctx := context.Background()
 	if len(args) > 2 {
 		panic(fmt.Sprintf("too many arguments.  enter 'help clusters' command to find out how to call this"))
 	}
 	if len(args) == 1 {
 		fmt.Printf("Finding Cluster information for %v\n", args[0])
-		cluster, err := env.Cluster(args[0])
+		cluster, err := client.Cluster(ctx, args[0])
 		if err != nil {
 			panic(fmt.Sprintf("unable to get Clusters: %v", err))
 		}
 		fmt.Printf("%#v\n", cluster)
 	}
 	if len(args) == 0 {
-		fmt.Printf("Finding All Clusters\nUsing Full Format? %v\n", FullFormat)
-		clusters, err := env.Clusters(FullFormat)
+		fmt.Printf("Finding All Clusters\nUsing Full Format? %v\n", listFullFormat())
+		var clusters []wls.Cluster
+		var err error
+		if ListDetail {
+			clusters, err = client.ClusterDetails(ctx, listDetailOptions())
+			err = warnDetailErrors(err)
+		} else {
+			clusters, err = client.Clusters(ctx, listQueryOptions())
+		}
 		if err != nil {
 			panic(fmt.Sprintf("unable to get Clusters: %v", err))
 		}
+		if applyListFlags(&clusters) {
+			return
+		}
 		for i := range clusters {
 			fmt.Printf("%#v\n", &clusters[i])
 		}
 	}
 }
 
+// Partitions is a command function to call out to the wls.Partitions resource on a remote AdminServer.
+func Partitions(cmd *cobra.Command, args []string) {
+	client := findClient()
+	ctx := context.Background()
+	if len(args) > 1 {
+		panic(fmt.Sprintf("too many arguments.  enter 'help partitions' command to find out how to call this"))
+	}
+	if len(args) == 1 {
+		fmt.Printf("Finding Partition information for %v\n", args[0])
+		partition, err := client.Partition(ctx, args[0])
+		if err != nil {
+			panic(fmt.Sprintf("unable to get Partition: %v", err))
+		}
+		fmt.Printf("%#v\n", partition)
+	}
+	if len(args) == 0 {
+		fmt.Printf("Finding All Partitions\nUsing Full Format? %v\n", listFullFormat())
+		partitions, err := client.Partitions(ctx, listQueryOptions())
+		if err != nil {
+			panic(fmt.Sprintf("unable to get Partitions: %v", err))
+		}
+		if applyListFlags(&partitions) {
+			return
+		}
+		for i := range partitions {
+			fmt.Printf("%#v\n", &partitions[i])
+		}
+	}
+}
+
 // DataSources is a command function to call out the wls.DataSources resource running on a remote AdminServer.
 func DataSources(cmd *cobra.Command, args []string) {
-	env := findConfiguration()
+	client := findClient()
+	ctx := context.Background()
 	if len(args) > 2 {
 		panic(fmt.Sprintf("Too many arguments.  enter 'help datasources' command to find out how to call this"))
 	}
 	if len(args) == 1 {
 		fmt.Printf("Finding DataSource information for %v\n", args[0])
-		datasource, err := env.DataSource(args[0])
+		datasource, err := client.DataSource(ctx, args[0])
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Datasource: %v", err))
 		}
 		fmt.Printf("Datasource %v: %v", args[0], datasource)
 	}
 	if len(args) == 0 {
-		fmt.Printf("Finding all DataSources\nUsing Full Format? %v\n", FullFormat)
-		datasources, err := env.DataSources(FullFormat)
+		fmt.Printf("Finding all DataSources\nUsing Full Format? %v\n", listFullFormat())
+		var datasources []wls.DataSource
+		var err error
+		if ListDetail {
+			datasources, err = client.DataSourceDetails(ctx, listDetailOptions())
+			err = warnDetailErrors(err)
+		} else {
+			datasources, err = client.DataSources(ctx, listQueryOptions())
+		}
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Datasources: %v\n", err))
 		}
+		if applyListFlags(&datasources) {
+			return
+		}
 		fmt.Printf("Datasources:\n%+v", datasources)
 	}
 }
 
 // Applications is a Cobra command function to call out to the wls.Applications resource on a remote AdminServer.
 func Applications(cmd *cobra.Command, args []string) {
-	env := findConfiguration()
+	client := findClient()
+	ctx := context.Background()
 	if len(args) > 2 {
 		panic(fmt.Sprintf("Too many arguments.  enter 'help applications' command to find out how to call this"))
 	}
 	if len(args) == 1 {
 		fmt.Printf("Finding application information for %v\n", args[0])
-		application, err := env.Application(args[0])
+		application, err := client.Application(ctx, args[0])
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Application: %v", err))
 		}
 		fmt.Printf("%#v\n", application)
 	}
 	if len(args) == 0 {
-		fmt.Printf("Finding All Applications\nUsing Full Format? %v\n", FullFormat)
-		applications, err := env.Applications(FullFormat)
+		fmt.Printf("Finding All Applications\nUsing Full Format? %v\n", listFullFormat())
+		var applications []wls.Application
+		var err error
+		if ListDetail {
+			applications, err = client.ApplicationDetails(ctx, listDetailOptions())
+			err = warnDetailErrors(err)
+		} else {
+			applications, err = client.Applications(ctx, listQueryOptions())
+		}
 		if err != nil {
 			panic(fmt.Sprintf("Unable to get Applications: %v\n", err))
 		}
+		if applyListFlags(&applications) {
+			return
+		}
 		for i := range applications {
 			fmt.Printf("%#v", &applications[i])
 		}
 	}
 }
 
+// Version is a Cobra command function printing remy's version, and with --server, the WebLogic version and REST
+// trees detected on the AdminServer.
+func Version(cmd *cobra.Command, args []string) {
+	fmt.Printf("remy version %v\n", remyVersion)
+	if server, _ := cmd.Flags().GetBool(ServerVersionFlag); !server {
+		return
+	}
+	caps, err := findClient().Capabilities(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("Unable to detect AdminServer capabilities: %v", err))
+	}
+	fmt.Print(caps)
+}
+
 // Configure generates or updates a configuration file to store default credentials to use when making REST queries to an AdminServer
 func Configure(cmd *cobra.Command, args []string) {
 	cfg := findConfiguration()
@@ -250,19 +425,70 @@ func findConfiguration() *wls.AdminServer {
 	viper.AutomaticEnv()
 
 	// Finally, load the configuration pieces from Viper
+	return adminServerFrom(viper.GetViper())
+}
+
+// findProfile loads the configuration like findConfiguration, and returns the AdminServer of the named domain profile
+// in it, e.g., the [profiles.prod1] table of wlsrest.toml.
+func findProfile(name string) *wls.AdminServer {
+	findConfiguration()
+	profile := viper.Sub(ProfilesKey + "." + name)
+	if profile == nil {
+		panic(fmt.Sprintf("no [%v.%v] profile found in the configuration", ProfilesKey, name))
+	}
+	return adminServerFrom(profile)
+}
+
+// adminServerFrom reads the username, password and adminurl keys from v, decrypting the password if it is encrypted,
+// and the ratelimit, burst and maxinflight keys limiting the requests sent to it.
+func adminServerFrom(v *viper.Viper) *wls.AdminServer {
 	server := &wls.AdminServer{}
-	server.Username = viper.GetString(UsernameFlag)
-	if strings.Contains(viper.GetString(PasswordFlag), "{AES}") {
-		server.Password = decrypt([]byte(viper.GetString(RemyKey)), viper.GetString(PasswordFlag)[len(EncryptedPrefix):])
+	server.Username = v.GetString(UsernameFlag)
+	if strings.Contains(v.GetString(PasswordFlag), "{AES}") {
+		server.Password = decrypt([]byte(viper.GetString(RemyKey)), v.GetString(PasswordFlag)[len(EncryptedPrefix):])
 	} else {
-		server.Password = viper.GetString(PasswordFlag)
+		server.Password = v.GetString(PasswordFlag)
 	}
-	server.AdminURL = viper.GetString(AdminURLFlag)
-
-	//	fmt.Printf("%+v\n", server)
+	server.AdminURL = v.GetString(AdminURLFlag)
+	server.RateLimit = v.GetFloat64(RateLimitFlag)
+	server.Burst = v.GetInt(BurstFlag)
+	server.MaxInFlight = v.GetInt(MaxInFlightFlag)
 	return server
 }
 
+// findClient creates a wls.Client for the AdminServer configuration found by findConfiguration.
+func findClient() *wls.Client {
+	return newClient(findConfiguration())
+}
+
+// newClient creates a wls.Client for the AdminServer, recording to or replaying from cassettes when --record or --replay
+// are given, and scoped to --partition when given.
+func newClient(cfg *wls.AdminServer) *wls.Client {
+	opts := []wls.ClientOption{
+		wls.WithLogger(newLogger()),
+		wls.WithTraceHTTP(TraceHTTP),
+		wls.WithRetries(Retries),
+	}
+	if RecordDir != "" && ReplayDir != "" {
+		panic(fmt.Sprintf("--%v and --%v cannot be used together", RecordFlag, ReplayFlag))
+	}
+	if RecordDir != "" {
+		opts = append(opts, wls.WithMiddleware(wls.Record(RecordDir)))
+	}
+	if ReplayDir != "" {
+		opts = append(opts, wls.WithTransport(wls.NewReplayer(ReplayDir)))
+	}
+	if PartitionName != "" {
+		opts = append(opts, wls.WithPartition(PartitionName))
+	}
+	if cache, ok := cacheOptions(); ok {
+		opts = append(opts, wls.WithCache(cache))
+	}
+	client := wls.NewClient(cfg, opts...)
+	clients = append(clients, client)
+	return client
+}
+
 // encrypt string to base64 crypto using AES
 func encrypt(key []byte, text string) string {
 	plaintext := []byte(text)
@@ -319,6 +545,13 @@ func Run(cfg *wls.AdminServer) {
 		Use:   "remy",
 		Short: "Query a WebLogic Domain's REST Management Extention-enabled resources",
 		Long:  "Query a WebLogic Domain's resources, including Datasources, Applications, Clusters, and Servers by using the WebLogic RESTful Management Extensions API",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			setupTracing()
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			logRateLimits()
+			shutdownTracing()
+		},
 	}
 
 	// Request the Servers resource, optionally passing a specific [servername] instance to get that particular Server.
@@ -353,6 +586,14 @@ func Run(cfg *wls.AdminServer) {
 		Run:   Applications,
 	}
 
+	// Partition list command.  Pass an optional [partitionname] to get a specific domain partition's details.
+	var partitionsCmd = &cobra.Command{
+		Use:   "partitions [partition to query, blank for ALL]",
+		Short: "Query domain partitions under AdminServer",
+		Long:  "Query the AdminServer of a WebLogic 12.2.1 multi-tenant domain for its partitions, their life cycle state, resource groups and resource consumption",
+		Run:   Partitions,
+	}
+
 	// Generate a configuration setting file in your ~/ home or local directory.
 	// When determined to be in the ~/home, it will be a ~/.wlsrest.toml file.
 	// When a local file, it will be a wlsrest.toml file instead.
@@ -367,11 +608,10 @@ func Run(cfg *wls.AdminServer) {
 	var versionCmd = &cobra.Command{
 		Use:   "version",
 		Short: "Show the version of this command",
-		Long:  "Display the version of this command",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("remy version %v\n", remyVersion)
-		},
+		Long:  "Display the version of this command, and optionally the WebLogic version and REST trees of the AdminServer",
+		Run:   Version,
 	}
+	versionCmd.Flags().Bool(ServerVersionFlag, false, "Also show the AdminServer's WebLogic version and REST capabilities")
 
 	// Add option to pass --full-format for all responses.  Single server, application, etc., requests will always return
 	// full responses, but group-related queries will return shortened versions
@@ -386,6 +626,30 @@ func Run(cfg *wls.AdminServer) {
 	// Allow the Password property to be overridden on the command-line
 	WlsRestCmd.PersistentFlags().StringVarP(&cfg.Password, PasswordFlag, "p", "welcome1", "Password for the user")
 
+	// Protect a busy AdminServer from the requests of fanned out commands
+	WlsRestCmd.PersistentFlags().Float64Var(&cfg.RateLimit, RateLimitFlag, 0, "Most requests a second to send to the AdminServer (0 is unlimited)")
+	WlsRestCmd.PersistentFlags().IntVar(&cfg.Burst, BurstFlag, 0, "Requests to send at once before --ratelimit holds the rest back (0 is 1)")
+	WlsRestCmd.PersistentFlags().IntVar(&cfg.MaxInFlight, MaxInFlightFlag, 0, "Most requests outstanding against the AdminServer at a time (0 is unlimited)")
+
+	// Save every request/response pair to a directory, or serve them back from one without touching the network
+	WlsRestCmd.PersistentFlags().StringVar(&RecordDir, RecordFlag, "", "Record request/response cassettes to this directory")
+	WlsRestCmd.PersistentFlags().StringVar(&ReplayDir, ReplayFlag, "", "Replay request/response cassettes from this directory instead of the AdminServer")
+
+	// Log every request to stderr, optionally dumping full request and response details
+	WlsRestCmd.PersistentFlags().StringVar(&LogLevel, LogLevelFlag, "warn", "Log level: debug, info, warn or error")
+	WlsRestCmd.PersistentFlags().StringVar(&LogFormat, LogFormatFlag, "text", "Log format: text or json")
+	WlsRestCmd.PersistentFlags().BoolVar(&TraceHTTP, TraceHTTPFlag, false, "Log full request and response headers and bodies (implies --log-level=debug)")
+	WlsRestCmd.PersistentFlags().IntVar(&Retries, RetriesFlag, 0, "Number of times to retry a request the AdminServer failed to answer")
+
+	// Scope servers, datasources and applications to a domain partition of a WebLogic 12.2.1 multi-tenant domain
+	WlsRestCmd.PersistentFlags().StringVar(&PartitionName, PartitionFlag, "", "Domain partition to scope servers, datasources and applications to")
+	WlsRestCmd.PersistentFlags().BoolVar(&NoCache, NoCacheFlag, false, "Send every request to the AdminServer rather than reusing cached responses")
+	WlsRestCmd.PersistentFlags().DurationVar(&CacheTTL, CacheTTLFlag, 0, "How long cached responses stay fresh, e.g. 30s (default: by resource)")
+
+	// Export OpenTelemetry spans for every resource call and HTTP request
+	WlsRestCmd.PersistentFlags().StringVar(&TraceExporter, TraceExporterFlag, "none", "Export OpenTelemetry spans to: none, stdout or otlp")
+	WlsRestCmd.PersistentFlags().StringVar(&OTLPEndpoint, OTLPEndpointFlag, "localhost:4318", "host:port of the OTLP/HTTP collector for --trace-exporter=otlp")
+
 	configureCmd.Flags().BoolVar(&FlagHomeConfig, HomeSetFlag, false, "Generate/Update the ~/$HOME config file")
 	configureCmd.Flags().BoolVar(&FlagLocalConfig, LocalSetFlag, false, "Generate/Update the local directory's config file")
 
@@ -396,7 +660,23 @@ func Run(cfg *wls.AdminServer) {
 		panic(errors.WithMessage(err, "cannot bind flag for "+configureCmd.Name()))
 	}
 
-	WlsRestCmd.AddCommand(applicationsCmd, configureCmd, clustersCmd, datasourcesCmd, serversCmd, versionCmd)
+	datasourcesCmd.AddCommand(newAnalyzeDataSourcesCmd(), newRACCmd())
+	applicationsCmd.AddCommand(newWorkloadCmd())
+	clustersCmd.AddCommand(newClusterHealthCmd())
+	serversCmd.AddCommand(newCapacityCmd())
+
+	for _, listCmd := range []*cobra.Command{applicationsCmd, clustersCmd, datasourcesCmd, partitionsCmd, serversCmd} {
+		addListFlags(listCmd)
+	}
+	for _, listCmd := range []*cobra.Command{applicationsCmd, clustersCmd, datasourcesCmd, serversCmd} {
+		addDetailFlags(listCmd)
+	}
+
+	WlsRestCmd.AddCommand(applicationsCmd, configureCmd, clustersCmd, datasourcesCmd, partitionsCmd, serversCmd, versionCmd, newFakeServerCmd())
+	WlsRestCmd.AddCommand(newHistoryCmds()...)
+	WlsRestCmd.AddCommand(newNotifyCmd(), newServeCmd(), newGRPCServerCmd())
+	WlsRestCmd.AddCommand(newSnapshotCmd(), newDriftCmd(), newGraphCmd())
+	WlsRestCmd.AddCommand(newApplyCmds()...)
 	if err := WlsRestCmd.Execute(); err != nil {
 		panic(errors.WithMessage(err, "error executing "+WlsRestCmd.Name()))
 	}
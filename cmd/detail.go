@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/klauern/remy"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// DetailFlag is the flag for list commands to fetch each resource's full detail after listing them
+	DetailFlag = "detail"
+
+	// DetailWorkersFlag is the flag for how many details --detail fetches at once
+	DetailWorkersFlag = "detail-workers"
+)
+
+// ListDetail lists resources in the short format, then fetches each one's full detail concurrently.
+var ListDetail bool
+
+// DetailWorkers is the most details ListDetail fetches at once.
+var DetailWorkers int
+
+// addDetailFlags adds --detail and --detail-workers to a list command.
+func addDetailFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&ListDetail, DetailFlag, false, "List in the short format, then fetch each resource's full detail concurrently")
+	flags.IntVar(&DetailWorkers, DetailWorkersFlag, remy.DefaultDetailWorkers, "Fetch at most this many details at once with --detail")
+}
+
+// listDetailOptions are the DetailOptions of the detail flags, showing progress on stderr when it is a terminal.
+func listDetailOptions() remy.DetailOptions {
+	opts := remy.DetailOptions{Workers: DetailWorkers}
+	if isTerminal(os.Stderr) {
+		opts.Progress = printProgress
+	}
+	return opts
+}
+
+// printProgress overwrites a line on stderr with how many details have been fetched.
+func printProgress(done, total int) {
+	fmt.Fprintf(os.Stderr, "\rFetching details: %d/%d", done, total)
+	if done == total {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// warnDetailErrors prints each resource whose detail couldn't be fetched to stderr, as the rest of the list is still
+// worth printing.  It returns any other error.
+func warnDetailErrors(err error) error {
+	errs, ok := err.(remy.DetailErrors)
+	if !ok {
+		return err
+	}
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "Unable to get the detail of %v: %v\n", e.Name, e.Err)
+	}
+	return nil
+}
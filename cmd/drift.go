@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/klauern/remy/drift"
+	"github.com/spf13/cobra"
+)
+
+// DesiredFlag is the flag for the YAML desired-state file domains are compared to
+const DesiredFlag = "desired"
+
+// driftOptions holds the flags for the drift command.
+var driftOptions struct {
+	desired string
+	output  string
+}
+
+// Drift compares the domain profiles given to the first of them, or to a --desired state file, and sets a non-zero
+// ExitCode when any of them differ.
+func Drift(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	capture := func(profile string) *drift.State {
+		cfg := findConfiguration()
+		if profile != "" {
+			cfg = findProfile(profile)
+		}
+		s, err := drift.Capture(ctx, newClient(cfg))
+		if err != nil {
+			panic(fmt.Sprintf("Unable to get the state of %v: %v", cfg.AdminURL, err))
+		}
+		return s
+	}
+
+	report := &drift.Report{}
+	var want *drift.State
+	switch {
+	case driftOptions.desired != "":
+		var err error
+		if want, err = drift.Load(driftOptions.desired); err != nil {
+			panic(err.Error())
+		}
+		report.Baseline = driftOptions.desired
+		if len(args) == 0 {
+			report.Add(findConfiguration().AdminURL, want, capture(""))
+		}
+	case len(args) < 2:
+		panic(fmt.Sprintf("Compare at least two profiles, or one or more against a --%v state file", DesiredFlag))
+	default:
+		report.Baseline = args[0]
+		want = capture(args[0])
+		args = args[1:]
+	}
+	for _, profile := range args {
+		report.Add(profile, want, capture(profile))
+	}
+
+	printReport(report, driftOptions.output)
+	if !report.Empty() {
+		ExitCode = 1
+	}
+}
+
+// newDriftCmd creates the drift command and its flags.
+func newDriftCmd() *cobra.Command {
+	driftCmd := &cobra.Command{
+		Use:   "drift [profile...]",
+		Short: "Report configuration drift between domains, or against a desired state",
+		Long: "Compare the domain profiles given to the first of them, or each of them (or the configured domain) to a " +
+			"--desired YAML state file: which applications are deployed where, data source names and types, cluster " +
+			"membership, and WebLogicVersion and JavaVersion on each server.  Exits with status 1 when any drift is found.",
+		Run: Drift,
+	}
+	driftCmd.Flags().StringVar(&driftOptions.desired, DesiredFlag, "", "YAML desired-state file to compare domains to")
+	driftCmd.Flags().StringVarP(&driftOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	return driftCmd
+}
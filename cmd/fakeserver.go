@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauern/remy/remytest"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ListenFlag is the address the fake-server command listens on
+	ListenFlag = "listen"
+
+	// LatencyFlag is the delay the fake-server command adds to every response
+	LatencyFlag = "latency"
+
+	// InjectErrorFlag makes the fake-server command fail requests for a resource with a status code, as resource=code
+	InjectErrorFlag = "inject-error"
+
+	// NoAuthFlag makes the fake-server command accept requests without checking credentials
+	NoAuthFlag = "no-auth"
+
+	// WebLogicVersionFlag is the WebLogic release the fake-server command emulates, deciding which REST trees it serves
+	WebLogicVersionFlag = "weblogic-version"
+)
+
+// fakeServerOptions holds the flags for the fake-server command.
+var fakeServerOptions struct {
+	listen  string
+	latency time.Duration
+	errors  []string
+	noAuth  bool
+	version string
+	domain  remytest.DomainConfig
+}
+
+// FakeServer runs a simulated WebLogic AdminServer on the --listen address until interrupted.  It accepts the same
+// --username and --password as every other command, so `remy --adminurl=http://localhost:7001 servers` works against
+// it unchanged.
+func FakeServer(cmd *cobra.Command, args []string) {
+	opts := []remytest.Option{
+		remytest.WithDomain(remytest.GenerateDomain(fakeServerOptions.domain)),
+		remytest.WithLatency(fakeServerOptions.latency),
+		remytest.WithVersion(fakeServerOptions.version),
+	}
+	if !fakeServerOptions.noAuth {
+		username, _ := cmd.Flags().GetString(UsernameFlag)
+		password, _ := cmd.Flags().GetString(PasswordFlag)
+		opts = append(opts, remytest.WithCredentials(username, password))
+	}
+	for _, e := range fakeServerOptions.errors {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 {
+			panic(fmt.Sprintf("invalid --%v %q, expected resource=statuscode", InjectErrorFlag, e))
+		}
+		code, err := strconv.Atoi(parts[1])
+		if err != nil {
+			panic(fmt.Sprintf("invalid status code in --%v %q: %v", InjectErrorFlag, e, err))
+		}
+		opts = append(opts, remytest.WithError(parts[0], code))
+	}
+
+	fmt.Printf("Serving fake WebLogic domain on %v\n", fakeServerOptions.listen)
+	if err := http.ListenAndServe(fakeServerOptions.listen, remytest.New(opts...)); err != nil {
+		panic(fmt.Sprintf("unable to run fake server: %v", err))
+	}
+}
+
+// newFakeServerCmd creates the fake-server command and its flags.
+func newFakeServerCmd() *cobra.Command {
+	fakeServerCmd := &cobra.Command{
+		Use:   "fake-server",
+		Short: "Run a simulated WebLogic AdminServer for local development",
+		Long: "Run a stateful, simulated WebLogic domain answering the tenant-monitoring REST resources, with a configurable " +
+			"number of servers, clusters, datasources and applications, plus optional latency and error injection",
+		Run: FakeServer,
+	}
+	flags := fakeServerCmd.Flags()
+	flags.StringVar(&fakeServerOptions.listen, ListenFlag, "localhost:7001", "Address to listen on")
+	flags.DurationVar(&fakeServerOptions.latency, LatencyFlag, 0, "Delay to add to every response")
+	flags.StringSliceVar(&fakeServerOptions.errors, InjectErrorFlag, nil, "Fail requests for a resource (e.g. servers, clusters/cluster1, or *) as resource=statuscode")
+	flags.BoolVar(&fakeServerOptions.noAuth, NoAuthFlag, false, "Accept requests without checking the username and password")
+	flags.StringVar(&fakeServerOptions.version, WebLogicVersionFlag, remytest.DefaultVersion, "WebLogic release to emulate, e.g. 12.2.1.4.0 or 14.1.1.0.0")
+	flags.IntVar(&fakeServerOptions.domain.ManagedServers, "servers", 4, "Number of managed servers")
+	flags.IntVar(&fakeServerOptions.domain.Clusters, "clusters", 2, "Number of clusters")
+	flags.IntVar(&fakeServerOptions.domain.DataSources, "datasources", 2, "Number of JDBC data sources")
+	flags.IntVar(&fakeServerOptions.domain.Applications, "applications", 3, "Number of applications")
+	flags.IntVar(&fakeServerOptions.domain.Partitions, "partitions", 0, "Number of domain partitions, served with a 12.2.1.x --weblogic-version")
+	return fakeServerCmd
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/klauern/remy/graph"
+	"github.com/klauern/remy/snapshot"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// FormatFlag is the flag for the format the graph is exported in: dot, mermaid or json
+	FormatFlag = "format"
+	// SnapshotFlag is the flag for a snapshot file to read the domain from, instead of the live domain
+	SnapshotFlag = "snapshot"
+)
+
+// graphOptions holds the flags for the graph command and its impact subcommand.
+var graphOptions struct {
+	format   string
+	snapshot string
+	output   string
+}
+
+// Graph exports the topology of the domain as Graphviz DOT, Mermaid or JSON.
+func Graph(cmd *cobra.Command, args []string) {
+	g := buildGraph()
+	switch graphOptions.format {
+	case "dot", "":
+		fmt.Print(g.DOT())
+	case "mermaid":
+		fmt.Print(g.Mermaid())
+	case "json":
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			panic(fmt.Sprintf("Unable to encode graph: %v", err))
+		}
+		fmt.Println(string(data))
+	default:
+		panic(fmt.Sprintf("invalid --%v %q, expected dot, mermaid or json", FormatFlag, graphOptions.format))
+	}
+}
+
+// GraphImpact reports everything that depends on a resource, and so is affected if it goes down.
+func GraphImpact(cmd *cobra.Command, args []string) {
+	kind := graph.Kind(strings.ToLower(args[0]))
+	impact, err := buildGraph().Impact(kind, args[1])
+	if err != nil {
+		panic(err.Error())
+	}
+	printReport(impact, graphOptions.output)
+}
+
+// buildGraph builds the graph of the --snapshot file, or of the live domain.
+func buildGraph() *graph.Graph {
+	var s *snapshot.Snapshot
+	var err error
+	if graphOptions.snapshot != "" {
+		s, err = snapshot.Load(graphOptions.snapshot)
+	} else {
+		s, err = snapshot.Take(context.Background(), findClient())
+	}
+	if err != nil {
+		panic(fmt.Sprintf("Unable to get the domain to graph: %v", err))
+	}
+	return graph.Build(s)
+}
+
+// newGraphCmd creates the graph command and its impact subcommand.
+func newGraphCmd() *cobra.Command {
+	var kinds []string
+	for _, k := range graph.Kinds {
+		kinds = append(kinds, string(k))
+	}
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the topology of the domain",
+		Long: "Build the graph of applications, their targets, cluster members, datasources and their instances, and the " +
+			"work managers and thread constraints applications use, and export it as Graphviz DOT, Mermaid or JSON",
+		Args: cobra.NoArgs,
+		Run:  Graph,
+	}
+	graphCmd.Flags().StringVar(&graphOptions.format, FormatFlag, "dot", "Graph format: dot, mermaid or json")
+	graphCmd.PersistentFlags().StringVar(&graphOptions.snapshot, SnapshotFlag, "", "Graph a snapshot file instead of the live domain")
+	impactCmd := &cobra.Command{
+		Use:   "impact <" + strings.Join(kinds, "|") + "> <name>",
+		Short: "Report what is affected if a resource goes down",
+		Long: "Walk the topology of the domain backwards from a resource to report everything that depends on it, e.g., " +
+			"the applications that break if a datasource goes down",
+		Args: cobra.ExactArgs(2),
+		Run:  GraphImpact,
+	}
+	impactCmd.Flags().StringVarP(&graphOptions.output, OutputFlag, "o", "text", "Report format: text or json")
+	graphCmd.AddCommand(impactCmd)
+	return graphCmd
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/klauern/remy/gateway"
+	"github.com/klauern/remy/rpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// grpcServerOptions holds the flags for the grpc-server command.
+var grpcServerOptions struct {
+	listen string
+	noAuth bool
+}
+
+// GRPCServer serves the Remy gRPC service over the configured domain, or the named profile, until interrupted.  Calls
+// are checked against serve's [[serve.tokens]], with the profile's name, or "default", as the domain their roles
+// must grant.
+func GRPCServer(cmd *cobra.Command, args []string) {
+	// Watch streams the changes seen by polling, so every poll has to reach the AdminServer.
+	NoCache = true
+	serveCfg := findGatewayConfig()
+	if len(serveCfg.Tokens) == 0 && !grpcServerOptions.noAuth {
+		panic(fmt.Sprintf("no [[%v.tokens]] configured: add tokens, or pass --%v to serve without authentication", ServeKey, NoAuthFlag))
+	}
+	if grpcServerOptions.noAuth {
+		serveCfg.Tokens = nil
+	}
+	cfg := findConfiguration()
+	domain := "default"
+	if len(args) > 0 {
+		cfg = findProfile(args[0])
+		domain = args[0]
+	}
+	unary, stream, err := gateway.GRPCAuth(domain, serveCfg.Roles, serveCfg.Tokens)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid [%v] configuration: %v", ServeKey, err))
+	}
+	lis, err := net.Listen("tcp", grpcServerOptions.listen)
+	if err != nil {
+		panic(fmt.Sprintf("unable to listen on %v: %v", grpcServerOptions.listen, err))
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	rpc.RegisterRemyServer(server, rpc.NewService(newClient(cfg)))
+	reflection.Register(server)
+
+	fmt.Printf("Serving the Remy gRPC service for %v on %v\n", cfg.AdminURL, lis.Addr())
+	if err := server.Serve(lis); err != nil {
+		panic(fmt.Sprintf("unable to serve: %v", err))
+	}
+}
+
+// newGRPCServerCmd creates the grpc-server command and its flags.
+func newGRPCServerCmd() *cobra.Command {
+	grpcServerCmd := &cobra.Command{
+		Use:   "grpc-server [profile to serve, blank for the configured domain]",
+		Short: "Serve the domain's resources over gRPC",
+		Long: "Serve the remy.v1.Remy gRPC service defined in rpc/remy.proto: unary Get and List calls for servers, " +
+			"clusters, datasources and applications, and a Watch stream of the changes seen by polling the domain.  " +
+			"Calls need a bearer token from the [[serve.tokens]] in their authorization metadata, unless --no-auth is given",
+		Args: cobra.MaximumNArgs(1),
+		Run:  GRPCServer,
+	}
+	grpcServerCmd.Flags().StringVar(&grpcServerOptions.listen, ListenFlag, "localhost:9090", "Address to listen on")
+	grpcServerCmd.Flags().BoolVar(&grpcServerOptions.noAuth, NoAuthFlag, false, "Serve without checking bearer tokens")
+	return grpcServerCmd
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauern/remy/history"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// HistoryDBFlag is the path to the history store written by collect and read by history
+	HistoryDBFlag = "db"
+
+	// IntervalFlag is the time between polls
+	IntervalFlag = "interval"
+
+	// RetentionFlag is how long collected samples are kept
+	RetentionFlag = "retention"
+
+	// DownsampleAfterFlag is the age after which collected samples are averaged
+	DownsampleAfterFlag = "downsample-after"
+
+	// DownsampleStepFlag is the period old samples are averaged over
+	DownsampleStepFlag = "downsample-step"
+
+	// MaintenanceIntervalFlag is the time between applying retention and downsampling to the history store
+	MaintenanceIntervalFlag = "maintenance-interval"
+
+	// MetricFlag is the metric the history command shows
+	MetricFlag = "metric"
+
+	// SinceFlag is how far back the history command shows
+	SinceFlag = "since"
+
+	// ChartFlag makes the history command draw a chart instead of listing values
+	ChartFlag = "chart"
+)
+
+// historyOptions holds the flags for the collect and history commands.
+var historyOptions struct {
+	db                  string
+	interval            time.Duration
+	retention           string
+	downsampleAfter     string
+	downsampleStep      time.Duration
+	maintenanceInterval time.Duration
+	metric              string
+	since               string
+	chart               bool
+}
+
+// Collect polls the AdminServer on an interval, writing every numeric statistic to the history store until interrupted.
+func Collect(cmd *cobra.Command, args []string) {
+	if historyOptions.interval <= 0 {
+		panic(fmt.Sprintf("invalid --%v %v: must be positive", IntervalFlag, historyOptions.interval))
+	}
+	c := &history.Collector{
+		Client:              findLiveClient(),
+		Path:                historyOptions.db,
+		Interval:            historyOptions.interval,
+		Retention:           parseDuration(RetentionFlag, historyOptions.retention),
+		DownsampleAfter:     parseDuration(DownsampleAfterFlag, historyOptions.downsampleAfter),
+		DownsampleStep:      historyOptions.downsampleStep,
+		MaintenanceInterval: historyOptions.maintenanceInterval,
+		OnError: func(err error) {
+			fmt.Fprintf(os.Stderr, "unable to collect samples: %v\n", err)
+		},
+	}
+	if engine := findNotifier(); engine != nil {
+		notifyOnPoll(c, engine)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Printf("Collecting samples every %v into %v\n", c.Interval, historyOptions.db)
+	c.Run(ctx)
+}
+
+// History prints the collected series of a metric for a resource, or lists the names and metrics available.
+func History(cmd *cobra.Command, args []string) {
+	store, err := history.OpenReadOnly(historyOptions.db)
+	if err != nil {
+		panic(err.Error())
+	}
+	defer store.Close()
+
+	switch {
+	case len(args) == 1:
+		names, err := store.Names(args[0])
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Printf("%v with history:\n%v\n", args[0], strings.Join(names, "\n"))
+	case historyOptions.metric == "":
+		metrics, err := store.Metrics(args[0], args[1])
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Printf("Metrics for %v %v:\n%v\n", args[0], args[1], strings.Join(metrics, "\n"))
+	default:
+		since := time.Now().Add(-parseDuration(SinceFlag, historyOptions.since))
+		points, err := store.Series(args[0], args[1], historyOptions.metric, since)
+		if err != nil {
+			panic(err.Error())
+		}
+		fmt.Printf("%v of %v %v since %v\n", historyOptions.metric, args[0], args[1], since.Format(time.RFC3339))
+		if historyOptions.chart {
+			fmt.Println(sparkline(points))
+			return
+		}
+		for _, p := range points {
+			fmt.Printf("%v  %v\n", p.Time.Format(time.RFC3339), strconv.FormatFloat(p.Value, 'f', -1, 64))
+		}
+	}
+}
+
+// sparkline draws the points as a single line of block characters, scaled between their minimum and maximum.
+func sparkline(points []history.Point) string {
+	if len(points) == 0 {
+		return ""
+	}
+	ticks := []rune("▁▂▃▄▅▆▇█")
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		min = math.Min(min, p.Value)
+		max = math.Max(max, p.Value)
+	}
+	line := make([]rune, len(points))
+	for i, p := range points {
+		tick := 0
+		if max > min {
+			tick = int((p.Value - min) / (max - min) * float64(len(ticks)-1))
+		}
+		line[i] = ticks[tick]
+	}
+	return fmt.Sprintf("%v  (min %v, max %v)", string(line), strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))
+}
+
+// parseDuration parses a duration flag, allowing a "d" suffix for days on top of what time.ParseDuration accepts.
+func parseDuration(flag, value string) time.Duration {
+	if value == "" || value == "0" {
+		return 0
+	}
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(value, "d"), 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid --%v %q: %v", flag, value, err))
+		}
+		return time.Duration(days * float64(24*time.Hour))
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid --%v %q: %v", flag, value, err))
+	}
+	return d
+}
+
+// newHistoryCmds creates the collect and history commands and their flags.
+func newHistoryCmds() []*cobra.Command {
+	collectCmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Poll the AdminServer and record resource statistics over time",
+		Long: "Poll the full format servers, datasources, clusters and applications on an interval, saving every numeric " +
+			"statistic to a local history store with retention and downsampling.  When the configuration has a [notify] " +
+			"table, each poll is checked against its rules and alerts are sent to its notifiers",
+		Run: Collect,
+	}
+	collectCmd.Flags().DurationVar(&historyOptions.interval, IntervalFlag, time.Minute, "Time between polls")
+	collectCmd.Flags().StringVar(&historyOptions.retention, RetentionFlag, "7d", "How long to keep samples (0 keeps them forever)")
+	collectCmd.Flags().StringVar(&historyOptions.downsampleAfter, DownsampleAfterFlag, "1d", "Average samples older than this (0 disables downsampling)")
+	collectCmd.Flags().DurationVar(&historyOptions.downsampleStep, DownsampleStepFlag, 5*time.Minute, "Period to average old samples over")
+	collectCmd.Flags().DurationVar(&historyOptions.maintenanceInterval, MaintenanceIntervalFlag, history.DefaultMaintenanceInterval, "Time between applying retention and downsampling")
+
+	historyCmd := &cobra.Command{
+		Use:   "history <servers|clusters|datasources|applications> [name]",
+		Short: "Show the recorded history of a resource's statistics",
+		Long: "Show the series of a --metric recorded by collect for a resource name.  Leave out the --metric to list the " +
+			"metrics available, or the name to list the names recorded.  Data source instances are named datasource/server, " +
+			"cluster members cluster/server and application work managers, constraints and request classes app/item/server",
+		Args: cobra.RangeArgs(1, 2),
+		Run:  History,
+	}
+	historyCmd.Flags().StringVar(&historyOptions.metric, MetricFlag, "", "Metric to show, e.g. HeapFreeCurrent")
+	historyCmd.Flags().StringVar(&historyOptions.since, SinceFlag, "1h", "How far back to show, e.g. 6h or 2d")
+	historyCmd.Flags().BoolVar(&historyOptions.chart, ChartFlag, false, "Draw a chart instead of listing values")
+
+	for _, c := range []*cobra.Command{collectCmd, historyCmd} {
+		c.Flags().StringVar(&historyOptions.db, HistoryDBFlag, "remy-history.db", "Path to the history store")
+	}
+	return []*cobra.Command{collectCmd, historyCmd}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/remy/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	var durationTests = []struct {
+		in  string
+		out time.Duration
+	}{
+		{"6h", 6 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"0", 0},
+		{"", 0},
+	}
+	for _, tt := range durationTests {
+		if got := parseDuration(SinceFlag, tt.in); got != tt.out {
+			t.Errorf("want %v, got %v", tt.out, got)
+		}
+	}
+	assert.Panics(t, func() { parseDuration(SinceFlag, "sixhours") })
+}
+
+func TestSparkline(t *testing.T) {
+	points := []history.Point{{Value: 0}, {Value: 50}, {Value: 100}}
+	assert.Equal(t, "▁▄█  (min 0, max 100)", sparkline(points))
+	assert.Equal(t, "", sparkline(nil))
+}
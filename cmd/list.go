@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/query"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// FilterFlag is the flag for a filter expression list commands keep only the matching resources of
+	FilterFlag = "filter"
+
+	// SortByFlag is the flag for the fields list commands sort resources by
+	SortByFlag = "sort-by"
+
+	// ColumnsFlag is the flag for the fields list commands print as a table
+	ColumnsFlag = "columns"
+
+	// LimitFlag is the flag for the most resources list commands print
+	LimitFlag = "limit"
+
+	// OffsetFlag is the flag for how many resources list commands skip before printing any
+	OffsetFlag = "offset"
+
+	// FieldsFlag is the flag for the only fields list commands request of each resource
+	FieldsFlag = "fields"
+
+	// ExcludeFieldsFlag is the flag for the fields list commands leave out of each resource
+	ExcludeFieldsFlag = "exclude-fields"
+)
+
+// listOptions holds the flags narrowing what the servers, clusters, datasources, applications and partitions commands
+// list.
+var listOptions query.Options
+
+// fieldOptions holds the flags for the fields the list commands request of each resource.
+var fieldOptions remy.QueryOptions
+
+// addListFlags adds the flags for listOptions to a list command.
+func addListFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&listOptions.Filter, FilterFlag, "", "Only list resources matching an expression, e.g. 'state!=RUNNING && cluster==c1'")
+	flags.StringSliceVar(&listOptions.SortBy, SortByFlag, nil, "Sort by these fields, descending when prefixed with -, e.g. -HeapFreeCurrent")
+	flags.StringSliceVar(&listOptions.Columns, ColumnsFlag, nil, "Print only these fields, as a table, e.g. name,state,health")
+	flags.Var((*countValue)(&listOptions.Limit), LimitFlag, "List at most this many resources, after filtering and sorting")
+	flags.Var((*countValue)(&listOptions.Offset), OffsetFlag, "Skip this many resources, after filtering and sorting")
+	flags.StringSliceVar(&fieldOptions.Fields, FieldsFlag, nil, "Request only these fields of each resource, e.g. state,heapFreeCurrent")
+	flags.StringSliceVar(&fieldOptions.ExcludeFields, ExcludeFieldsFlag, nil, "Leave these fields out of each resource, e.g. workManagers,requestClasses")
+}
+
+// countValue is an int flag that can't be negative, such as --limit and --offset.
+type countValue int
+
+func (c *countValue) String() string {
+	return strconv.Itoa(int(*c))
+}
+
+// Set parses s, rejecting negative counts.
+func (c *countValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	*c = countValue(n)
+	return nil
+}
+
+func (c *countValue) Type() string {
+	return "int"
+}
+
+// listFullFormat is whether a list command requests the full format: when asked to with --full-format, or when the
+// list flags may use fields only in the full format.
+func listFullFormat() bool {
+	return FullFormat || ListDetail || listOptions.Filter != "" || len(listOptions.SortBy) > 0 || len(listOptions.Columns) > 0 ||
+		len(fieldOptions.Fields) > 0
+}
+
+// pagedByQuery is whether --offset and --limit are requested with the list, rather than applied to it after
+// --filter and --sort-by, or to the details fetched by --detail.
+func pagedByQuery() bool {
+	return listOptions.Filter == "" && len(listOptions.SortBy) == 0 && !ListDetail
+}
+
+// listQueryOptions are the QueryOptions a list command requests its resources with.
+func listQueryOptions() remy.QueryOptions {
+	opts := fieldOptions
+	opts.Full = listFullFormat()
+	if pagedByQuery() {
+		opts.Offset, opts.Limit = listOptions.Offset, listOptions.Limit
+	}
+	return opts
+}
+
+// applyListFlags filters, sorts and limits list, a pointer to a slice of resources, by the list flags, then prints it
+// as a table when given --columns.  It returns whether it printed the list, leaving the caller to print it otherwise.
+func applyListFlags(list interface{}) bool {
+	opts := listOptions
+	if pagedByQuery() {
+		opts.Offset, opts.Limit = 0, 0
+	}
+	if err := query.Apply(list, opts); err != nil {
+		panic(fmt.Sprintf("Invalid --%v, --%v or --%v: %v", FilterFlag, SortByFlag, ColumnsFlag, err))
+	}
+	if len(listOptions.Columns) == 0 {
+		return false
+	}
+	table, err := query.NewTable(list, listOptions.Columns)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid --%v: %v", ColumnsFlag, err))
+	}
+	fmt.Print(table)
+	return true
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFlagsRejectNegativeCounts(t *testing.T) {
+	cmd := &cobra.Command{}
+	addListFlags(cmd)
+	defer func() { listOptions.Offset, listOptions.Limit = 0, 0 }()
+
+	assert.NoError(t, cmd.ParseFlags([]string{"--offset", "2", "--limit", "3"}))
+	assert.Equal(t, 2, listOptions.Offset)
+	assert.Equal(t, 3, listOptions.Limit)
+	assert.Error(t, cmd.ParseFlags([]string{"--offset", "-1"}))
+	assert.Error(t, cmd.ParseFlags([]string{"--limit", "-2"}))
+	assert.Equal(t, 2, listOptions.Offset)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	wls "github.com/klauern/remy"
+)
+
+// newLogger creates the logger every command logs to, writing to stderr at the --log-level and in the --log-format
+// given.
+func newLogger() *slog.Logger {
+	level := parseLogLevel(LogLevel)
+	if TraceHTTP {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(LogFormat) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	case "text", "":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts))
+	}
+	panic(fmt.Sprintf("invalid --%v %q, expected text or json", LogFormatFlag, LogFormat))
+}
+
+// parseLogLevel converts a --log-level into a slog.Level.
+func parseLogLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		panic(fmt.Sprintf("invalid --%v %q, expected debug, info, warn or error", LogLevelFlag, level))
+	}
+	return l
+}
+
+// clients are the Clients newClient created for the command, whose rate limits are logged once it finishes.
+var clients []*wls.Client
+
+// logRateLimits logs, at info, how much the rate limit of each AdminServer the command sent requests to held them back.
+func logRateLimits() {
+	logger := newLogger()
+	logged := make(map[string]bool)
+	for _, c := range clients {
+		url := c.AdminServer().AdminURL
+		stats := c.RateLimitStats()
+		if logged[url] || stats.Requests == 0 {
+			continue
+		}
+		logged[url] = true
+		logger.Info("rate limit", "adminurl", url, "requests", stats.Requests, "delayed", stats.Delayed,
+			"waited", stats.Waited, "maxWait", stats.MaxWait)
+	}
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	var logLevelTests = []struct {
+		in  string
+		out slog.Level
+	}{
+		{"debug", slog.LevelDebug},
+		{"INFO", slog.LevelInfo},
+		{"warn", slog.LevelWarn},
+		{"error", slog.LevelError},
+	}
+	for _, tt := range logLevelTests {
+		if got := parseLogLevel(tt.in); got != tt.out {
+			t.Errorf("want %v, got %v", tt.out, got)
+		}
+	}
+}
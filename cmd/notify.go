@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/klauern/remy/history"
+	"github.com/klauern/remy/notify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// findNotifier creates the notify.Engine configured by the [notify] table, or nil when there isn't one.
+func findNotifier() *notify.Engine {
+	findConfiguration()
+	if !viper.IsSet(NotifyKey) {
+		return nil
+	}
+	var cfg notify.Config
+	if err := viper.UnmarshalKey(NotifyKey, &cfg); err != nil {
+		panic(fmt.Sprintf("Unable to read the [%v] configuration: %v", NotifyKey, err))
+	}
+	engine, err := notify.New(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid [%v] configuration: %v", NotifyKey, err))
+	}
+	engine.OnError = func(err error) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return engine
+}
+
+// notifyOnPoll checks every poll the Collector makes against the engine's rules, printing the alerts sent.
+func notifyOnPoll(c *history.Collector, engine *notify.Engine) {
+	c.OnPoll = func(ctx context.Context, p *history.Poll) {
+		for _, ev := range engine.Evaluate(ctx, p.Time, notify.Observe(p)) {
+			fmt.Printf("%v %v\n", p.Time.Format(time.RFC3339), ev)
+		}
+	}
+	fmt.Printf("Checking %v notification rules on every poll\n", len(engine.Rules()))
+}
+
+// NotifyTest sends a test notification to the named notifiers, or all of them.
+func NotifyTest(cmd *cobra.Command, args []string) {
+	engine := findNotifier()
+	if engine == nil {
+		panic(fmt.Sprintf("no [%v] table found in the configuration", NotifyKey))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := engine.Test(ctx, args...); err != nil {
+		panic(err.Error())
+	}
+	fmt.Println("Test notification sent")
+}
+
+// newNotifyCmd creates the notify command and its test subcommand.
+func newNotifyCmd() *cobra.Command {
+	notifyCmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Manage alert notifications sent while collecting",
+		Long: "Alerts are raised by the [[notify.rules]] in the configuration file on every poll collect makes, and sent " +
+			"to the [notify.notifiers.<name>] they are routed to: JSON webhooks, Slack or Teams incoming webhooks, SMTP " +
+			"email or syslog",
+	}
+	testCmd := &cobra.Command{
+		Use:   "test [notifiers to test, blank for ALL]",
+		Short: "Send a test notification",
+		Long:  "Send a test notification to each of the named notifiers, or every notifier, to check they are configured correctly",
+		Run:   NotifyTest,
+	}
+	notifyCmd.AddCommand(testCmd)
+	return notifyCmd
+}
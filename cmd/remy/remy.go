@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	cfg "github.com/klauern/remy"
 	"github.com/klauern/remy/cmd"
 )
@@ -10,4 +12,7 @@ var Config cfg.AdminServer
 
 func main() {
 	cmd.Run(&Config)
+	if cmd.ExitCode != 0 {
+		os.Exit(cmd.ExitCode)
+	}
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/gateway"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// serveOptions holds the flags for the serve command.
+var serveOptions struct {
+	listen               string
+	noAuth               bool
+	staleWhileRevalidate time.Duration
+}
+
+// findGatewayConfig reads the [serve] table, if there is one.
+func findGatewayConfig() gateway.Config {
+	findConfiguration()
+	cfg := gateway.Config{Listen: "localhost:8080", StaleWhileRevalidate: 30 * time.Second}
+	if viper.IsSet(ServeKey) {
+		if err := viper.UnmarshalKey(ServeKey, &cfg); err != nil {
+			panic(fmt.Sprintf("Unable to read the [%v] configuration: %v", ServeKey, err))
+		}
+	}
+	return cfg
+}
+
+// findDomains creates a client for each named profile, or every profile in the configuration.  Without any profiles,
+// the default configuration is served as the "default" domain.
+func findDomains(profiles []string) map[string]*remy.Client {
+	findConfiguration()
+	if len(profiles) == 0 {
+		for name := range viper.GetStringMap(ProfilesKey) {
+			profiles = append(profiles, name)
+		}
+	}
+	domains := make(map[string]*remy.Client)
+	if len(profiles) == 0 {
+		domains["default"] = findClient()
+		return domains
+	}
+	for _, name := range profiles {
+		domains[name] = newClient(findProfile(name))
+	}
+	return domains
+}
+
+// Serve runs the read-only API gateway over the domains of the named profiles until interrupted.
+func Serve(cmd *cobra.Command, args []string) {
+	cfg := findGatewayConfig()
+	if cmd.Flags().Changed(ListenFlag) || cfg.Listen == "" {
+		cfg.Listen = serveOptions.listen
+	}
+	if cmd.Flags().Changed(StaleWhileRevalidateFlag) {
+		cfg.StaleWhileRevalidate = serveOptions.staleWhileRevalidate
+	}
+	if len(cfg.Tokens) == 0 && !serveOptions.noAuth {
+		panic(fmt.Sprintf("no [[%v.tokens]] configured: add tokens, or pass --%v to serve without authentication", ServeKey, NoAuthFlag))
+	}
+	if serveOptions.noAuth {
+		cfg.Tokens = nil
+	}
+
+	// The gateway caches responses for [serve] cache itself
+	NoCache = true
+	domains := findDomains(args)
+	g, err := gateway.New(domains, cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid [%v] configuration: %v", ServeKey, err))
+	}
+	var names []string
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("Serving %v on http://%v, see /openapi.json\n", strings.Join(names, ", "), cfg.Listen)
+	if err := http.ListenAndServe(cfg.Listen, g); err != nil {
+		panic(fmt.Sprintf("unable to serve: %v", err))
+	}
+}
+
+// newServeCmd creates the serve command and its flags.
+func newServeCmd() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve [profiles to serve, blank for ALL]",
+		Short: "Serve a read-only JSON API over one or more domains",
+		Long: "Serve the servers, clusters, datasources and applications of each [profiles.<name>] domain as a read-only " +
+			"HTTP/JSON API at /domains/<name>/<resource>, described by /openapi.json.  Clients authenticate with the " +
+			"[[serve.tokens]] in the configuration file, whose [serve.roles.<name>] limit the domains and resources they " +
+			"can read, and responses are cached for [serve] cache so a busy portal doesn't load the AdminServers",
+		Run: Serve,
+	}
+	flags := serveCmd.Flags()
+	flags.StringVar(&serveOptions.listen, ListenFlag, "localhost:8080", "Address to listen on, overriding [serve] listen")
+	flags.BoolVar(&serveOptions.noAuth, NoAuthFlag, false, "Serve without checking bearer tokens")
+	flags.DurationVar(&serveOptions.staleWhileRevalidate, StaleWhileRevalidateFlag, 30*time.Second,
+		"How long past [serve] cache to answer with cached responses while refreshing them, overriding [serve] stale-while-revalidate")
+	return serveCmd
+}
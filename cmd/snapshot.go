@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/klauern/remy/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// SnapshotOutput is the format, text or json, of the snapshot diff report.
+var SnapshotOutput string
+
+// SnapshotSave captures the full state of the domain to the file given, or snapshot-<timestamp>.json.
+func SnapshotSave(cmd *cobra.Command, args []string) {
+	s, err := snapshot.Take(context.Background(), findClient())
+	if err != nil {
+		panic(fmt.Sprintf("Unable to take snapshot: %v", err))
+	}
+	path := snapshotName(s.Taken)
+	if len(args) == 1 {
+		path = args[0]
+	}
+	if err := s.Save(path); err != nil {
+		panic(fmt.Sprintf("Unable to save snapshot: %v", err))
+	}
+	fmt.Printf("Saved %v servers, %v clusters, %v datasources and %v applications to %v\n",
+		len(s.Servers), len(s.Clusters), len(s.DataSources), len(s.Applications), path)
+}
+
+// SnapshotDiff reports the changes between two snapshot files, or between a snapshot file and the live domain.
+func SnapshotDiff(cmd *cobra.Command, args []string) {
+	from, err := snapshot.Load(args[0])
+	if err != nil {
+		panic(fmt.Sprintf("Unable to load snapshot: %v", err))
+	}
+	var to *snapshot.Snapshot
+	if len(args) == 2 {
+		to, err = snapshot.Load(args[1])
+	} else {
+		to, err = snapshot.Take(context.Background(), findClient())
+	}
+	if err != nil {
+		panic(fmt.Sprintf("Unable to get snapshot to compare with: %v", err))
+	}
+	printReport(snapshot.Diff(from, to), SnapshotOutput)
+}
+
+// printReport prints a report as indented JSON, or with its String method.
+func printReport(report fmt.Stringer, output string) {
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			panic(fmt.Sprintf("Unable to encode report: %v", err))
+		}
+		fmt.Println(string(data))
+	case "text", "":
+		fmt.Print(report.String())
+	default:
+		panic(fmt.Sprintf("invalid --%v %q, expected text or json", OutputFlag, output))
+	}
+}
+
+// newSnapshotCmd creates the snapshot command and its save and diff subcommands.
+func newSnapshotCmd() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and compare the full state of the domain",
+		Long:  "Save every server, cluster, datasource and application in full format to a versioned JSON file, and report the differences between snapshots",
+	}
+	saveCmd := &cobra.Command{
+		Use:   "save [file, blank for snapshot-<timestamp>.json]",
+		Short: "Save the full state of the domain to a file",
+		Long:  "Save every server, cluster, datasource and application in full format to a versioned JSON file",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   SnapshotSave,
+	}
+	diffCmd := &cobra.Command{
+		Use:   "diff <before.json> [after.json, blank for the live domain]",
+		Short: "Report the differences between two snapshots",
+		Long: "Report added and removed servers, clusters, datasources and applications, server state and health changes, " +
+			"cluster membership, datasource instance changes, and applications whose targets moved",
+		Args: cobra.RangeArgs(1, 2),
+		Run:  SnapshotDiff,
+	}
+	diffCmd.Flags().StringVarP(&SnapshotOutput, OutputFlag, "o", "text", "Report format: text or json")
+	snapshotCmd.AddCommand(saveCmd, diffCmd)
+	return snapshotCmd
+}
+
+// snapshotName is the default file name for a snapshot taken at t.
+func snapshotName(t time.Time) string {
+	return fmt.Sprintf("snapshot-%v.json", t.Format("20060102-150405"))
+}
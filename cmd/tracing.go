@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracerProvider exports the spans created while running a command.  It is nil unless --trace-exporter is given.
+var tracerProvider *sdktrace.TracerProvider
+
+// setupTracing installs a global OpenTelemetry TracerProvider exporting to the --trace-exporter, if any.  Spans are
+// flushed by shutdownTracing once the command finishes.
+func setupTracing() {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch TraceExporter {
+	case "", "none":
+		return
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpoint(OTLPEndpoint), otlptracehttp.WithInsecure())
+	default:
+		panic(fmt.Sprintf("invalid --%v %q, expected none, stdout or otlp", TraceExporterFlag, TraceExporter))
+	}
+	if err != nil {
+		panic(fmt.Sprintf("unable to create %v trace exporter: %v", TraceExporter, err))
+	}
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// shutdownTracing flushes any spans not yet exported.
+func shutdownTracing() {
+	if tracerProvider == nil {
+		return
+	}
+	if err := tracerProvider.Shutdown(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to export traces: %v\n", err)
+	}
+}
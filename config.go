@@ -0,0 +1,362 @@
+package remy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// See https://docs.oracle.com/middleware/1221/wls/WLRUR/overview.htm
+
+const (
+	// ConfigPath is the REST resource path from the root / to the activated configuration of the domain, as of WLS
+	// 12.2.1.
+	ConfigPath string = "/management/weblogic/latest/domainConfig"
+
+	// EditPath is the REST resource path from the root / to the pending configuration of the domain, changed inside
+	// an edit session started with StartEdit.
+	EditPath string = "/management/weblogic/latest/edit"
+)
+
+// Identity is the path to a configuration bean, e.g., ["clusters", "cluster1"] or ["servers", "ms1"].  WebLogic uses
+// identities to refer from one bean to another, such as a server to its cluster or a data source to its targets.
+type Identity []string
+
+// ClusterIdentity is the Identity of the named cluster.
+func ClusterIdentity(name string) Identity {
+	return Identity{"clusters", name}
+}
+
+// ServerIdentity is the Identity of the named server.
+func ServerIdentity(name string) Identity {
+	return Identity{"servers", name}
+}
+
+// Name is the name of the bean the Identity refers to, or blank for a nil Identity.
+func (i Identity) Name() string {
+	if len(i) == 0 {
+		return ""
+	}
+	return i[len(i)-1]
+}
+
+// DomainConfig is the configuration of the resources in a domain that remy can change.
+type DomainConfig struct {
+	AdminServerName string
+	Clusters        []string
+	Servers         []ServerConfig
+	DataSources     []DataSourceConfig
+	Deployments     []DeploymentConfig
+}
+
+// ServerConfig is the configuration of a server.  A nil Cluster means the server is not in a cluster, and a zero
+// ListenPort leaves WebLogic's default.
+type ServerConfig struct {
+	Name       string   `json:"name"`
+	ListenPort int      `json:"listenPort,omitempty"`
+	Cluster    Identity `json:"cluster"`
+}
+
+// DataSourceConfig is the configuration of a JDBC system resource: its driver, JNDI names, pool sizing and the
+// servers and clusters it is targeted to.
+type DataSourceConfig struct {
+	Name            string
+	URL             string
+	DriverName      string
+	JNDINames       []string
+	InitialCapacity int
+	MinCapacity     int
+	MaxCapacity     int
+	Targets         []Identity
+}
+
+// DeploymentConfig is the configuration of an application deployment: the path to the application on the
+// AdminServer and the servers and clusters it is targeted to.
+type DeploymentConfig struct {
+	Name       string
+	SourcePath string
+	Targets    []Identity
+}
+
+// target is how WebLogic writes a reference to a server or cluster in a list of targets.
+type target struct {
+	Identity Identity `json:"identity"`
+}
+
+func toTargets(ids []Identity) []target {
+	targets := make([]target, len(ids))
+	for i := range ids {
+		targets[i] = target{Identity: ids[i]}
+	}
+	return targets
+}
+
+func fromTargets(targets []target) []Identity {
+	var ids []Identity
+	for _, t := range targets {
+		ids = append(ids, t.Identity)
+	}
+	return ids
+}
+
+// configSearch asks for only the fields of DomainConfig, in a single request.
+const configSearch = `{
+  "fields": ["adminServerName"], "links": [],
+  "children": {
+    "clusters": {"fields": ["name"], "links": []},
+    "servers": {"fields": ["name", "listenPort", "cluster"], "links": []},
+    "JDBCSystemResources": {
+      "fields": ["name", "targets"], "links": [],
+      "children": {"JDBCResource": {"fields": [], "links": [], "children": {
+        "JDBCDriverParams": {"fields": ["url", "driverName"], "links": []},
+        "JDBCDataSourceParams": {"fields": ["JNDINames"], "links": []},
+        "JDBCConnectionPoolParams": {"fields": ["initialCapacity", "minCapacity", "maxCapacity"], "links": []}
+      }}}
+    },
+    "appDeployments": {"fields": ["name", "sourcePath", "targets"], "links": []}
+  }
+}`
+
+// configSearchResult is the tree of beans returned by a search for configSearch.
+type configSearchResult struct {
+	AdminServerName string `json:"adminServerName"`
+	Clusters        struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	} `json:"clusters"`
+	Servers struct {
+		Items []ServerConfig `json:"items"`
+	} `json:"servers"`
+	JDBCSystemResources struct {
+		Items []struct {
+			Name         string       `json:"name"`
+			Targets      []target     `json:"targets"`
+			JDBCResource jdbcResource `json:"JDBCResource"`
+		} `json:"items"`
+	} `json:"JDBCSystemResources"`
+	AppDeployments struct {
+		Items []struct {
+			Name       string   `json:"name"`
+			SourcePath string   `json:"sourcePath"`
+			Targets    []target `json:"targets"`
+		} `json:"items"`
+	} `json:"appDeployments"`
+}
+
+type jdbcResource struct {
+	JDBCDriverParams struct {
+		URL        string `json:"url"`
+		DriverName string `json:"driverName"`
+	} `json:"JDBCDriverParams"`
+	JDBCDataSourceParams struct {
+		JNDINames []string `json:"JNDINames"`
+	} `json:"JDBCDataSourceParams"`
+	JDBCConnectionPoolParams struct {
+		InitialCapacity int `json:"initialCapacity"`
+		MinCapacity     int `json:"minCapacity"`
+		MaxCapacity     int `json:"maxCapacity"`
+	} `json:"JDBCConnectionPoolParams"`
+}
+
+// configURL builds the full URL to a bean under root, either ConfigPath or EditPath.  Each part is path-escaped.
+func (c *Client) configURL(root string, bean ...string) string {
+	parts := make([]string, len(bean))
+	for i := range bean {
+		parts[i] = url.PathEscape(bean[i])
+	}
+	return c.server.AdminURL + root + "/" + strings.Join(parts, "/")
+}
+
+// Configuration requests the activated configuration of the clusters, servers, data sources and deployments in the
+// domain.
+func (c *Client) Configuration(ctx context.Context) (_ *DomainConfig, err error) {
+	ctx, span := c.startSpan(ctx, "Configuration", "domainConfig", "")
+	defer func() { endSpan(span, err) }()
+
+	data, err := c.send(ctx, http.MethodPost, c.configURL(ConfigPath, "search"), []byte(configSearch))
+	if err != nil {
+		return nil, err
+	}
+	var result configSearchResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	cfg := &DomainConfig{AdminServerName: result.AdminServerName, Servers: result.Servers.Items}
+	for _, cluster := range result.Clusters.Items {
+		cfg.Clusters = append(cfg.Clusters, cluster.Name)
+	}
+	for _, ds := range result.JDBCSystemResources.Items {
+		r := ds.JDBCResource
+		cfg.DataSources = append(cfg.DataSources, DataSourceConfig{
+			Name:            ds.Name,
+			URL:             r.JDBCDriverParams.URL,
+			DriverName:      r.JDBCDriverParams.DriverName,
+			JNDINames:       r.JDBCDataSourceParams.JNDINames,
+			InitialCapacity: r.JDBCConnectionPoolParams.InitialCapacity,
+			MinCapacity:     r.JDBCConnectionPoolParams.MinCapacity,
+			MaxCapacity:     r.JDBCConnectionPoolParams.MaxCapacity,
+			Targets:         fromTargets(ds.Targets),
+		})
+	}
+	for _, d := range result.AppDeployments.Items {
+		cfg.Deployments = append(cfg.Deployments, DeploymentConfig{Name: d.Name, SourcePath: d.SourcePath, Targets: fromTargets(d.Targets)})
+	}
+	return cfg, nil
+}
+
+// StartEdit starts an edit session.  Every change made with the Create, Update and Delete methods is pending until
+// Activate is called, or discarded by CancelEdit.
+func (c *Client) StartEdit(ctx context.Context) error {
+	return c.changeManager(ctx, "startEdit")
+}
+
+// Activate activates the changes made in the edit session, ending it.
+func (c *Client) Activate(ctx context.Context) error {
+	return c.changeManager(ctx, "activate")
+}
+
+// CancelEdit discards the changes made in the edit session, ending it.
+func (c *Client) CancelEdit(ctx context.Context) error {
+	return c.changeManager(ctx, "cancelEdit")
+}
+
+func (c *Client) changeManager(ctx context.Context, operation string) (err error) {
+	ctx, span := c.startSpan(ctx, strings.ToUpper(operation[:1])+operation[1:], "changeManager", "")
+	defer func() { endSpan(span, err) }()
+
+	_, err = c.send(ctx, http.MethodPost, c.configURL(EditPath, "changeManager", operation), []byte("{}"))
+	return err
+}
+
+// edit POSTs v, as JSON, to a bean in the edit session, creating it when the bean is a collection such as servers.
+func (c *Client) edit(ctx context.Context, v interface{}, bean ...string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = c.send(ctx, http.MethodPost, c.configURL(EditPath, bean...), data)
+	return err
+}
+
+// CreateServer creates a server in the edit session.
+func (c *Client) CreateServer(ctx context.Context, s ServerConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "CreateServer", "servers", s.Name)
+	defer func() { endSpan(span, err) }()
+	return c.edit(ctx, s, "servers")
+}
+
+// UpdateServer changes the listen port and cluster of a server in the edit session.
+func (c *Client) UpdateServer(ctx context.Context, s ServerConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "UpdateServer", "servers", s.Name)
+	defer func() { endSpan(span, err) }()
+	return c.edit(ctx, s, "servers", s.Name)
+}
+
+// DeleteServer deletes a server in the edit session.
+func (c *Client) DeleteServer(ctx context.Context, name string) (err error) {
+	ctx, span := c.startSpan(ctx, "DeleteServer", "servers", name)
+	defer func() { endSpan(span, err) }()
+	_, err = c.send(ctx, http.MethodDelete, c.configURL(EditPath, "servers", name), nil)
+	return err
+}
+
+// CreateDataSource creates a JDBC system resource in the edit session.
+func (c *Client) CreateDataSource(ctx context.Context, ds DataSourceConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "CreateDataSource", "JDBCSystemResources", ds.Name)
+	defer func() { endSpan(span, err) }()
+
+	create := struct {
+		Name    string   `json:"name"`
+		Targets []target `json:"targets"`
+	}{ds.Name, toTargets(ds.Targets)}
+	if err := c.edit(ctx, create, "JDBCSystemResources"); err != nil {
+		return err
+	}
+	return c.editDataSourceParams(ctx, ds)
+}
+
+// UpdateDataSource changes the driver, JNDI names, pool sizing and targets of a JDBC system resource in the edit
+// session.
+func (c *Client) UpdateDataSource(ctx context.Context, ds DataSourceConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "UpdateDataSource", "JDBCSystemResources", ds.Name)
+	defer func() { endSpan(span, err) }()
+
+	update := struct {
+		Targets []target `json:"targets"`
+	}{toTargets(ds.Targets)}
+	if err := c.edit(ctx, update, "JDBCSystemResources", ds.Name); err != nil {
+		return err
+	}
+	return c.editDataSourceParams(ctx, ds)
+}
+
+// editDataSourceParams sets the driver, data source and connection pool parameters of the JDBC resource.
+func (c *Client) editDataSourceParams(ctx context.Context, ds DataSourceConfig) error {
+	var r jdbcResource
+	r.JDBCDriverParams.URL = ds.URL
+	r.JDBCDriverParams.DriverName = ds.DriverName
+	r.JDBCDataSourceParams.JNDINames = ds.JNDINames
+	r.JDBCConnectionPoolParams.InitialCapacity = ds.InitialCapacity
+	r.JDBCConnectionPoolParams.MinCapacity = ds.MinCapacity
+	r.JDBCConnectionPoolParams.MaxCapacity = ds.MaxCapacity
+
+	params := []struct {
+		bean string
+		v    interface{}
+	}{
+		{"JDBCDriverParams", r.JDBCDriverParams},
+		{"JDBCDataSourceParams", r.JDBCDataSourceParams},
+		{"JDBCConnectionPoolParams", r.JDBCConnectionPoolParams},
+	}
+	for _, p := range params {
+		if err := c.edit(ctx, p.v, "JDBCSystemResources", ds.Name, "JDBCResource", p.bean); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDataSource deletes a JDBC system resource in the edit session.
+func (c *Client) DeleteDataSource(ctx context.Context, name string) (err error) {
+	ctx, span := c.startSpan(ctx, "DeleteDataSource", "JDBCSystemResources", name)
+	defer func() { endSpan(span, err) }()
+	_, err = c.send(ctx, http.MethodDelete, c.configURL(EditPath, "JDBCSystemResources", name), nil)
+	return err
+}
+
+// CreateDeployment deploys the application at SourcePath, a path on the AdminServer, in the edit session.
+func (c *Client) CreateDeployment(ctx context.Context, d DeploymentConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "CreateDeployment", "appDeployments", d.Name)
+	defer func() { endSpan(span, err) }()
+
+	create := struct {
+		Name       string   `json:"name"`
+		SourcePath string   `json:"sourcePath"`
+		Targets    []target `json:"targets"`
+	}{d.Name, d.SourcePath, toTargets(d.Targets)}
+	return c.edit(ctx, create, "appDeployments")
+}
+
+// UpdateDeployment changes the targets of an application deployment in the edit session.  WebLogic can't change the
+// SourcePath of a deployment; delete and create it instead.
+func (c *Client) UpdateDeployment(ctx context.Context, d DeploymentConfig) (err error) {
+	ctx, span := c.startSpan(ctx, "UpdateDeployment", "appDeployments", d.Name)
+	defer func() { endSpan(span, err) }()
+
+	update := struct {
+		Targets []target `json:"targets"`
+	}{toTargets(d.Targets)}
+	return c.edit(ctx, update, "appDeployments", d.Name)
+}
+
+// DeleteDeployment undeploys an application in the edit session.
+func (c *Client) DeleteDeployment(ctx context.Context, name string) (err error) {
+	ctx, span := c.startSpan(ctx, "DeleteDeployment", "appDeployments", name)
+	defer func() { endSpan(span, err) }()
+	_, err = c.send(ctx, http.MethodDelete, c.configURL(EditPath, "appDeployments", name), nil)
+	return err
+}
@@ -0,0 +1,112 @@
+package remy
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const configSearchJSON = `{
+  "adminServerName": "AdminServer",
+  "clusters": {"items": [{"name": "cluster1"}]},
+  "servers": {"items": [
+    {"name": "AdminServer", "listenPort": 7001, "cluster": null},
+    {"name": "ms1", "listenPort": 7003, "cluster": ["clusters", "cluster1"]}
+  ]},
+  "JDBCSystemResources": {"items": [{
+    "name": "ds1",
+    "targets": [{"identity": ["clusters", "cluster1"]}],
+    "JDBCResource": {
+      "JDBCDriverParams": {"url": "jdbc:oracle:thin:@db:1521/ORCL", "driverName": "oracle.jdbc.OracleDriver"},
+      "JDBCDataSourceParams": {"JNDINames": ["jdbc/ds1"]},
+      "JDBCConnectionPoolParams": {"initialCapacity": 1, "minCapacity": 1, "maxCapacity": 15}
+    }
+  }]},
+  "appDeployments": {"items": [{"name": "app1", "sourcePath": "/u01/apps/app1.ear", "targets": [{"identity": ["servers", "ms1"]}]}]}
+}`
+
+func TestConfiguration(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, ConfigPath+"/search", r.URL.Path)
+		assert.Equal(t, "remy", r.Header.Get("X-Requested-By"))
+		w.Write([]byte(configSearchJSON))
+	}))
+	defer ts.Close()
+
+	cfg, err := NewClient(&AdminServer{AdminURL: ts.URL}).Configuration(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "AdminServer", cfg.AdminServerName)
+	assert.Equal(t, []string{"cluster1"}, cfg.Clusters)
+	assert.Equal(t, []ServerConfig{{Name: "AdminServer", ListenPort: 7001}, {Name: "ms1", ListenPort: 7003, Cluster: ClusterIdentity("cluster1")}}, cfg.Servers)
+	assert.Equal(t, DataSourceConfig{
+		Name:            "ds1",
+		URL:             "jdbc:oracle:thin:@db:1521/ORCL",
+		DriverName:      "oracle.jdbc.OracleDriver",
+		JNDINames:       []string{"jdbc/ds1"},
+		InitialCapacity: 1,
+		MinCapacity:     1,
+		MaxCapacity:     15,
+		Targets:         []Identity{ClusterIdentity("cluster1")},
+	}, cfg.DataSources[0])
+	assert.Equal(t, []DeploymentConfig{{Name: "app1", SourcePath: "/u01/apps/app1.ear", Targets: []Identity{ServerIdentity("ms1")}}}, cfg.Deployments)
+}
+
+func TestEditRequests(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   map[string]interface{}
+	}
+	var requests []request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "remy", r.Header.Get("X-Requested-By"))
+		req := request{Method: r.Method, Path: r.URL.Path}
+		if data, _ := ioutil.ReadAll(r.Body); len(data) > 0 {
+			assert.NoError(t, json.Unmarshal(data, &req.Body))
+		}
+		requests = append(requests, req)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(3))
+	ctx := context.Background()
+	assert.NoError(t, c.StartEdit(ctx))
+	assert.NoError(t, c.UpdateServer(ctx, ServerConfig{Name: "ms1"}))
+	assert.NoError(t, c.CreateDataSource(ctx, DataSourceConfig{Name: "ds2", URL: "jdbc:x", DriverName: "d", MaxCapacity: 30, Targets: []Identity{ClusterIdentity("cluster1")}}))
+	assert.NoError(t, c.DeleteDeployment(ctx, "my app"))
+	assert.NoError(t, c.Activate(ctx))
+
+	assert.Equal(t, []request{
+		{Method: "POST", Path: EditPath + "/changeManager/startEdit", Body: map[string]interface{}{}},
+		{Method: "POST", Path: EditPath + "/servers/ms1", Body: map[string]interface{}{"name": "ms1", "cluster": nil}},
+		{Method: "POST", Path: EditPath + "/JDBCSystemResources", Body: map[string]interface{}{
+			"name": "ds2", "targets": []interface{}{map[string]interface{}{"identity": []interface{}{"clusters", "cluster1"}}},
+		}},
+		{Method: "POST", Path: EditPath + "/JDBCSystemResources/ds2/JDBCResource/JDBCDriverParams", Body: map[string]interface{}{"url": "jdbc:x", "driverName": "d"}},
+		{Method: "POST", Path: EditPath + "/JDBCSystemResources/ds2/JDBCResource/JDBCDataSourceParams", Body: map[string]interface{}{"JNDINames": nil}},
+		{Method: "POST", Path: EditPath + "/JDBCSystemResources/ds2/JDBCResource/JDBCConnectionPoolParams", Body: map[string]interface{}{
+			"initialCapacity": 0.0, "minCapacity": 0.0, "maxCapacity": 30.0,
+		}},
+		{Method: "DELETE", Path: EditPath + "/appDeployments/my app"},
+		{Method: "POST", Path: EditPath + "/changeManager/activate", Body: map[string]interface{}{}},
+	}, requests)
+}
+
+func TestChangesAreNotRetried(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	err := NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(3)).DeleteServer(context.Background(), "ms1")
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
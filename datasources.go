@@ -2,6 +2,7 @@ package remy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -106,12 +107,26 @@ func (d *DataSource) GoString() string {
 }
 
 // DataSources returns all generic and GridLink JDBC data sources configured in the domain, and provides run-time information for each data source.
-func (a *AdminServer) DataSources(isFullFormat bool) ([]DataSource, error) {
-	url := fmt.Sprintf("%v%v/datasources", a.AdminURL, MonitorPath)
-	if isFullFormat {
+// opts determine the format, fields and page of the list.
+func (c *Client) DataSources(ctx context.Context, opts QueryOptions) (list []DataSource, err error) {
+	ctx, span := c.startSpan(ctx, "DataSources", "datasources", "")
+	defer func() { endSpan(span, err) }()
+	if opts, err = opts.resolve(DataSource{}); err != nil {
+		return nil, err
+	}
+	defer func() { opts.apply(&list) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeDataSources(ctx, opts)
+	}
+	url := c.resourceURL("datasources")
+	if opts.Full {
 		url = url + "?format=full"
 	}
-	w, err := requestAndUnmarshal(url, a)
+	w, err := c.requestAndUnmarshal(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -123,9 +138,17 @@ func (a *AdminServer) DataSources(isFullFormat bool) ([]DataSource, error) {
 }
 
 // DataSource returns run-time information for the specified data source, including Oracle RAC statistics for GridLink data sources.
-func (a *AdminServer) DataSource(dataSourceName string) (*DataSource, error) {
-	url := fmt.Sprintf("%v%v/datasources/%v", a.AdminURL, MonitorPath, dataSourceName)
-	w, err := requestAndUnmarshal(url, a)
+func (c *Client) DataSource(ctx context.Context, dataSourceName string) (_ *DataSource, err error) {
+	ctx, span := c.startSpan(ctx, "DataSource", "datasources", dataSourceName)
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeDataSource(ctx, dataSourceName)
+	}
+	w, err := c.requestAndUnmarshal(ctx, c.resourceURL("datasources", dataSourceName))
 	if err != nil {
 		return nil, err
 	}
@@ -135,3 +158,16 @@ func (a *AdminServer) DataSource(dataSourceName string) (*DataSource, error) {
 	}
 	return &dataSource, nil
 }
+
+// DataSources returns all JDBC data sources configured in the domain using a default Client.  See Client.DataSources.
+//
+// Deprecated: DataSources only chooses between the short and full format.  Use Client.DataSources, whose QueryOptions
+// also select fields and page through the data sources.
+func (a *AdminServer) DataSources(isFullFormat bool) ([]DataSource, error) {
+	return NewClient(a).DataSources(context.Background(), QueryOptions{Full: isFullFormat})
+}
+
+// DataSource returns run-time information for the specified data source using a default Client.  See Client.DataSource.
+func (a *AdminServer) DataSource(dataSourceName string) (*DataSource, error) {
+	return NewClient(a).DataSource(context.Background(), dataSourceName)
+}
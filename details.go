@@ -0,0 +1,219 @@
+package remy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultDetailWorkers is how many details DetailOptions fetch at once unless told otherwise.
+const DefaultDetailWorkers = 4
+
+// DetailOptions are how ServerDetails, ClusterDetails, DataSourceDetails and ApplicationDetails fetch the detail of
+// each resource they list.
+type DetailOptions struct {
+	// Workers is the most details fetched at once.  Zero is DefaultDetailWorkers.
+	Workers int
+	// Progress, when set, is called after each detail is fetched, or fails, with how many are done of the total.  It is
+	// called from one goroutine at a time.
+	Progress func(done, total int)
+}
+
+// DetailError is the failure to fetch the detail of one resource of a list.
+type DetailError struct {
+	// Index is where the resource is in the list.
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e *DetailError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Name, e.Err)
+}
+
+// DetailErrors are the resources of a list whose detail couldn't be fetched, in the order of the list.  They are left
+// in the short format.
+type DetailErrors []*DetailError
+
+func (e DetailErrors) Error() string {
+	var msgs []string
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("unable to fetch the detail of %d resources: %v", len(e), strings.Join(msgs, "; "))
+}
+
+// fetchDetails calls fetch for the index of each of names, up to opts.Workers at once, until ctx is done.  It returns
+// DetailErrors for the fetches that failed, or nil.
+func fetchDetails(ctx context.Context, names []string, opts DetailOptions, fetch func(ctx context.Context, i int) error) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultDetailWorkers
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	indexes := make(chan int)
+	var (
+		mu   sync.Mutex
+		done int
+		errs = make([]*DetailError, len(names))
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := ctx.Err()
+				if err == nil {
+					err = fetch(ctx, i)
+				}
+				mu.Lock()
+				if err != nil {
+					errs[i] = &DetailError{Index: i, Name: names[i], Err: err}
+				}
+				done++
+				if opts.Progress != nil {
+					opts.Progress(done, len(names))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var failed DetailErrors
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// ServerDetails lists the servers in the short format, then fetches each one's full detail with Server, up to
+// opts.Workers at once, which is much faster than one at a time over a slow link.  The servers stay in the order they
+// were listed.  When some details can't be fetched, the rest are still returned, with DetailErrors.  The weblogic REST
+// tree reads every server's detail in the one search, so from it the servers are simply listed in the full format.
+func (c *Client) ServerDetails(ctx context.Context, opts DetailOptions) (_ []Server, err error) {
+	ctx, span := c.startSpan(ctx, "ServerDetails", "servers", "")
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.Servers(ctx, QueryOptions{Full: true})
+	}
+	servers, err := c.Servers(ctx, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(servers))
+	for i := range servers {
+		names[i] = servers[i].Name
+	}
+	return servers, fetchDetails(ctx, names, opts, func(ctx context.Context, i int) error {
+		server, err := c.Server(ctx, names[i])
+		if err == nil {
+			servers[i] = *server
+		}
+		return err
+	})
+}
+
+// ClusterDetails lists the clusters, then fetches each one's full detail with Cluster.  See ServerDetails.
+func (c *Client) ClusterDetails(ctx context.Context, opts DetailOptions) (_ []Cluster, err error) {
+	ctx, span := c.startSpan(ctx, "ClusterDetails", "clusters", "")
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.Clusters(ctx, QueryOptions{Full: true})
+	}
+	clusters, err := c.Clusters(ctx, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(clusters))
+	for i := range clusters {
+		names[i] = clusters[i].Name
+	}
+	return clusters, fetchDetails(ctx, names, opts, func(ctx context.Context, i int) error {
+		cluster, err := c.Cluster(ctx, names[i])
+		if err == nil {
+			clusters[i] = *cluster
+		}
+		return err
+	})
+}
+
+// DataSourceDetails lists the data sources, then fetches each one's full detail with DataSource, including Oracle RAC
+// statistics for GridLink data sources.  See ServerDetails.
+func (c *Client) DataSourceDetails(ctx context.Context, opts DetailOptions) (_ []DataSource, err error) {
+	ctx, span := c.startSpan(ctx, "DataSourceDetails", "datasources", "")
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.DataSources(ctx, QueryOptions{Full: true})
+	}
+	dataSources, err := c.DataSources(ctx, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(dataSources))
+	for i := range dataSources {
+		names[i] = dataSources[i].Name
+	}
+	return dataSources, fetchDetails(ctx, names, opts, func(ctx context.Context, i int) error {
+		dataSource, err := c.DataSource(ctx, names[i])
+		if err == nil {
+			dataSources[i] = *dataSource
+		}
+		return err
+	})
+}
+
+// ApplicationDetails lists the applications, then fetches each one's full detail with Application, including the
+// data sources it uses.  See ServerDetails.
+func (c *Client) ApplicationDetails(ctx context.Context, opts DetailOptions) (_ []Application, err error) {
+	ctx, span := c.startSpan(ctx, "ApplicationDetails", "applications", "")
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.Applications(ctx, QueryOptions{Full: true})
+	}
+	applications, err := c.Applications(ctx, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(applications))
+	for i := range applications {
+		names[i] = applications[i].Name
+	}
+	return applications, fetchDetails(ctx, names, opts, func(ctx context.Context, i int) error {
+		application, err := c.Application(ctx, names[i])
+		if err == nil {
+			applications[i] = *application
+		}
+		return err
+	})
+}
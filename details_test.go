@@ -0,0 +1,87 @@
+package remy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerDetails(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+
+	var progress []int
+	servers, err := client.ServerDetails(context.Background(), DetailOptions{Progress: func(done, total int) {
+		assert.Equal(t, 2, total)
+		progress = append(progress, done)
+	}})
+	assert.Equal(t, []int{1, 2}, progress)
+	assert.Len(t, servers, 2)
+	assert.Equal(t, "adminserver", servers[0].Name)
+	assert.Equal(t, "machine-0", servers[0].CurrentMachine)
+	assert.Equal(t, Server{Name: "ms1", State: "SHUTDOWN"}, servers[1])
+
+	errs, ok := err.(DetailErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 1, errs[0].Index)
+	assert.Equal(t, "ms1", errs[0].Name)
+	assert.Contains(t, err.Error(), "unable to fetch the detail of 1 resources")
+}
+
+func TestDetailsWorkers(t *testing.T) {
+	const count = 12
+	var inFlight, most int32
+	r := mux.NewRouter()
+	r.HandleFunc(MonitorPath+"/applications", func(w http.ResponseWriter, r *http.Request) {
+		items := ""
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"name": "app%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"body": {"items": [%v]}}`, items)
+	})
+	r.HandleFunc(MonitorPath+"/applications/{app}", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprintf(w, `{"body": {"item": {"name": %q, "state": "STATE_ACTIVE"}}}`, mux.Vars(r)["app"])
+	})
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	apps, err := NewClient(&AdminServer{AdminURL: ts.URL}).ApplicationDetails(context.Background(), DetailOptions{Workers: 3})
+	assert.NoError(t, err)
+	assert.Len(t, apps, count)
+	for i, app := range apps {
+		assert.Equal(t, fmt.Sprintf("app%d", i), app.Name)
+		assert.Equal(t, "STATE_ACTIVE", app.State)
+	}
+	assert.True(t, most > 1 && most <= 3, "%d details fetched at once", most)
+}
+
+func TestDetailsFromWebLogicTree(t *testing.T) {
+	client, searches, cleanup := newRuntimeClient(t)
+	defer cleanup()
+
+	servers, err := client.ServerDetails(context.Background(), DetailOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "machine-1", servers[1].CurrentMachine)
+	assert.Len(t, *searches, 1)
+}
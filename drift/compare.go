@@ -0,0 +1,173 @@
+package drift
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kind describes how a domain differs from the State it is compared to.
+type Kind string
+
+// The kinds of Difference reported by Compare.
+const (
+	Missing    Kind = "missing"
+	Unexpected Kind = "unexpected"
+	Mismatch   Kind = "mismatch"
+)
+
+// Resources compared by Compare.
+const (
+	ServerResource      = "server"
+	ClusterResource     = "cluster"
+	DataSourceResource  = "datasource"
+	ApplicationResource = "application"
+)
+
+// Difference is a single way a domain differs from the State it was compared to.  Want and Got are only set for a
+// Mismatch, with lists of cluster members and application targets comma-separated.
+type Difference struct {
+	Domain   string `json:"domain"`
+	Kind     Kind   `json:"kind"`
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	Field    string `json:"field,omitempty"`
+	Want     string `json:"want,omitempty"`
+	Got      string `json:"got,omitempty"`
+}
+
+// Report is every Difference found comparing domains to a Baseline, either the first domain or a desired-state file.
+type Report struct {
+	Baseline    string       `json:"baseline"`
+	Domains     []string     `json:"domains"`
+	Differences []Difference `json:"differences"`
+}
+
+// Add compares the domain's State got to want, adding any differences to the Report.
+func (r *Report) Add(domain string, want, got *State) {
+	r.Domains = append(r.Domains, domain)
+	r.Differences = append(r.Differences, Compare(domain, want, got)...)
+}
+
+// Empty reports whether every domain matched the Baseline.
+func (r *Report) Empty() bool {
+	return len(r.Differences) == 0
+}
+
+// String formats the Report for the console, one line per Difference.
+func (r *Report) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Drift of %v from %v\n", strings.Join(r.Domains, ", "), r.Baseline))
+	if r.Empty() {
+		buffer.WriteString("No drift\n")
+	}
+	for _, d := range r.Differences {
+		switch d.Kind {
+		case Mismatch:
+			buffer.WriteString(fmt.Sprintf("%v: %v %v %v: want %q, got %q\n", d.Domain, d.Resource, d.Name, d.Field, d.Want, d.Got))
+		default:
+			buffer.WriteString(fmt.Sprintf("%v: %v %v %v\n", d.Domain, d.Kind, d.Resource, d.Name))
+		}
+	}
+	return buffer.String()
+}
+
+// Compare reports how the domain's State got differs from want.  Sections and server attributes left out of want are
+// not compared.
+func Compare(domain string, want, got *State) []Difference {
+	var diffs []Difference
+	add := func(kind Kind, resource, name, field, w, g string) {
+		diffs = append(diffs, Difference{Domain: domain, Kind: kind, Resource: resource, Name: name, Field: field, Want: w, Got: g})
+	}
+
+	if want.Servers != nil {
+		for _, name := range names(want.Servers, got.Servers) {
+			w, inWant := want.Servers[name]
+			g, inGot := got.Servers[name]
+			switch {
+			case !inGot:
+				add(Missing, ServerResource, name, "", "", "")
+			case !inWant:
+				add(Unexpected, ServerResource, name, "", "", "")
+			default:
+				if w.WebLogicVersion != "" && w.WebLogicVersion != g.WebLogicVersion {
+					add(Mismatch, ServerResource, name, "WebLogicVersion", w.WebLogicVersion, g.WebLogicVersion)
+				}
+				if w.JavaVersion != "" && w.JavaVersion != g.JavaVersion {
+					add(Mismatch, ServerResource, name, "JavaVersion", w.JavaVersion, g.JavaVersion)
+				}
+			}
+		}
+	}
+
+	if want.Clusters != nil {
+		for _, name := range names(want.Clusters, got.Clusters) {
+			w, inWant := want.Clusters[name]
+			g, inGot := got.Clusters[name]
+			switch {
+			case !inGot:
+				add(Missing, ClusterResource, name, "", "", "")
+			case !inWant:
+				add(Unexpected, ClusterResource, name, "", "", "")
+			case list(w) != list(g):
+				add(Mismatch, ClusterResource, name, "members", list(w), list(g))
+			}
+		}
+	}
+
+	if want.DataSources != nil {
+		for _, name := range names(want.DataSources, got.DataSources) {
+			w, inWant := want.DataSources[name]
+			g, inGot := got.DataSources[name]
+			switch {
+			case !inGot:
+				add(Missing, DataSourceResource, name, "", "", "")
+			case !inWant:
+				add(Unexpected, DataSourceResource, name, "", "", "")
+			case w != "" && w != g:
+				add(Mismatch, DataSourceResource, name, "type", w, g)
+			}
+		}
+	}
+
+	if want.Applications != nil {
+		for _, name := range names(want.Applications, got.Applications) {
+			w, inWant := want.Applications[name]
+			g, inGot := got.Applications[name]
+			switch {
+			case !inGot:
+				add(Missing, ApplicationResource, name, "", "", "")
+			case !inWant:
+				add(Unexpected, ApplicationResource, name, "", "", "")
+			case list(w) != list(g):
+				add(Mismatch, ApplicationResource, name, "targets", list(w), list(g))
+			}
+		}
+	}
+	return diffs
+}
+
+// names returns the sorted keys of both maps, which must be maps with string keys.
+func names(a, b interface{}) []string {
+	set := make(map[string]bool)
+	for _, m := range []interface{}{a, b} {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			set[k.String()] = true
+		}
+	}
+	sorted := make([]string, 0, len(set))
+	for k := range set {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// list formats a set of names in sorted order, so the order members or targets were listed in doesn't matter.
+func list(items []string) string {
+	sorted := append([]string(nil), items...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,70 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func baseline() *State {
+	return &State{
+		Servers: map[string]ServerState{
+			"AdminServer": {WebLogicVersion: "12.2.1.3", JavaVersion: "1.8.0_144"},
+			"ms1":         {WebLogicVersion: "12.2.1.3", JavaVersion: "1.8.0_144"},
+		},
+		Clusters:     map[string][]string{"cluster1": {"ms1"}},
+		DataSources:  map[string]string{"ds1": "Generic"},
+		Applications: map[string][]string{"app1": {"cluster1"}},
+	}
+}
+
+func TestCompareIdentical(t *testing.T) {
+	assert.Empty(t, Compare("prod2", baseline(), baseline()))
+}
+
+func TestCompare(t *testing.T) {
+	got := baseline()
+	got.Servers["ms1"] = ServerState{WebLogicVersion: "12.2.1.3", JavaVersion: "1.8.0_121"}
+	got.Servers["ms2"] = ServerState{}
+	got.Clusters["cluster1"] = []string{"ms2", "ms1"}
+	got.DataSources["ds1"] = "Multi"
+	delete(got.Applications, "app1")
+
+	diffs := Compare("prod2", baseline(), got)
+	assert.Equal(t, []Difference{
+		{Domain: "prod2", Kind: Mismatch, Resource: ServerResource, Name: "ms1", Field: "JavaVersion", Want: "1.8.0_144", Got: "1.8.0_121"},
+		{Domain: "prod2", Kind: Unexpected, Resource: ServerResource, Name: "ms2"},
+		{Domain: "prod2", Kind: Mismatch, Resource: ClusterResource, Name: "cluster1", Field: "members", Want: "ms1", Got: "ms1,ms2"},
+		{Domain: "prod2", Kind: Mismatch, Resource: DataSourceResource, Name: "ds1", Field: "type", Want: "Generic", Got: "Multi"},
+		{Domain: "prod2", Kind: Missing, Resource: ApplicationResource, Name: "app1"},
+	}, diffs)
+}
+
+func TestComparePartialState(t *testing.T) {
+	want := &State{
+		Servers:      map[string]ServerState{"AdminServer": {}, "ms1": {JavaVersion: "1.8.0_144"}},
+		Applications: map[string][]string{"app1": {"cluster1"}},
+	}
+	got := baseline()
+	got.Clusters = map[string][]string{}
+	got.DataSources = map[string]string{}
+	assert.Empty(t, Compare("prod1", want, got), "sections and attributes left out should not be compared")
+}
+
+func TestReport(t *testing.T) {
+	r := &Report{Baseline: "desired.yaml"}
+	r.Add("prod1", baseline(), baseline())
+	assert.True(t, r.Empty())
+	assert.Contains(t, r.String(), "No drift")
+
+	got := baseline()
+	got.Applications["app1"] = []string{"ms1"}
+	delete(got.Servers, "ms1")
+	r.Add("prod2", baseline(), got)
+	assert.False(t, r.Empty())
+	assert.Equal(t, []string{"prod1", "prod2"}, r.Domains)
+	out := r.String()
+	assert.Contains(t, out, "Drift of prod1, prod2 from desired.yaml")
+	assert.Contains(t, out, "prod2: missing server ms1")
+	assert.Contains(t, out, `prod2: application app1 targets: want "cluster1", got "ms1"`)
+}
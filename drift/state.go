@@ -0,0 +1,99 @@
+// Package drift compares the resources of domains that should be identical, or a domain against a declarative desired
+// state, and reports where they differ: which applications are deployed where, data source names and types, cluster
+// membership, and the WebLogic and Java versions of each server.
+package drift
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/klauern/remy"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// State is the set of resources and key attributes of a domain checked for drift.  Loaded from a desired-state file, a
+// nil section is not checked, so a file listing only applications ignores servers, clusters and data sources.
+type State struct {
+	// Servers are keyed by name.
+	Servers map[string]ServerState `yaml:"servers,omitempty" json:"servers,omitempty"`
+	// Clusters are keyed by name, with their member servers.
+	Clusters map[string][]string `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+	// DataSources are keyed by name, with their type.
+	DataSources map[string]string `yaml:"datasources,omitempty" json:"datasources,omitempty"`
+	// Applications are keyed by name, with the targets they are deployed to.
+	Applications map[string][]string `yaml:"applications,omitempty" json:"applications,omitempty"`
+}
+
+// ServerState is the key attributes of a server.  Blank attributes in a desired-state file are not checked.
+type ServerState struct {
+	WebLogicVersion string `yaml:"weblogicVersion,omitempty" json:"weblogicVersion,omitempty"`
+	JavaVersion     string `yaml:"javaVersion,omitempty" json:"javaVersion,omitempty"`
+}
+
+// Capture requests the full format servers, clusters, data sources and applications from the Client's AdminServer.
+func Capture(ctx context.Context, client *remy.Client) (*State, error) {
+	s := &State{
+		Servers:      make(map[string]ServerState),
+		Clusters:     make(map[string][]string),
+		DataSources:  make(map[string]string),
+		Applications: make(map[string][]string),
+	}
+
+	servers, err := client.Servers(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, srv := range servers {
+		s.Servers[srv.Name] = ServerState{WebLogicVersion: srv.WebLogicVersion, JavaVersion: srv.JavaVersion}
+	}
+
+	clusters, err := client.Clusters(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range clusters {
+		members := []string{}
+		for _, m := range c.Servers {
+			members = append(members, m.Name)
+		}
+		sort.Strings(members)
+		s.Clusters[c.Name] = members
+	}
+
+	dataSources, err := client.DataSources(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range dataSources {
+		s.DataSources[ds.Name] = ds.Type
+	}
+
+	applications, err := client.Applications(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range applications {
+		targets := []string{}
+		for _, t := range app.TargetStates {
+			targets = append(targets, t.Target)
+		}
+		sort.Strings(targets)
+		s.Applications[app.Name] = targets
+	}
+	return s, nil
+}
+
+// Load reads a desired State from a YAML file.
+func Load(path string) (*State, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s State
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("unable to read desired state %v: %v", path, err)
+	}
+	return &s, nil
+}
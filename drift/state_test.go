@@ -0,0 +1,55 @@
+package drift
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapture(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+
+	s, err := Capture(context.Background(), remy.NewClient(fake.AdminServer(ts.URL)))
+	assert.NoError(t, err)
+	assert.Len(t, s.Servers, 3)
+	assert.Equal(t, "1.8.0_144", s.Servers["ms1"].JavaVersion)
+	assert.Equal(t, []string{"ms1", "ms2"}, s.Clusters["cluster1"])
+	assert.Equal(t, "Generic", s.DataSources["ds1"])
+	assert.Equal(t, []string{"cluster1"}, s.Applications["app1"])
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remy-drift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "desired.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+servers:
+  ms1:
+    javaVersion: 1.8.0_144
+clusters:
+  cluster1: [ms1, ms2]
+applications:
+  app1: [cluster1]
+`), 0644))
+	s, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ServerState{JavaVersion: "1.8.0_144"}, s.Servers["ms1"])
+	assert.Equal(t, []string{"ms1", "ms2"}, s.Clusters["cluster1"])
+	assert.Nil(t, s.DataSources, "sections left out should not be checked")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("servrs:\n  ms1: {}\n"), 0644))
+	_, err = Load(path)
+	assert.Error(t, err, "unknown sections should be rejected")
+}
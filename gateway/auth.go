@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Role grants read access to the domains matching any of Domains, which are globs such as "prod*", and to the
+// Resources named, or every resource with "*".
+type Role struct {
+	Domains   []string `mapstructure:"domains"`
+	Resources []string `mapstructure:"resources"`
+}
+
+// Token is a bearer token a client authenticates with, granted the union of its Roles.
+type Token struct {
+	Name  string   `mapstructure:"name"`
+	Token string   `mapstructure:"token"`
+	Roles []string `mapstructure:"roles"`
+}
+
+// grants are the domains and resources a request may read.
+type grants struct {
+	name      string
+	domains   []string
+	resources map[string]bool
+}
+
+// domain reports whether the domain may be read.
+func (g *grants) domain(name string) bool {
+	for _, pattern := range g.domains {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// resource reports whether the resource may be read.
+func (g *grants) resource(name string) bool {
+	return g.resources["*"] || g.resources[name]
+}
+
+// authorizer finds the grants of a request's bearer token.  Tokens are looked up by their SHA-256 digest, so
+// comparing them doesn't leak their contents through timing.
+type authorizer struct {
+	tokens map[[sha256.Size]byte]*grants
+}
+
+// newAuthorizer checks every token's roles exist and are valid.  Without tokens, every request is granted everything.
+func newAuthorizer(roles map[string]Role, tokens []Token) (*authorizer, error) {
+	for name, role := range roles {
+		for _, pattern := range role.Domains {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("role %v: invalid domain pattern %q: %v", name, pattern, err)
+			}
+		}
+		for _, resource := range role.Resources {
+			if resource != "*" && !isResource(resource) {
+				return nil, fmt.Errorf("role %v: unknown resource %q, expected * or one of %v", name, resource, strings.Join(Resources, ", "))
+			}
+		}
+	}
+
+	a := &authorizer{}
+	if len(tokens) == 0 {
+		return a, nil
+	}
+	a.tokens = make(map[[sha256.Size]byte]*grants)
+	for i, t := range tokens {
+		if t.Name == "" {
+			t.Name = fmt.Sprintf("token%d", i+1)
+		}
+		if t.Token == "" {
+			return nil, fmt.Errorf("token %v is blank", t.Name)
+		}
+		sum := sha256.Sum256([]byte(t.Token))
+		if _, ok := a.tokens[sum]; ok {
+			return nil, fmt.Errorf("token %v is used more than once", t.Name)
+		}
+		g := &grants{name: t.Name, resources: make(map[string]bool)}
+		for _, name := range t.Roles {
+			role, ok := roles[name]
+			if !ok {
+				return nil, fmt.Errorf("token %v: no role named %v", t.Name, name)
+			}
+			g.domains = append(g.domains, role.Domains...)
+			for _, resource := range role.Resources {
+				g.resources[resource] = true
+			}
+		}
+		a.tokens[sum] = g
+	}
+	return a, nil
+}
+
+// authenticate returns the grants of the request's bearer token, and whether it is valid.
+func (a *authorizer) authenticate(r *http.Request) (*grants, bool) {
+	return a.bearer(r.Header.Get("Authorization"))
+}
+
+// bearer returns the grants of the token in an Authorization header, and whether it is valid.
+func (a *authorizer) bearer(header string) (*grants, bool) {
+	if a.tokens == nil {
+		return &grants{name: "anonymous", domains: []string{"*"}, resources: map[string]bool{"*": true}}, true
+	}
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, false
+	}
+	g, ok := a.tokens[sha256.Sum256([]byte(strings.TrimPrefix(header, "Bearer ")))]
+	return g, ok
+}
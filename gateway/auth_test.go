@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	roles := map[string]Role{"read": {Domains: []string{"prod*"}, Resources: []string{"servers", "clusters"}}}
+
+	_, err := newAuthorizer(roles, []Token{{Name: "a", Token: "x", Roles: []string{"admin"}}})
+	assert.EqualError(t, err, "token a: no role named admin")
+	_, err = newAuthorizer(roles, []Token{{Token: "x"}, {Token: "x"}})
+	assert.EqualError(t, err, "token token2 is used more than once")
+	_, err = newAuthorizer(roles, []Token{{Name: "a"}})
+	assert.EqualError(t, err, "token a is blank")
+	_, err = newAuthorizer(map[string]Role{"bad": {Resources: []string{"widgets"}}}, nil)
+	assert.Error(t, err)
+	_, err = newAuthorizer(map[string]Role{"bad": {Domains: []string{"["}}}, nil)
+	assert.Error(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	roles := map[string]Role{"read": {Domains: []string{"prod*"}, Resources: []string{"servers", "clusters"}}}
+	a, err := newAuthorizer(roles, []Token{{Name: "portal", Token: "t0ken", Roles: []string{"read"}}})
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest("GET", "/domains", nil)
+	_, ok := a.authenticate(r)
+	assert.False(t, ok)
+	r.Header.Set("Authorization", "Basic dTpw")
+	_, ok = a.authenticate(r)
+	assert.False(t, ok)
+
+	r.Header.Set("Authorization", "Bearer t0ken")
+	g, ok := a.authenticate(r)
+	assert.True(t, ok)
+	assert.Equal(t, "portal", g.name)
+	assert.True(t, g.domain("prod-east"))
+	assert.False(t, g.domain("test"))
+	assert.True(t, g.resource("clusters"))
+	assert.False(t, g.resource("datasources"))
+
+	open, err := newAuthorizer(nil, nil)
+	assert.NoError(t, err)
+	g, ok = open.authenticate(httptest.NewRequest("GET", "/domains", nil))
+	assert.True(t, ok)
+	assert.True(t, g.domain("anything"))
+	assert.True(t, g.resource("applications"))
+}
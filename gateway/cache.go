@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// cache keeps encoded responses for a fixed time, then answers with them for up to stale longer while they are
+// refreshed in the background.  Expired entries are dropped when they are next looked up, or when the cache is swept on
+// put.
+type cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	stale      time.Duration
+	entries    map[string]cacheEntry
+	refreshing map[string]bool
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// newCache creates a cache keeping entries fresh for ttl, and stale for another stale.  A ttl of zero caches nothing.
+func newCache(ttl, stale time.Duration) *cache {
+	return &cache{ttl: ttl, stale: stale, entries: make(map[string]cacheEntry), refreshing: make(map[string]bool), now: time.Now}
+}
+
+// get returns the entry cached under key, and whether it is still fresh.
+func (c *cache) get(key string) (data []byte, fresh, ok bool) {
+	if c.ttl <= 0 {
+		return nil, false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	now := c.now()
+	if !ok || !now.Before(e.expires.Add(c.stale)) {
+		delete(c.entries, key)
+		return nil, false, false
+	}
+	return e.data, now.Before(e.expires), true
+}
+
+func (c *cache) put(key string, data []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expires.Add(c.stale)) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{data: data, expires: now.Add(c.ttl)}
+}
+
+// refresh replaces the entry under key with what fetch returns, in the background, unless it is already being
+// refreshed.  When fetch fails, the stale entry is kept until it expires.
+func (c *cache) refresh(key string, fetch func() ([]byte, error)) {
+	c.mu.Lock()
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		data, err := fetch()
+		if err == nil {
+			c.put(key, data)
+		}
+		c.mu.Lock()
+		delete(c.refreshing, key)
+		c.mu.Unlock()
+	}()
+}
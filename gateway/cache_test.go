@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute, 0)
+	c.now = func() time.Time { return now }
+
+	_, _, ok := c.get("prod/servers")
+	assert.False(t, ok)
+	c.put("prod/servers", []byte("[]"))
+	data, fresh, ok := c.get("prod/servers")
+	assert.True(t, ok && fresh)
+	assert.Equal(t, "[]", string(data))
+
+	now = now.Add(time.Minute)
+	_, _, ok = c.get("prod/servers")
+	assert.False(t, ok)
+	assert.Empty(t, c.entries)
+}
+
+func TestCacheStale(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.put("prod/servers", []byte("[]"))
+	now = now.Add(90 * time.Second)
+	data, fresh, ok := c.get("prod/servers")
+	assert.True(t, ok)
+	assert.False(t, fresh)
+	assert.Equal(t, "[]", string(data))
+
+	now = now.Add(30 * time.Second)
+	_, _, ok = c.get("prod/servers")
+	assert.False(t, ok, "stale entries expire too")
+}
+
+func TestCacheDisabled(t *testing.T) {
+	c := newCache(0, time.Minute)
+	c.put("prod/servers", []byte("[]"))
+	_, _, ok := c.get("prod/servers")
+	assert.False(t, ok)
+}
@@ -0,0 +1,241 @@
+// Package gateway serves a read-only HTTP/JSON API over one or more WebLogic domains, so clients can see domain status
+// without holding WebLogic credentials.  Only the gateway talks to the AdminServers; its clients authenticate with
+// bearer tokens whose roles limit the domains and resources they can read, and responses are cached briefly so a busy
+// portal doesn't load the AdminServers.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/klauern/remy"
+)
+
+// Resources served for every domain.
+const (
+	ServersResource      = "servers"
+	ClustersResource     = "clusters"
+	DataSourcesResource  = "datasources"
+	ApplicationsResource = "applications"
+)
+
+// Resources lists every resource served, in the order they are documented.
+var Resources = []string{ServersResource, ClustersResource, DataSourcesResource, ApplicationsResource}
+
+// Config is the [serve] table of the configuration file.
+type Config struct {
+	// Listen is the address to serve on.
+	Listen string `mapstructure:"listen"`
+	// Cache is how long responses from the AdminServers are reused.  Zero disables caching.
+	Cache time.Duration `mapstructure:"cache"`
+	// StaleWhileRevalidate is how long after Cache a response is still answered with, while a fresh one is fetched in
+	// the background.
+	StaleWhileRevalidate time.Duration `mapstructure:"stale-while-revalidate"`
+	// Roles are the named sets of domains and resources a Token can be granted.
+	Roles map[string]Role `mapstructure:"roles"`
+	// Tokens are the bearer tokens clients authenticate with.  Without any, every request is allowed.
+	Tokens []Token `mapstructure:"tokens"`
+}
+
+// Gateway is an http.Handler serving the resources of its domains.
+type Gateway struct {
+	domains map[string]*remy.Client
+	names   []string
+	auth    *authorizer
+	cache   *cache
+	router  *mux.Router
+}
+
+// New creates a Gateway serving each of domains, keyed by the name clients use for it, with the roles, tokens and
+// cache in cfg.
+func New(domains map[string]*remy.Client, cfg Config) (*Gateway, error) {
+	auth, err := newAuthorizer(cfg.Roles, cfg.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gateway{domains: domains, auth: auth, cache: newCache(cfg.Cache, cfg.StaleWhileRevalidate)}
+	for name := range domains {
+		g.names = append(g.names, name)
+	}
+	sort.Strings(g.names)
+	g.router = g.routes()
+	return g, nil
+}
+
+func (g *Gateway) routes() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/openapi.json", g.openAPI).Methods("GET")
+	r.HandleFunc("/metrics", g.authenticated(g.metrics)).Methods("GET")
+	r.HandleFunc("/domains", g.authenticated(g.listDomains)).Methods("GET")
+	r.HandleFunc("/domains/{domain}/{resource}", g.authenticated(g.resources)).Methods("GET")
+	r.HandleFunc("/domains/{domain}/{resource}/{name}", g.authenticated(g.resource)).Methods("GET")
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no resource found at %v", r.URL.Path)
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "the gateway is read-only")
+	})
+	return r
+}
+
+// ServeHTTP serves the gateway's API.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.router.ServeHTTP(w, r)
+}
+
+// authenticated checks the request's bearer token before calling next with the Token's grants.
+func (g *Gateway) authenticated(next func(http.ResponseWriter, *http.Request, *grants)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		grants, ok := g.auth.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="remy"`)
+			writeError(w, http.StatusUnauthorized, "a valid bearer token is required")
+			return
+		}
+		next(w, r, grants)
+	}
+}
+
+// listDomains lists the domains the token can read.
+func (g *Gateway) listDomains(w http.ResponseWriter, r *http.Request, grants *grants) {
+	domains := []string{}
+	for _, name := range g.names {
+		if grants.domain(name) {
+			domains = append(domains, name)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domains": domains})
+}
+
+// resources lists every resource of a kind in a domain, in full format with ?full=true.
+func (g *Gateway) resources(w http.ResponseWriter, r *http.Request, grants *grants) {
+	vars := mux.Vars(r)
+	full := r.URL.Query().Get("full") == "true"
+	key := fmt.Sprintf("%v/%v?full=%v", vars["domain"], vars["resource"], full)
+	g.serve(w, r, grants, vars["domain"], vars["resource"], key, func(ctx context.Context, c *remy.Client) (interface{}, error) {
+		var items interface{}
+		var err error
+		switch vars["resource"] {
+		case ServersResource:
+			items, err = c.Servers(ctx, remy.QueryOptions{Full: full})
+		case ClustersResource:
+			items, err = c.Clusters(ctx, remy.QueryOptions{Full: full})
+		case DataSourcesResource:
+			items, err = c.DataSources(ctx, remy.QueryOptions{Full: full})
+		case ApplicationsResource:
+			items, err = c.Applications(ctx, remy.QueryOptions{Full: full})
+		}
+		return map[string]interface{}{"items": items}, err
+	})
+}
+
+// resource gets a single named resource, always in full format.
+func (g *Gateway) resource(w http.ResponseWriter, r *http.Request, grants *grants) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	key := fmt.Sprintf("%v/%v/%v", vars["domain"], vars["resource"], name)
+	g.serve(w, r, grants, vars["domain"], vars["resource"], key, func(ctx context.Context, c *remy.Client) (interface{}, error) {
+		switch vars["resource"] {
+		case ServersResource:
+			return c.Server(ctx, name)
+		case ClustersResource:
+			return c.Cluster(ctx, name)
+		case DataSourcesResource:
+			return c.DataSource(ctx, name)
+		default:
+			return c.Application(ctx, name)
+		}
+	})
+}
+
+// serve checks the domain and resource exist and the token can read them, then writes what fetch gets from the
+// domain's AdminServer, or the copy cached under key.  A stale copy is written while fetch refreshes it in the
+// background.
+func (g *Gateway) serve(w http.ResponseWriter, r *http.Request, grants *grants, domain, resource, key string,
+	fetch func(context.Context, *remy.Client) (interface{}, error)) {
+	client, ok := g.domains[domain]
+	switch {
+	case !ok || !grants.domain(domain):
+		writeError(w, http.StatusNotFound, "no domain named %v", domain)
+		return
+	case !isResource(resource):
+		writeError(w, http.StatusNotFound, "no resource named %v, expected one of %v", resource, strings.Join(Resources, ", "))
+		return
+	case !grants.resource(resource):
+		writeError(w, http.StatusForbidden, "not allowed to read %v", resource)
+		return
+	}
+
+	if data, fresh, ok := g.cache.get(key); ok {
+		if fresh {
+			w.Header().Set("X-Cache", "HIT")
+		} else {
+			w.Header().Set("X-Cache", "STALE")
+			g.cache.refresh(key, func() ([]byte, error) {
+				v, err := fetch(context.Background(), client)
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(v)
+			})
+		}
+		writeRaw(w, http.StatusOK, data)
+		return
+	}
+	v, err := fetch(r.Context(), client)
+	if err != nil {
+		status := http.StatusBadGateway
+		if remy.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, "unable to get %v from domain %v: %v", resource, domain, firstLine(err.Error()))
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "unable to encode %v: %v", resource, err)
+		return
+	}
+	g.cache.put(key, data)
+	w.Header().Set("X-Cache", "MISS")
+	writeRaw(w, http.StatusOK, data)
+}
+
+func isResource(resource string) bool {
+	for _, r := range Resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine keeps AdminServer response bodies, which follow the status in client errors, out of gateway errors.
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeRaw(w, status, data)
+}
+
+func writeRaw(w http.ResponseWriter, status int, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
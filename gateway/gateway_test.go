@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGateway(t *testing.T, cfg Config) (*Gateway, *remytest.Server, func()) {
+	fake := remytest.New()
+	ts := fake.Start()
+	g, err := New(map[string]*remy.Client{"prod": remy.NewClient(fake.AdminServer(ts.URL))}, cfg)
+	if err != nil {
+		ts.Close()
+		t.Fatal(err)
+	}
+	return g, fake, ts.Close
+}
+
+func get(g *Gateway, path, token string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", path, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+	return w
+}
+
+func TestGatewayResources(t *testing.T) {
+	g, _, cleanup := newTestGateway(t, Config{})
+	defer cleanup()
+
+	w := get(g, "/domains", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"domains":["prod"]}`, w.Body.String())
+
+	w = get(g, "/domains/prod/servers", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list struct{ Items []remy.Server }
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 3)
+
+	w = get(g, "/domains/prod/datasources/ds1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ds remy.DataSource
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ds))
+	assert.Equal(t, "ds1", ds.Name)
+	assert.Len(t, ds.Instances, 2)
+}
+
+func TestGatewayErrors(t *testing.T) {
+	g, fake, cleanup := newTestGateway(t, Config{})
+	defer cleanup()
+
+	assert.Equal(t, http.StatusNotFound, get(g, "/domains/test/servers", "").Code)
+	assert.Equal(t, http.StatusNotFound, get(g, "/domains/prod/widgets", "").Code)
+	assert.Equal(t, http.StatusNotFound, get(g, "/domains/prod/servers/nope", "").Code)
+
+	r := httptest.NewRequest("DELETE", "/domains/prod/servers/ms1", nil)
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	fake.InjectError("servers", http.StatusInternalServerError)
+	w = get(g, "/domains/prod/servers", "")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"unable to get servers from domain prod`)
+}
+
+func TestGatewayCache(t *testing.T) {
+	g, fake, cleanup := newTestGateway(t, Config{Cache: time.Minute})
+	defer cleanup()
+
+	w := get(g, "/domains/prod/servers", "")
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	fake.InjectError("servers", http.StatusInternalServerError)
+	w = get(g, "/domains/prod/servers", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+
+	// full format responses are cached separately
+	assert.Equal(t, http.StatusBadGateway, get(g, "/domains/prod/servers?full=true", "").Code)
+}
+
+func TestGatewayStaleWhileRevalidate(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+	var blocked int32
+	release := make(chan struct{})
+	transport := remy.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.LoadInt32(&blocked) == 1 {
+			<-release
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := remy.NewClient(fake.AdminServer(ts.URL), remy.WithTransport(transport))
+	g, err := New(map[string]*remy.Client{"prod": client}, Config{Cache: time.Minute, StaleWhileRevalidate: time.Minute})
+	assert.NoError(t, err)
+	now := time.Now()
+	g.cache.now = func() time.Time { return now }
+
+	assert.Equal(t, "MISS", get(g, "/domains/prod/servers", "").Header().Get("X-Cache"))
+	fake.Update(func(d *remytest.Domain) { d.Servers = d.Servers[:1] })
+	atomic.StoreInt32(&blocked, 1)
+	now = now.Add(90 * time.Second)
+
+	w := get(g, "/domains/prod/servers", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "STALE", w.Header().Get("X-Cache"), "answered while the refresh waits on the AdminServer")
+	var list struct{ Items []remy.Server }
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 3)
+
+	close(release)
+	for i := 0; i < 100; i++ {
+		if _, fresh, _ := g.cache.get("prod/servers?full=false"); fresh {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	w = get(g, "/domains/prod/servers", "")
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 1, "the refreshed servers")
+}
+
+func TestGatewayAuthorization(t *testing.T) {
+	g, _, cleanup := newTestGateway(t, Config{
+		Roles: map[string]Role{
+			"ops":    {Domains: []string{"*"}, Resources: []string{"*"}},
+			"portal": {Domains: []string{"test*"}, Resources: []string{"servers"}},
+		},
+		Tokens: []Token{{Name: "ops", Token: "s3cret", Roles: []string{"ops"}}, {Name: "portal", Token: "p0rtal", Roles: []string{"portal"}}},
+	})
+	defer cleanup()
+
+	w := get(g, "/domains/prod/servers", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="remy"`, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, get(g, "/domains/prod/servers", "wrong").Code)
+	assert.Equal(t, http.StatusOK, get(g, "/domains/prod/servers", "s3cret").Code)
+
+	assert.JSONEq(t, `{"domains":[]}`, get(g, "/domains", "p0rtal").Body.String())
+	assert.Equal(t, http.StatusNotFound, get(g, "/domains/prod/servers", "p0rtal").Code)
+
+	assert.Equal(t, http.StatusOK, get(g, "/openapi.json", "").Code)
+}
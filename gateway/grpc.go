@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/klauern/remy/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodResources are the resources read by each unary method of the Remy service.
+var methodResources = map[string]string{
+	rpc.Remy_GetServer_FullMethodName:        ServersResource,
+	rpc.Remy_ListServers_FullMethodName:      ServersResource,
+	rpc.Remy_GetCluster_FullMethodName:       ClustersResource,
+	rpc.Remy_ListClusters_FullMethodName:     ClustersResource,
+	rpc.Remy_GetDataSource_FullMethodName:    DataSourcesResource,
+	rpc.Remy_ListDataSources_FullMethodName:  DataSourcesResource,
+	rpc.Remy_GetApplication_FullMethodName:   ApplicationsResource,
+	rpc.Remy_ListApplications_FullMethodName: ApplicationsResource,
+}
+
+// GRPCAuth checks the bearer tokens of calls to the Remy service of the named domain the same way the gateway checks
+// its requests: the call's "authorization" metadata must hold a token whose roles grant the domain and every resource
+// the call reads.  Other services, such as reflection, only need a valid token.  Without tokens, every call is allowed.
+func GRPCAuth(domain string, roles map[string]Role, tokens []Token) (
+	grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error) {
+	a, err := newAuthorizer(roles, tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resources []string
+		if resource, ok := methodResources[info.FullMethod]; ok {
+			resources = []string{resource}
+		}
+		if err := a.authorizeCall(ctx, domain, resources); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorizeCall(ss.Context(), domain, nil); err != nil {
+			return err
+		}
+		if info.FullMethod == rpc.Remy_Watch_FullMethodName {
+			ss = &watchStream{ServerStream: ss, auth: a, domain: domain}
+		}
+		return handler(srv, ss)
+	}
+	return unary, stream, nil
+}
+
+// authorizeCall checks the bearer token of a call grants the domain and resources.
+func (a *authorizer) authorizeCall(ctx context.Context, domain string, resources []string) error {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	grants, ok := a.bearer(header)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "a valid bearer token is required")
+	}
+	if !grants.domain(domain) {
+		return status.Errorf(codes.PermissionDenied, "not allowed to read domain %v", domain)
+	}
+	for _, resource := range resources {
+		if !grants.resource(resource) {
+			return status.Errorf(codes.PermissionDenied, "not allowed to read %v", resource)
+		}
+	}
+	return nil
+}
+
+// watchStream checks the resources of a WatchRequest as the handler receives it, as a stream's request isn't known
+// until then.  Watching every resource needs them all granted.
+type watchStream struct {
+	grpc.ServerStream
+	auth   *authorizer
+	domain string
+}
+
+func (s *watchStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	req, ok := m.(*rpc.WatchRequest)
+	if !ok {
+		return nil
+	}
+	resources := req.GetResources()
+	if len(resources) == 0 {
+		resources = Resources
+	}
+	return s.auth.authorizeCall(s.Context(), s.domain, resources)
+}
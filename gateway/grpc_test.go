@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/klauern/remy/rpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestGRPCAuth(t *testing.T) {
+	unary, stream, err := GRPCAuth("prod", map[string]Role{
+		"servers": {Domains: []string{"prod*"}, Resources: []string{"servers"}},
+		"test":    {Domains: []string{"test*"}, Resources: []string{"*"}},
+	}, []Token{
+		{Name: "ops", Token: "ops-token", Roles: []string{"servers"}},
+		{Name: "qa", Token: "qa-token", Roles: []string{"test"}},
+	})
+	assert.NoError(t, err)
+
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+	service := rpc.NewService(remy.NewClient(fake.AdminServer(ts.URL)))
+	service.MinWatchInterval = time.Millisecond
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	rpc.RegisterRemyServer(server, service)
+	go server.Serve(lis)
+	defer server.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := rpc.NewRemyClient(conn)
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	_, err = client.ListServers(context.Background(), &rpc.ListRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ListServers(withToken("wrong"), &rpc.ListRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	servers, err := client.ListServers(withToken("ops-token"), &rpc.ListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, servers.GetServers(), 3)
+	_, err = client.ListClusters(withToken("ops-token"), &rpc.ListRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.ListServers(withToken("qa-token"), &rpc.ListRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	watch := func(token string, resources ...string) error {
+		ctx, cancel := context.WithTimeout(withToken(token), time.Second)
+		defer cancel()
+		w, err := client.Watch(ctx, &rpc.WatchRequest{Resources: resources, Interval: durationpb.New(time.Millisecond)})
+		if err != nil {
+			return err
+		}
+		_, err = w.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	assert.Equal(t, codes.Unauthenticated, status.Code(watch("wrong", "servers")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(watch("ops-token")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(watch("ops-token", "servers", "clusters")))
+	assert.NoError(t, watch("ops-token", "servers"))
+}
+
+func TestGRPCAuthWithoutTokens(t *testing.T) {
+	unary, _, err := GRPCAuth("prod", nil, nil)
+	assert.NoError(t, err)
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: rpc.Remy_ListServers_FullMethodName}
+	resp, err := unary(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, _, err = GRPCAuth("prod", nil, []Token{{Name: "a", Roles: []string{"missing"}, Token: "x"}})
+	assert.EqualError(t, err, "token a: no role named missing")
+}
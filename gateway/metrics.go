@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/klauern/remy"
+)
+
+// rateLimitMetrics are the Prometheus metrics of each domain's rate limit, and how to read them from its stats.
+var rateLimitMetrics = []struct {
+	name, kind, help string
+	value            func(remy.RateLimitStats) float64
+}{
+	{"remy_rate_limit_requests_total", "counter", "Requests the AdminServer's rate limit let through.",
+		func(s remy.RateLimitStats) float64 { return float64(s.Requests) }},
+	{"remy_rate_limit_delayed_total", "counter", "Requests that waited for a token or a free slot.",
+		func(s remy.RateLimitStats) float64 { return float64(s.Delayed) }},
+	{"remy_rate_limit_wait_seconds_total", "counter", "Time requests waited for the rate limit.",
+		func(s remy.RateLimitStats) float64 { return s.Waited.Seconds() }},
+	{"remy_rate_limit_max_wait_seconds", "gauge", "Longest any one request waited for the rate limit.",
+		func(s remy.RateLimitStats) float64 { return s.MaxWait.Seconds() }},
+	{"remy_rate_limit_waiting", "gauge", "Requests waiting for a token or a free slot now.",
+		func(s remy.RateLimitStats) float64 { return float64(s.Waiting) }},
+	{"remy_rate_limit_in_flight", "gauge", "Requests sent to the AdminServer and not yet finished.",
+		func(s remy.RateLimitStats) float64 { return float64(s.InFlight) }},
+}
+
+// metrics serves the rate limit stats of every domain the token can read in the Prometheus text format, so how much
+// the gateway is being throttled can be graphed and alerted on.
+func (g *Gateway) metrics(w http.ResponseWriter, r *http.Request, grants *grants) {
+	var b strings.Builder
+	for _, m := range rateLimitMetrics {
+		fmt.Fprintf(&b, "# HELP %v %v\n# TYPE %v %v\n", m.name, m.help, m.name, m.kind)
+		for _, name := range g.names {
+			if grants.domain(name) {
+				fmt.Fprintf(&b, "%v{domain=%q} %v\n", m.name, name, m.value(g.domains[name].RateLimitStats()))
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
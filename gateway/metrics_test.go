@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayMetrics(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+	limited := fake.AdminServer(ts.URL)
+	limited.MaxInFlight = 3
+	g, err := New(map[string]*remy.Client{
+		"prod": remy.NewClient(limited),
+		"test": remy.NewClient(fake.AdminServer(ts.URL)),
+	}, Config{
+		Roles:  map[string]Role{"portal": {Domains: []string{"prod"}, Resources: []string{"*"}}},
+		Tokens: []Token{{Name: "portal", Token: "p0rtal", Roles: []string{"portal"}}},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, get(g, "/domains/prod/servers", "p0rtal").Code)
+	assert.Equal(t, http.StatusUnauthorized, get(g, "/metrics", "").Code)
+	w := get(g, "/metrics", "p0rtal")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "# TYPE remy_rate_limit_requests_total counter\n")
+	assert.Regexp(t, `\nremy_rate_limit_requests_total\{domain="prod"\} [1-9]`, w.Body.String())
+	assert.Contains(t, w.Body.String(), "\nremy_rate_limit_in_flight{domain=\"prod\"} 0\n")
+	assert.Contains(t, w.Body.String(), "\nremy_rate_limit_waiting{domain=\"prod\"} 0\n")
+	assert.NotContains(t, w.Body.String(), `domain="test"`, "only the domains the token can read")
+}
@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/klauern/remy"
+)
+
+// schemas are the types served for each resource, documented as OpenAPI component schemas.
+var schemas = map[string]reflect.Type{
+	ServersResource:      reflect.TypeOf(remy.Server{}),
+	ClustersResource:     reflect.TypeOf(remy.Cluster{}),
+	DataSourcesResource:  reflect.TypeOf(remy.DataSource{}),
+	ApplicationsResource: reflect.TypeOf(remy.Application{}),
+}
+
+// openAPI serves an OpenAPI 3.0 description of the gateway.  It needs no token, so clients can be generated from it.
+func (g *Gateway) openAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, OpenAPI())
+}
+
+// OpenAPI describes the gateway's API as an OpenAPI 3.0 document, with the schema of each resource generated from
+// the fields the client decodes.
+func OpenAPI() map[string]interface{} {
+	components := make(map[string]interface{})
+	components["Error"] = object(map[string]interface{}{"error": primitive("string")})
+	paths := map[string]interface{}{
+		"/domains": map[string]interface{}{
+			"get": operation("List the domains the token can read", map[string]interface{}{
+				"domains": map[string]interface{}{"type": "array", "items": primitive("string")},
+			}, nil),
+		},
+	}
+
+	domain := parameter("domain", "path", "string", "The name of the domain")
+	for _, resource := range Resources {
+		ref := schema(schemas[resource], components)
+		paths["/domains/{domain}/"+resource] = map[string]interface{}{
+			"get": operation("List the "+resource+" of a domain", map[string]interface{}{
+				"items": map[string]interface{}{"type": "array", "items": ref},
+			}, []interface{}{domain, parameter("full", "query", "boolean", "Fetch every field rather than the summary")}),
+		}
+		paths["/domains/{domain}/"+resource+"/{name}"] = map[string]interface{}{
+			"get": operation("Get one of the "+resource+" of a domain", ref,
+				[]interface{}{domain, parameter("name", "path", "string", "The name of the "+strings.TrimSuffix(resource, "s"))}),
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "remy gateway",
+			"description": "A read-only view of the servers, clusters, datasources and applications of WebLogic domains.",
+			"version":     "1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas":         components,
+			"securitySchemes": map[string]interface{}{"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"}},
+		},
+		"security": []interface{}{map[string]interface{}{"bearer": []string{}}},
+	}
+}
+
+// operation describes a GET returning body, which is either a schema or the properties of an object.
+func operation(summary string, body map[string]interface{}, parameters []interface{}) map[string]interface{} {
+	if _, ref := body["$ref"]; !ref {
+		body = object(body)
+	}
+	errorResponse := func(description string) map[string]interface{} {
+		return map[string]interface{}{"description": description, "content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"}},
+		}}
+	}
+	op := map[string]interface{}{
+		"summary": summary,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{"description": "OK", "content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": body},
+			}},
+			"401": errorResponse("No valid bearer token"),
+			"403": errorResponse("The token can't read the resource"),
+			"404": errorResponse("No such domain or resource"),
+			"502": errorResponse("The domain's AdminServer failed"),
+		},
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+	return op
+}
+
+func parameter(name, in, kind, description string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "in": in, "required": in == "path", "description": description, "schema": primitive(kind)}
+}
+
+func object(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func primitive(kind string) map[string]interface{} {
+	return map[string]interface{}{"type": kind}
+}
+
+// schema returns the schema of t, adding a component for each struct it refers to and referencing it.
+func schema(t reflect.Type, components map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schema(t.Elem(), components)
+	case reflect.String:
+		return primitive("string")
+	case reflect.Bool:
+		return primitive("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return primitive("integer")
+	case reflect.Float32, reflect.Float64:
+		return primitive("number")
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schema(t.Elem(), components)}
+	case reflect.Struct:
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := components[t.Name()]; ok {
+			return ref
+		}
+		properties := make(map[string]interface{})
+		components[t.Name()] = object(properties)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if f.PkgPath != "" || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			properties[name] = schema(f.Type, components)
+		}
+		return ref
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI(t *testing.T) {
+	data, err := json.Marshal(OpenAPI())
+	assert.NoError(t, err)
+	var spec struct {
+		OpenAPI    string
+		Paths      map[string]interface{}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{}
+			}
+		}
+	}
+	assert.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Contains(t, spec.Paths, "/domains")
+	assert.Contains(t, spec.Paths, "/domains/{domain}/datasources/{name}")
+	assert.Len(t, spec.Paths, 1+2*len(Resources))
+
+	server := spec.Components.Schemas["Server"].Properties
+	assert.Equal(t, "string", server["name"]["type"])
+	assert.Equal(t, "integer", server["HeapSizeCurrent"]["type"])
+	assert.Equal(t, "number", server["JvmProcessorLoad"]["type"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/DataSourceInstance"}},
+		spec.Components.Schemas["DataSource"].Properties["instances"])
+	assert.Contains(t, spec.Components.Schemas, "WorkManager")
+}
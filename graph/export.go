@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// dotShapes are the Graphviz node shapes used for each Kind.
+var dotShapes = map[Kind]string{
+	Application: "box",
+	Cluster:     "doubleoctagon",
+	Server:      "box3d",
+	DataSource:  "cylinder",
+	Instance:    "cylinder",
+	WorkManager: "component",
+	Constraint:  "component",
+}
+
+// DOT formats the Graph for Graphviz, e.g., `remy graph | dot -Tsvg > domain.svg`.
+func (g *Graph) DOT() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph domain {\n")
+	buffer.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		buffer.WriteString(fmt.Sprintf("  %q [label=%q, shape=%v];\n", n.ID, n.Name, dotShapes[n.Kind]))
+	}
+	for _, e := range g.Edges {
+		buffer.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", e.From, e.To, e.Relation))
+	}
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+// mermaidShapes are the opening and closing brackets of the Mermaid node shape used for each Kind.
+var mermaidShapes = map[Kind][2]string{
+	Application: {"[", "]"},
+	Cluster:     {"{{", "}}"},
+	Server:      {"[[", "]]"},
+	DataSource:  {"[(", ")]"},
+	Instance:    {"[(", ")]"},
+	WorkManager: {"([", "])"},
+	Constraint:  {"([", "])"},
+}
+
+// Mermaid formats the Graph as a Mermaid flowchart.  Node IDs contain characters Mermaid doesn't allow, so nodes are
+// numbered in order and labelled with their name.
+func (g *Graph) Mermaid() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("graph LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		shape := mermaidShapes[n.Kind]
+		label := strings.Replace(n.Name, `"`, "#quot;", -1)
+		buffer.WriteString(fmt.Sprintf("  %v%v\"%v\"%v\n", ids[n.ID], shape[0], label, shape[1]))
+	}
+	for _, e := range g.Edges {
+		buffer.WriteString(fmt.Sprintf("  %v -->|%v| %v\n", ids[e.From], e.Relation, ids[e.To]))
+	}
+	return buffer.String()
+}
+
+// String formats the Graph for the console, one line per Edge.
+func (g *Graph) String() string {
+	var buffer bytes.Buffer
+	for _, e := range g.Edges {
+		buffer.WriteString(fmt.Sprintf("%v %v %v\n", e.From, e.Relation, e.To))
+	}
+	return buffer.String()
+}
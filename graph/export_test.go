@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDOT(t *testing.T) {
+	dot := Build(domainSnapshot(remytest.DefaultDomain())).DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph domain {\n"))
+	assert.Contains(t, dot, `"datasource:ds1" [label="ds1", shape=cylinder];`)
+	assert.Contains(t, dot, `"application:app1" -> "cluster:cluster1" [label="targets"];`)
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+}
+
+func TestMermaid(t *testing.T) {
+	mermaid := Build(domainSnapshot(remytest.DefaultDomain())).Mermaid()
+	assert.True(t, strings.HasPrefix(mermaid, "graph LR\n"))
+	assert.Contains(t, mermaid, `  n0["app1"]`)
+	assert.Contains(t, mermaid, `  n1{{"cluster1"}}`)
+	assert.Contains(t, mermaid, "  n0 -->|targets| n1\n")
+	assert.NotContains(t, mermaid, ":", "node IDs should not be used")
+}
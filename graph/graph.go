@@ -0,0 +1,250 @@
+// Package graph builds the topology of a domain from a snapshot: the targets applications are deployed to, the servers
+// in each cluster, and the datasources, work managers and thread constraints applications use on each server.  The
+// graph can be exported as Graphviz DOT, Mermaid or JSON, and walked backwards to find everything affected when a
+// resource goes down.
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/klauern/remy/snapshot"
+)
+
+// Kind is the type of resource a Node represents.
+type Kind string
+
+// The kinds of Node in a Graph.  Instances, work managers and constraints are named for their parent, e.g., the
+// instance of ds1 on ms1 is "ds1/ms1", and app1's default work manager is "app1/default".
+const (
+	Application Kind = "application"
+	Cluster     Kind = "cluster"
+	Server      Kind = "server"
+	DataSource  Kind = "datasource"
+	Instance    Kind = "instance"
+	WorkManager Kind = "workmanager"
+	Constraint  Kind = "constraint"
+)
+
+// Kinds lists every Kind, in the order nodes are sorted.
+var Kinds = []Kind{Application, Cluster, Server, DataSource, Instance, WorkManager, Constraint}
+
+// The relations an Edge describes, read as "From <relation> To".
+const (
+	TargetedTo  = "targets"
+	MemberOf    = "member"
+	Uses        = "uses"
+	HasInstance = "instance"
+	RunsOn      = "runs on"
+)
+
+// Node is a single resource in the domain.
+type Node struct {
+	ID   string `json:"id"`
+	Kind Kind   `json:"kind"`
+	Name string `json:"name"`
+}
+
+// Edge is a dependency of From on To: if To goes down, From is affected.
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// Graph is the topology of a domain.  Nodes are sorted by Kind and then name, and Edges by From and then To.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+	nodes map[string]Node
+	edges map[Edge]bool
+}
+
+// ID is the identifier of the Node of kind with name, e.g., "server:ms1".
+func ID(kind Kind, name string) string {
+	return string(kind) + ":" + name
+}
+
+// Build creates the Graph of the domain captured in s.  Clusters point to their member servers, applications to their
+// targets, datasources, work managers and constraints, datasources to their instances, and instances, work managers
+// and constraints to the server they run on.
+func Build(s *snapshot.Snapshot) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}, nodes: make(map[string]Node), edges: make(map[Edge]bool)}
+	for _, srv := range s.Servers {
+		g.add(Server, srv.Name)
+	}
+	clusters := make(map[string]bool)
+	for _, c := range s.Clusters {
+		clusters[c.Name] = true
+		cluster := g.add(Cluster, c.Name)
+		for _, m := range c.Servers {
+			g.link(cluster, g.add(Server, m.Name), MemberOf)
+		}
+	}
+	for _, ds := range s.DataSources {
+		d := g.add(DataSource, ds.Name)
+		for _, inst := range ds.Instances {
+			g.link(d, g.instance(ds.Name, inst.Server), HasInstance)
+		}
+	}
+	for _, app := range s.Applications {
+		a := g.add(Application, app.Name)
+		for _, t := range app.TargetStates {
+			kind := Server
+			if clusters[t.Target] {
+				kind = Cluster
+			}
+			g.link(a, g.add(kind, t.Target), TargetedTo)
+		}
+		for _, ds := range app.DataSources {
+			d := g.add(DataSource, ds.Name)
+			g.link(a, d, Uses)
+			if ds.Server != "" {
+				g.link(d, g.instance(ds.Name, ds.Server), HasInstance)
+			}
+		}
+		for _, wm := range app.WorkManagers {
+			g.runsOn(a, WorkManager, app.Name+"/"+wm.Name, wm.Server)
+		}
+		for _, c := range app.MinThreadsConstraints {
+			g.runsOn(a, Constraint, app.Name+"/"+c.Name, c.Server)
+		}
+		for _, c := range app.MaxThreadsConstraints {
+			g.runsOn(a, Constraint, app.Name+"/"+c.Name, c.Server)
+		}
+	}
+	g.sort()
+	return g
+}
+
+// add adds the Node of kind with name, if it isn't already in the Graph, and returns its ID.
+func (g *Graph) add(kind Kind, name string) string {
+	id := ID(kind, name)
+	if _, ok := g.nodes[id]; !ok {
+		n := Node{ID: id, Kind: kind, Name: name}
+		g.nodes[id] = n
+		g.Nodes = append(g.Nodes, n)
+	}
+	return id
+}
+
+// link adds the Edge from -> to, if it isn't already in the Graph.
+func (g *Graph) link(from, to, relation string) {
+	e := Edge{From: from, To: to, Relation: relation}
+	if !g.edges[e] {
+		g.edges[e] = true
+		g.Edges = append(g.Edges, e)
+	}
+}
+
+// instance adds the instance of datasource ds on server, running on that server, and returns its ID.
+func (g *Graph) instance(ds, server string) string {
+	id := g.add(Instance, ds+"/"+server)
+	g.link(id, g.add(Server, server), RunsOn)
+	return id
+}
+
+// runsOn adds the work manager or constraint name used by app, running on server.
+func (g *Graph) runsOn(app string, kind Kind, name, server string) {
+	id := g.add(kind, name)
+	g.link(app, id, Uses)
+	if server != "" {
+		g.link(id, g.add(Server, server), RunsOn)
+	}
+}
+
+func (g *Graph) sort() {
+	order := make(map[Kind]int)
+	for i, k := range Kinds {
+		order[k] = i
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		if g.Nodes[i].Kind != g.Nodes[j].Kind {
+			return order[g.Nodes[i].Kind] < order[g.Nodes[j].Kind]
+		}
+		return g.Nodes[i].Name < g.Nodes[j].Name
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+// Node returns the Node of kind with name, and whether it is in the Graph.
+func (g *Graph) Node(kind Kind, name string) (Node, bool) {
+	n, ok := g.nodes[ID(kind, name)]
+	return n, ok
+}
+
+// Impact is every Node affected, directly or transitively, when a resource goes down.
+type Impact struct {
+	Node     Node   `json:"node"`
+	Affected []Node `json:"affected"`
+}
+
+// Impact walks the Graph backwards from the Node of kind with name to find everything that depends on it, e.g., the
+// applications using a datasource, or the clusters, instances and applications on a server.
+func (g *Graph) Impact(kind Kind, name string) (*Impact, error) {
+	start, ok := g.Node(kind, name)
+	if !ok {
+		return nil, fmt.Errorf("no %v named %v in the domain", kind, name)
+	}
+	dependents := make(map[string][]string)
+	for _, e := range g.Edges {
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+	seen := map[string]bool{start.ID: true}
+	queue := []string{start.ID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, from := range dependents[id] {
+			if !seen[from] {
+				seen[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+	impact := &Impact{Node: start, Affected: []Node{}}
+	for _, n := range g.Nodes {
+		if seen[n.ID] && n.ID != start.ID {
+			impact.Affected = append(impact.Affected, n)
+		}
+	}
+	return impact, nil
+}
+
+// Applications lists the names of the affected applications.
+func (i *Impact) Applications() []string {
+	var apps []string
+	for _, n := range i.Affected {
+		if n.Kind == Application {
+			apps = append(apps, n.Name)
+		}
+	}
+	return apps
+}
+
+// String formats the Impact for the console, the affected resources grouped by Kind.
+func (i *Impact) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("If %v %v goes down:\n", i.Node.Kind, i.Node.Name))
+	if len(i.Affected) == 0 {
+		buffer.WriteString("Nothing depends on it\n")
+	}
+	for _, kind := range Kinds {
+		var names []string
+		for _, n := range i.Affected {
+			if n.Kind == kind {
+				names = append(names, n.Name)
+			}
+		}
+		if len(names) > 0 {
+			buffer.WriteString(fmt.Sprintf("  %v affected: %v\n", kind, names))
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/klauern/remy/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func domainSnapshot(d remytest.Domain) *snapshot.Snapshot {
+	return &snapshot.Snapshot{Servers: d.Servers, Clusters: d.Clusters, DataSources: d.DataSources, Applications: d.Applications}
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(domainSnapshot(remytest.DefaultDomain()))
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{
+		"application:app1", "cluster:cluster1", "server:AdminServer", "server:ms1", "server:ms2", "datasource:ds1",
+		"instance:ds1/ms1", "instance:ds1/ms2", "workmanager:app1/default",
+	}, ids)
+	assert.Contains(t, g.Edges, Edge{From: "application:app1", To: "cluster:cluster1", Relation: TargetedTo})
+	assert.Contains(t, g.Edges, Edge{From: "cluster:cluster1", To: "server:ms2", Relation: MemberOf})
+	assert.Contains(t, g.Edges, Edge{From: "application:app1", To: "datasource:ds1", Relation: Uses})
+	assert.Contains(t, g.Edges, Edge{From: "datasource:ds1", To: "instance:ds1/ms1", Relation: HasInstance})
+	assert.Contains(t, g.Edges, Edge{From: "instance:ds1/ms1", To: "server:ms1", Relation: RunsOn})
+	assert.Contains(t, g.Edges, Edge{From: "workmanager:app1/default", To: "server:ms2", Relation: RunsOn})
+	assert.Len(t, g.Edges, 11, "edges referenced twice should only be added once")
+}
+
+func TestBuildServerTargets(t *testing.T) {
+	s := &snapshot.Snapshot{
+		Servers: []remy.Server{{Name: "ms1"}},
+		Applications: []remy.Application{{
+			Name:                  "app1",
+			TargetStates:          []remy.TargetState{{Target: "ms1"}},
+			MaxThreadsConstraints: []remy.MaxThreadsConstraint{{Name: "max", Server: "ms1"}},
+		}},
+	}
+	g := Build(s)
+	assert.Contains(t, g.Edges, Edge{From: "application:app1", To: "server:ms1", Relation: TargetedTo})
+	assert.Contains(t, g.Edges, Edge{From: "constraint:app1/max", To: "server:ms1", Relation: RunsOn})
+}
+
+func TestImpact(t *testing.T) {
+	d := remytest.GenerateDomain(remytest.DomainConfig{ManagedServers: 2, Clusters: 1, DataSources: 2, Applications: 1})
+	d.Applications = append(d.Applications, remy.Application{Name: "standalone", TargetStates: []remy.TargetState{{Target: "AdminServer"}}})
+	g := Build(domainSnapshot(d))
+
+	impact, err := g.Impact(DataSource, "ds2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app1"}, impact.Applications())
+	assert.Len(t, impact.Affected, 1)
+
+	impact, err = g.Impact(Server, "ms1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app1"}, impact.Applications())
+	affected := make(map[string]bool)
+	for _, n := range impact.Affected {
+		affected[n.ID] = true
+	}
+	assert.True(t, affected["cluster:cluster1"])
+	assert.True(t, affected["instance:ds1/ms1"])
+	assert.True(t, affected["datasource:ds1"])
+	assert.False(t, affected["instance:ds1/ms2"])
+	assert.Contains(t, impact.String(), "application affected: [app1]")
+
+	impact, err = g.Impact(Server, "AdminServer")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"standalone"}, impact.Applications())
+
+	impact, err = g.Impact(Application, "app1")
+	assert.NoError(t, err)
+	assert.Empty(t, impact.Affected)
+	assert.Contains(t, impact.String(), "Nothing depends on it")
+
+	_, err = g.Impact(DataSource, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,212 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/klauern/remy"
+)
+
+// Resource names samples are stored under.
+const (
+	ServersResource      = "servers"
+	ClustersResource     = "clusters"
+	DataSourcesResource  = "datasources"
+	ApplicationsResource = "applications"
+)
+
+// Collector polls a domain on an interval and writes every numeric statistic it finds to the Store at Path.  The Store
+// is only open while samples are written or maintained, so the history can be read while a Collector runs.
+type Collector struct {
+	Client *remy.Client
+	Path   string
+
+	// Interval is the time between polls.  It must be positive.
+	Interval time.Duration
+	// Retention is how long samples are kept.  Zero keeps them forever.
+	Retention time.Duration
+	// DownsampleAfter is the age after which samples are averaged over DownsampleStep.  Zero disables downsampling.
+	DownsampleAfter time.Duration
+	// DownsampleStep is the period samples older than DownsampleAfter are averaged over.
+	DownsampleStep time.Duration
+	// MaintenanceInterval is the time between applying Retention and DownsampleAfter, each of which rescans the whole
+	// Store.  Zero is DefaultMaintenanceInterval.
+	MaintenanceInterval time.Duration
+
+	// OnError is called with any error collecting samples.  Collection continues on the next Interval regardless.
+	OnError func(error)
+	// OnPoll, when set, is called with everything fetched by each poll, before its samples are written.
+	OnPoll func(ctx context.Context, p *Poll)
+}
+
+// DefaultMaintenanceInterval is how often a Collector applies retention and downsampling by default.
+const DefaultMaintenanceInterval = time.Hour
+
+// Poll is the full format resources fetched by a single poll of the domain.
+type Poll struct {
+	Time         time.Time
+	Servers      []remy.Server
+	Clusters     []remy.Cluster
+	DataSources  []remy.DataSource
+	Applications []remy.Application
+}
+
+// Run polls the domain every Interval, and maintains the Store every MaintenanceInterval, until ctx is done.
+func (c *Collector) Run(ctx context.Context) error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("collection interval must be positive, was %v", c.Interval)
+	}
+	maintenance := c.MaintenanceInterval
+	if maintenance <= 0 {
+		maintenance = DefaultMaintenanceInterval
+	}
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	var maintained time.Time
+	for {
+		now := time.Now()
+		if err := c.CollectOnce(ctx, now); err != nil && c.OnError != nil {
+			c.OnError(err)
+		}
+		if now.Sub(maintained) >= maintenance {
+			if err := c.Maintain(now); err != nil && c.OnError != nil {
+				c.OnError(err)
+			}
+			maintained = now
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectOnce polls the full format Servers, DataSources, Clusters and Applications once, and writes their samples
+// timestamped now.
+func (c *Collector) CollectOnce(ctx context.Context, now time.Time) error {
+	var samples []Sample
+
+	servers, err := c.Client.Servers(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return err
+	}
+	for i := range servers {
+		samples = append(samples, Samples(now, ServersResource, servers[i].Name, "", servers[i])...)
+	}
+
+	dataSources, err := c.Client.DataSources(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return err
+	}
+	for _, ds := range dataSources {
+		for _, inst := range ds.Instances {
+			samples = append(samples, Samples(now, DataSourcesResource, ds.Name+"/"+inst.Server, "", inst)...)
+		}
+	}
+
+	clusters, err := c.Client.Clusters(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		for _, member := range cluster.Servers {
+			samples = append(samples, Samples(now, ClustersResource, cluster.Name+"/"+member.Name, "", member)...)
+		}
+	}
+
+	applications, err := c.Client.Applications(ctx, remy.QueryOptions{Full: true})
+	if err != nil {
+		return err
+	}
+	for _, app := range applications {
+		samples = append(samples, applicationSamples(now, app)...)
+	}
+
+	if c.OnPoll != nil {
+		c.OnPoll(ctx, &Poll{Time: now, Servers: servers, Clusters: clusters, DataSources: dataSources, Applications: applications})
+	}
+	return c.withStore(func(s *Store) error {
+		return s.Write(samples)
+	})
+}
+
+// Maintain applies Retention and downsampling as of now.
+func (c *Collector) Maintain(now time.Time) error {
+	if c.Retention <= 0 && c.DownsampleAfter <= 0 {
+		return nil
+	}
+	return c.withStore(func(s *Store) error {
+		if c.Retention > 0 {
+			if err := s.Prune(now.Add(-c.Retention)); err != nil {
+				return err
+			}
+		}
+		if c.DownsampleAfter > 0 {
+			return s.Downsample(now.Add(-c.DownsampleAfter), c.DownsampleStep)
+		}
+		return nil
+	})
+}
+
+// withStore opens the Store at Path for fn, and closes it again so readers aren't locked out until the next time.
+func (c *Collector) withStore(fn func(s *Store) error) error {
+	s, err := Open(c.Path)
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		s.Close()
+		return err
+	}
+	return s.Close()
+}
+
+// applicationSamples names each work manager, constraint and request class sample app/item/server, with the metric
+// prefixed by its kind, e.g., MaxThreadsConstraint.DeferredRequests.
+func applicationSamples(now time.Time, app remy.Application) []Sample {
+	var samples []Sample
+	for _, wm := range app.WorkManagers {
+		samples = append(samples, Samples(now, ApplicationsResource, app.Name+"/"+wm.Name+"/"+wm.Server, "WorkManager.", wm)...)
+	}
+	for _, c := range app.MinThreadsConstraints {
+		samples = append(samples, Samples(now, ApplicationsResource, app.Name+"/"+c.Name+"/"+c.Server, "MinThreadsConstraint.", c)...)
+	}
+	for _, c := range app.MaxThreadsConstraints {
+		samples = append(samples, Samples(now, ApplicationsResource, app.Name+"/"+c.Name+"/"+c.Server, "MaxThreadsConstraint.", c)...)
+	}
+	for _, rc := range app.RequestClasses {
+		samples = append(samples, Samples(now, ApplicationsResource, app.Name+"/"+rc.Name+"/"+rc.Server, "RequestClass.", rc)...)
+	}
+	return samples
+}
+
+// Samples creates a Sample for every numeric (int, float or bool) field of the struct v, named for the field with
+// the given metric prefix.  Bools are recorded as 0 or 1.
+func Samples(now time.Time, resource, name, prefix string, v interface{}) []Sample {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var samples []Sample
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Field(i)
+		var value float64
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value = float64(f.Int())
+		case reflect.Float32, reflect.Float64:
+			value = f.Float()
+		case reflect.Bool:
+			if f.Bool() {
+				value = 1
+			}
+		default:
+			continue
+		}
+		samples = append(samples, Sample{Time: now, Resource: resource, Name: name, Metric: prefix + rv.Type().Field(i).Name, Value: value})
+	}
+	return samples
+}
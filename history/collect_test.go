@@ -0,0 +1,93 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamples(t *testing.T) {
+	now := time.Now()
+	samples := Samples(now, ServersResource, "ms1", "", remy.Server{Name: "ms1", HeapFreeCurrent: 42, JvmProcessorLoad: 0.5})
+	values := make(map[string]float64)
+	for _, s := range samples {
+		values[s.Metric] = s.Value
+	}
+	assert.Equal(t, float64(42), values["HeapFreeCurrent"])
+	assert.Equal(t, 0.5, values["JvmProcessorLoad"])
+	assert.NotContains(t, values, "Name")
+}
+
+func TestCollectOnce(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+
+	var polled *Poll
+	path := filepath.Join(t.TempDir(), "history.db")
+	c := &Collector{Client: remy.NewClient(fake.AdminServer(ts.URL)), Path: path, Retention: time.Hour}
+	c.OnPoll = func(ctx context.Context, p *Poll) { polled = p }
+	start := time.Now()
+	assert.NoError(t, c.CollectOnce(context.Background(), start))
+	assert.Equal(t, start, polled.Time)
+	assert.Len(t, polled.Servers, 3)
+	assert.Len(t, polled.Applications, 1)
+	fake.Update(func(d *remytest.Domain) {
+		d.Servers[1].HeapFreeCurrent = 1024
+	})
+	assert.NoError(t, c.CollectOnce(context.Background(), start.Add(time.Minute)))
+
+	s, err := OpenReadOnly(path)
+	assert.NoError(t, err, "the Store isn't held open between polls")
+	defer s.Close()
+	points, err := s.Series(ServersResource, "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, float64(1024), points[1].Value)
+
+	points, err = s.Series(DataSourcesResource, "ds1/ms2", "PrepStmtCacheHitCount", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+
+	points, err = s.Series(ClustersResource, "cluster1/ms1", "IsClusterMaster", start)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), points[0].Value)
+
+	metrics, err := s.Metrics(ApplicationsResource, "app1/default/ms1")
+	assert.NoError(t, err)
+	assert.Contains(t, metrics, "WorkManager.CompletedRequests")
+}
+
+func TestMaintain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	start := time.Now()
+	s, err := Open(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Write([]Sample{
+		{Time: start, Resource: ServersResource, Name: "ms1", Metric: "HeapFreeCurrent", Value: 1},
+		{Time: start.Add(2 * time.Hour), Resource: ServersResource, Name: "ms1", Metric: "HeapFreeCurrent", Value: 2},
+	}))
+	assert.NoError(t, s.Close())
+
+	c := &Collector{Path: path, Retention: time.Hour}
+	assert.NoError(t, c.Maintain(start.Add(90*time.Minute)))
+	s, err = OpenReadOnly(path)
+	assert.NoError(t, err)
+	defer s.Close()
+	points, err := s.Series(ServersResource, "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 1)
+	assert.Equal(t, float64(2), points[0].Value)
+}
+
+func TestRunRejectsInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		c := &Collector{Interval: interval}
+		assert.Error(t, c.Run(context.Background()))
+	}
+}
@@ -0,0 +1,250 @@
+// Package history keeps a time-series of the numeric run-time statistics remy collects from a domain, in a local
+// BoltDB file, so questions like "when did heap start climbing on ms3?" can be answered after the fact.
+//
+// Samples are stored in nested buckets: resource (servers, clusters, datasources, applications) => name (e.g., ms3, or
+// jdbc-ds/ms3 for a data source instance) => metric (e.g., HeapFreeCurrent) => time => value.
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Sample is a single value of a metric for a named resource at a point in time.
+type Sample struct {
+	Time     time.Time
+	Resource string
+	Name     string
+	Metric   string
+	Value    float64
+}
+
+// Point is a single value in a series returned by Store.Series.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Store is a BoltDB-backed time-series store of Samples.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens, or creates, the Store at path for writing.  BoltDB locks the file for as long as it is open, so writers
+// should Close the Store as soon as they are done, or readers wait for up to 5 seconds then give up.
+func Open(path string) (*Store, error) {
+	return open(path, &bolt.Options{Timeout: 5 * time.Second})
+}
+
+// OpenReadOnly opens the Store at path for reading, sharing the file with other readers.
+func OpenReadOnly(path string) (*Store, error) {
+	return open(path, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+}
+
+func open(path string, opts *bolt.Options) (*Store, error) {
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history store %v: %v", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the Store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Write saves samples in a single transaction.
+func (s *Store) Write(samples []Sample) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, sample := range samples {
+			b, err := seriesBucket(tx, sample.Resource, sample.Name, sample.Metric)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(timeKey(sample.Time), floatValue(sample.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Series returns the values of metric for the named resource since the given time, oldest first.
+func (s *Store) Series(resource, name, metric string, since time.Time) ([]Point, error) {
+	var points []Point
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := lookup(tx, resource, name, metric)
+		if b == nil {
+			return fmt.Errorf("no history of %v for %v %v", metric, resource, name)
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(timeKey(since)); k != nil; k, v = c.Next() {
+			points = append(points, Point{Time: keyTime(k), Value: valueFloat(v)})
+		}
+		return nil
+	})
+	return points, err
+}
+
+// Names returns the names with history for a resource, sorted.
+func (s *Store) Names(resource string) ([]string, error) {
+	return s.children(resource)
+}
+
+// Metrics returns the metrics with history for the named resource, sorted.
+func (s *Store) Metrics(resource, name string) ([]string, error) {
+	return s.children(resource, name)
+}
+
+func (s *Store) children(path ...string) ([]string, error) {
+	var names []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := lookup(tx, path...)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			if v == nil {
+				names = append(names, string(k))
+			}
+			return nil
+		})
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// Prune deletes every sample older than before.  This is how retention is applied.
+func (s *Store) Prune(before time.Time) error {
+	return s.eachSeries(func(b *bolt.Bucket) error {
+		c := b.Cursor()
+		end := timeKey(before)
+		for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Downsample replaces the samples older than before with their average over each step, timestamped at the start of
+// the step.  Only whole steps are downsampled, so the step before falls in is left until a later Downsample has all of
+// its samples.  Downsampling already downsampled samples leaves them unchanged.
+func (s *Store) Downsample(before time.Time, step time.Duration) error {
+	if step <= 0 {
+		return fmt.Errorf("downsample step must be positive, was %v", step)
+	}
+	return s.eachSeries(func(b *bolt.Bucket) error {
+		type bucket struct {
+			sum   float64
+			count int
+		}
+		steps := make(map[int64]*bucket)
+		var old [][]byte
+		c := b.Cursor()
+		end := timeKey(before.Truncate(step))
+		for k, v := c.First(); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+			t := keyTime(k).Truncate(step).UnixNano()
+			if steps[t] == nil {
+				steps[t] = &bucket{}
+			}
+			steps[t].sum += valueFloat(v)
+			steps[t].count++
+			old = append(old, append([]byte(nil), k...))
+		}
+		for _, k := range old {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		for t, avg := range steps {
+			if err := b.Put(timeKey(time.Unix(0, t)), floatValue(avg.sum/float64(avg.count))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// eachSeries calls fn with every metric bucket in the Store, in a single read-write transaction.  The buckets are
+// found before fn is called, as buckets must not be modified while they are iterated over.
+func (s *Store) eachSeries(fn func(b *bolt.Bucket) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var paths [][]string
+		err := tx.ForEach(func(resource []byte, rb *bolt.Bucket) error {
+			return rb.ForEach(func(name, v []byte) error {
+				nb := rb.Bucket(name)
+				if nb == nil {
+					return nil
+				}
+				return nb.ForEach(func(metric, v []byte) error {
+					if v == nil {
+						paths = append(paths, []string{string(resource), string(name), string(metric)})
+					}
+					return nil
+				})
+			})
+		})
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if err := fn(lookup(tx, path...)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func seriesBucket(tx *bolt.Tx, resource, name, metric string) (*bolt.Bucket, error) {
+	rb, err := tx.CreateBucketIfNotExists([]byte(resource))
+	if err != nil {
+		return nil, err
+	}
+	nb, err := rb.CreateBucketIfNotExists([]byte(name))
+	if err != nil {
+		return nil, err
+	}
+	return nb.CreateBucketIfNotExists([]byte(metric))
+}
+
+func lookup(tx *bolt.Tx, path ...string) *bolt.Bucket {
+	b := tx.Bucket([]byte(path[0]))
+	for _, p := range path[1:] {
+		if b == nil {
+			return nil
+		}
+		b = b.Bucket([]byte(p))
+	}
+	return b
+}
+
+// timeKey encodes t so keys sort in time order.
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	return k
+}
+
+func keyTime(k []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(k)))
+}
+
+func floatValue(f float64) []byte {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, math.Float64bits(f))
+	return v
+}
+
+func valueFloat(v []byte) float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(v))
+}
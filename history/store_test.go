@@ -0,0 +1,92 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestStore(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "remy-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(filepath.Join(dir, "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, func() {
+		s.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestWriteAndSeries(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	start := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	var samples []Sample
+	for i := 0; i < 10; i++ {
+		samples = append(samples, Sample{Time: start.Add(time.Duration(i) * time.Minute), Resource: "servers", Name: "ms3", Metric: "HeapFreeCurrent", Value: float64(100 - i)})
+	}
+	assert.NoError(t, s.Write(samples))
+
+	points, err := s.Series("servers", "ms3", "HeapFreeCurrent", start.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, points, 5)
+	assert.Equal(t, float64(95), points[0].Value)
+	assert.True(t, points[0].Time.Equal(start.Add(5*time.Minute)))
+
+	_, err = s.Series("servers", "ms3", "NoSuchMetric", start)
+	assert.Error(t, err)
+
+	names, err := s.Names("servers")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ms3"}, names)
+	metrics, err := s.Metrics("servers", "ms3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"HeapFreeCurrent"}, metrics)
+}
+
+func TestPruneAndDownsample(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	start := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	var samples []Sample
+	for i := 0; i < 20; i++ {
+		samples = append(samples, Sample{Time: start.Add(time.Duration(i) * time.Minute), Resource: "servers", Name: "ms1", Metric: "HeapFreeCurrent", Value: float64(i)})
+	}
+	assert.NoError(t, s.Write(samples))
+
+	assert.NoError(t, s.Prune(start.Add(5*time.Minute)))
+	points, err := s.Series("servers", "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 15)
+
+	// 12:05-12:09 averages to 7 at 12:05, 12:10-12:14 to 12 at 12:10; 12:15 onwards is untouched.
+	assert.NoError(t, s.Downsample(start.Add(15*time.Minute), 5*time.Minute))
+	assert.NoError(t, s.Downsample(start.Add(15*time.Minute), 5*time.Minute))
+	points, err = s.Series("servers", "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 7)
+	assert.Equal(t, float64(7), points[0].Value)
+	assert.Equal(t, float64(12), points[1].Value)
+	assert.Equal(t, float64(15), points[2].Value)
+
+	// 12:15-12:19 straddles 12:17, so it waits until all of it can be averaged.
+	assert.NoError(t, s.Downsample(start.Add(17*time.Minute), 5*time.Minute))
+	points, err = s.Series("servers", "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 7)
+	assert.NoError(t, s.Downsample(start.Add(20*time.Minute), 5*time.Minute))
+	points, err = s.Series("servers", "ms1", "HeapFreeCurrent", start)
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, float64(17), points[2].Value)
+}
@@ -0,0 +1,97 @@
+package remy
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs every request the Client makes to logger: the method, URL, status code, latency, response size,
+// number of retries and any time waited for the AdminServer's rate limit.  Successful requests are logged at
+// slog.LevelDebug, retries and failures at slog.LevelWarn.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithTraceHTTP adds the (redacted) request and response headers, and the full response body, to every request logged
+// by the logger given in WithLogger.
+func WithTraceHTTP(trace bool) ClientOption {
+	return func(c *Client) {
+		c.traceHTTP = trace
+	}
+}
+
+// notFoundExpectedKey is the context key of requests a 404 is an answer to rather than a failure, such as the probe of
+// VersionPath, so it isn't logged as a warning.
+type notFoundExpectedKey struct{}
+
+// logRequest logs the outcome of a request, after any retries.
+func (c *Client) logRequest(ctx context.Context, method, url string, resp *http.Response, body []byte, retries int, latency, waited time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", url),
+		slog.Duration("latency", latency),
+		slog.Int("retries", retries),
+	}
+	if waited > 0 {
+		attrs = append(attrs, slog.Duration("waited", waited))
+	}
+	level := slog.LevelDebug
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Int("size", len(body)))
+		expected := resp.StatusCode == http.StatusNotFound && ctx.Value(notFoundExpectedKey{}) != nil
+		if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !expected {
+			level = slog.LevelWarn
+		}
+		if c.traceHTTP {
+			if resp.Request != nil {
+				attrs = append(attrs, slog.Any("request_headers", redactHeader(resp.Request.Header)))
+			}
+			attrs = append(attrs, slog.Any("response_headers", redactHeader(resp.Header)), slog.String("response_body", string(body)))
+		}
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, level, "request", attrs...)
+}
+
+// logRetry logs a failed attempt that is about to be retried.
+func (c *Client) logRetry(ctx context.Context, method, url string, retry int, resp *http.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", url),
+		slog.Int("retry", retry),
+		slog.Duration("backoff", backoff(retry)),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "retrying request", attrs...)
+}
+
+func redactHeader(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for k, v := range h {
+		redacted[k] = append([]string(nil), v...)
+	}
+	for _, k := range credentialHeaders {
+		if redacted.Get(k) != "" {
+			redacted.Set(k, "REDACTED")
+		}
+	}
+	return redacted
+}
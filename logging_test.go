@@ -0,0 +1,76 @@
+package remy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRecordsRequests(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(&AdminServer{AdminURL: ts.URL, Username: "user", Password: "secretpass"},
+		WithLogger(logger), WithTraceHTTP(true), WithTree(TenantMonitoring))
+
+	_, err := client.Server(context.Background(), "adminserver")
+	assert.NoError(t, err)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "DEBUG", entry["level"])
+	assert.Equal(t, ts.URL+MonitorPath+"/servers/adminserver", entry["url"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.Equal(t, float64(len(singleServer)), entry["size"])
+	assert.Equal(t, float64(0), entry["retries"])
+	assert.Contains(t, entry["response_body"], "machine-0")
+	assert.NotContains(t, buf.String(), "dXNlcjpzZWNyZXRwYXNz", "Authorization header must be redacted")
+	assert.Contains(t, buf.String(), "REDACTED")
+}
+
+func TestRetries(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(serversJSON))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(2), WithLogger(logger), WithTree(TenantMonitoring))
+	servers, err := client.Servers(context.Background(), QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 2, strings.Count(buf.String(), "retrying request"))
+	assert.Contains(t, buf.String(), "retries=2")
+
+	attempts = -10
+	_, err = client.Servers(context.Background(), QueryOptions{})
+	assert.Error(t, err)
+}
+
+func TestLoggerQuietProbe(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	_, err := NewClient(&AdminServer{AdminURL: ts.URL}, WithLogger(logger)).Servers(context.Background(), QueryOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String(), "an AdminServer without VersionPath isn't a warning")
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Email sends every Event as a plain text email through an SMTP server at Addr (host:port), authenticating with
+// Username and Password when they are given.
+type Email struct {
+	Addr     string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+// Notify emails e, with its Title as the subject.
+func (m *Email) Notify(ctx context.Context, e Event) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	return smtp.SendMail(m.Addr, auth, m.From, m.To, m.message(e))
+}
+
+// message formats e as an RFC 5322 message.
+func (m *Email) message(e Event) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("From: %v\r\n", m.From))
+	buffer.WriteString(fmt.Sprintf("To: %v\r\n", strings.Join(m.To, ", ")))
+	buffer.WriteString(fmt.Sprintf("Subject: %v\r\n", e.Title()))
+	buffer.WriteString(fmt.Sprintf("Date: %v\r\n", e.Time.Format(time.RFC1123Z)))
+	buffer.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	buffer.WriteString(e.Message() + "\r\n\r\n")
+	buffer.WriteString(fmt.Sprintf("Rule:     %v\r\n", e.Rule))
+	buffer.WriteString(fmt.Sprintf("Severity: %v\r\n", e.Severity))
+	buffer.WriteString(fmt.Sprintf("Since:    %v\r\n", e.Since.Format(time.RFC3339)))
+	return buffer.Bytes()
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// smtpStandIn is a minimal SMTP server accepting a single message, sending what it received on the channel.
+func smtpStandIn(t *testing.T) (string, <-chan []string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan []string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var lines []string
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		data := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				received <- lines
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+			switch {
+			case data && line == ".":
+				data = false
+				reply("250 OK")
+			case data:
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case line == "DATA":
+				data = true
+				reply("354 go ahead")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return l.Addr().String(), received
+}
+
+func TestEmail(t *testing.T) {
+	addr, received := smtpStandIn(t)
+	n, err := NewNotifier(NotifierConfig{Type: EmailType, SMTP: addr, From: "remy@example.com", To: []string{"ops@example.com", "oncall@example.com"}})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	lines := <-received
+	assert.Contains(t, lines, "MAIL FROM:<remy@example.com>")
+	assert.Contains(t, lines, "RCPT TO:<oncall@example.com>")
+	assert.Contains(t, lines, "Subject: [FIRING] server-down: servers ms1")
+	assert.Contains(t, lines, "servers ms1 State is SHUTDOWN, expected RUNNING")
+
+	_, err = NewNotifier(NotifierConfig{Type: EmailType, SMTP: addr})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Types of notifier in a NotifierConfig.
+const (
+	WebhookType = "webhook"
+	SlackType   = "slack"
+	TeamsType   = "teams"
+	EmailType   = "email"
+	SyslogType  = "syslog"
+)
+
+// NotifierConfig is a [notify.notifiers.<name>] table of the configuration file.  Which settings apply depends on
+// its Type: webhook, slack and teams post to URL; email sends through the SMTP server From one address To others;
+// and syslog writes to Address over Network.
+type NotifierConfig struct {
+	Type    string            `mapstructure:"type"`
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+
+	SMTP     string   `mapstructure:"smtp"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+	Username string   `mapstructure:"username"`
+	Password string   `mapstructure:"password"`
+
+	Network string `mapstructure:"network"`
+	Address string `mapstructure:"address"`
+	Tag     string `mapstructure:"tag"`
+}
+
+// httpTimeout bounds each request made by the webhook notifiers.
+const httpTimeout = 10 * time.Second
+
+// NewNotifier creates the Notifier described by cfg.
+func NewNotifier(cfg NotifierConfig) (Notifier, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	switch cfg.Type {
+	case WebhookType, SlackType, TeamsType:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("no url to post to")
+		}
+	}
+	switch cfg.Type {
+	case WebhookType:
+		return &Webhook{URL: cfg.URL, Headers: cfg.Headers, Client: client}, nil
+	case SlackType:
+		return &Slack{URL: cfg.URL, Client: client}, nil
+	case TeamsType:
+		return &Teams{URL: cfg.URL, Client: client}, nil
+	case EmailType:
+		if cfg.SMTP == "" || cfg.From == "" || len(cfg.To) == 0 {
+			return nil, fmt.Errorf("smtp, from and to are required")
+		}
+		return &Email{Addr: cfg.SMTP, From: cfg.From, To: cfg.To, Username: cfg.Username, Password: cfg.Password}, nil
+	case SyslogType:
+		return &Syslog{Network: cfg.Network, Address: cfg.Address, Tag: cfg.Tag}, nil
+	}
+	return nil, fmt.Errorf("unknown type %q, expected webhook, slack, teams, email or syslog", cfg.Type)
+}
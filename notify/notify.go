@@ -0,0 +1,298 @@
+// Package notify evaluates rules against each poll of a domain and pushes the resulting alerts, state transitions and
+// threshold breaches, to webhooks, Slack and Teams incoming webhooks, SMTP email and syslog.  An alert is sent once
+// when a rule starts firing, again every re-notify interval while it keeps firing, and once more when it recovers.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauern/remy/analysis"
+)
+
+// Config is the [notify] table of the configuration file: the notifiers alerts can be sent to, and the rules that
+// raise them.
+type Config struct {
+	// Renotify is how often an alert that is still firing is sent again.  Zero sends it only once.
+	Renotify  time.Duration             `mapstructure:"renotify"`
+	Notifiers map[string]NotifierConfig `mapstructure:"notifiers"`
+	Rules     []Rule                    `mapstructure:"rules"`
+}
+
+// Rule raises an alert for every observed resource whose Field differs from Expect, or is Above or Below a
+// threshold.  Rules are evaluated against the resources named by Resource (servers, clusters, datasources or
+// applications), optionally only those whose name matches the Match glob, e.g., "ms*" or "ds1/*".
+type Rule struct {
+	Name     string            `mapstructure:"name"`
+	Resource string            `mapstructure:"resource"`
+	Match    string            `mapstructure:"match"`
+	Field    string            `mapstructure:"field"`
+	Expect   string            `mapstructure:"expect"`
+	Above    *float64          `mapstructure:"above"`
+	Below    *float64          `mapstructure:"below"`
+	Severity analysis.Severity `mapstructure:"severity"`
+	// Renotify overrides Config.Renotify for this rule.
+	Renotify time.Duration `mapstructure:"renotify"`
+	// Notifiers are the names of the notifiers the rule's alerts are routed to.  Blank routes to every notifier.
+	Notifiers []string `mapstructure:"notifiers"`
+	// NoRecovery stops a notice being sent when the rule stops firing.
+	NoRecovery bool `mapstructure:"norecovery"`
+}
+
+// Status is whether an Event is raising or clearing an alert.
+type Status string
+
+// Statuses of an Event.
+const (
+	Firing   Status = "firing"
+	Resolved Status = "resolved"
+)
+
+// Event is a notification that a rule started firing, is still firing, or has recovered for a resource.
+type Event struct {
+	Rule      string            `json:"rule"`
+	Severity  analysis.Severity `json:"severity"`
+	Status    Status            `json:"status"`
+	Repeat    bool              `json:"repeat,omitempty"`
+	Resource  string            `json:"resource"`
+	Name      string            `json:"name"`
+	Field     string            `json:"field"`
+	Value     string            `json:"value"`
+	Condition string            `json:"condition"`
+	Since     time.Time         `json:"since"`
+	Time      time.Time         `json:"time"`
+}
+
+// Title summarizes the Event in a line, e.g., "[FIRING] server-down: servers ms1".
+func (e Event) Title() string {
+	return fmt.Sprintf("[%v] %v: %v %v", strings.ToUpper(string(e.Status)), e.Rule, e.Resource, e.Name)
+}
+
+// Message describes the Event, e.g., "servers ms1 State is SHUTDOWN, expected RUNNING".
+func (e Event) Message() string {
+	if e.Status == Resolved {
+		return fmt.Sprintf("%v %v %v is %v, recovered after %v", e.Resource, e.Name, e.Field, e.Value, e.Time.Sub(e.Since).Round(time.Second))
+	}
+	msg := fmt.Sprintf("%v %v %v is %v, %v", e.Resource, e.Name, e.Field, e.Value, e.Condition)
+	if e.Repeat {
+		msg += fmt.Sprintf(", firing for %v", e.Time.Sub(e.Since).Round(time.Second))
+	}
+	return msg
+}
+
+// String formats the Event for the console and plain text notifications.
+func (e Event) String() string {
+	return fmt.Sprintf("[%v] %v %v: %v", strings.ToUpper(string(e.Status)), e.Severity, e.Rule, e.Message())
+}
+
+// Notifier sends an Event somewhere someone will see it.
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// alert is the state of a rule for one resource.
+type alert struct {
+	since    time.Time
+	notified time.Time
+}
+
+// Engine evaluates rules against observations, remembering which alerts are firing so each is only sent when it
+// starts, every re-notify interval, and when it recovers.
+type Engine struct {
+	// OnError is called with any error sending an Event.  The Event is not retried.
+	OnError func(error)
+
+	renotify  time.Duration
+	rules     []Rule
+	notifiers map[string]Notifier
+	names     []string
+	alerts    map[string]*alert
+}
+
+// New creates an Engine from cfg, creating each of its notifiers and checking its rules.
+func New(cfg Config) (*Engine, error) {
+	notifiers := make(map[string]Notifier)
+	for name, nc := range cfg.Notifiers {
+		n, err := NewNotifier(nc)
+		if err != nil {
+			return nil, fmt.Errorf("notifier %v: %v", name, err)
+		}
+		notifiers[name] = n
+	}
+	return NewEngine(cfg.Renotify, cfg.Rules, notifiers)
+}
+
+// NewEngine creates an Engine routing the alerts of rules to notifiers.
+func NewEngine(renotify time.Duration, rules []Rule, notifiers map[string]Notifier) (*Engine, error) {
+	e := &Engine{renotify: renotify, notifiers: notifiers, alerts: make(map[string]*alert)}
+	for name := range notifiers {
+		e.names = append(e.names, name)
+	}
+	sort.Strings(e.names)
+	for i, r := range rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule%d", i+1)
+		}
+		if err := e.check(r); err != nil {
+			return nil, fmt.Errorf("rule %v: %v", r.Name, err)
+		}
+		if r.Severity == "" {
+			r.Severity = analysis.Warning
+		}
+		e.rules = append(e.rules, r)
+	}
+	return e, nil
+}
+
+func (e *Engine) check(r Rule) error {
+	if !Resources[r.Resource] {
+		return fmt.Errorf("unknown resource %q, expected servers, clusters, datasources or applications", r.Resource)
+	}
+	if r.Field == "" {
+		return fmt.Errorf("no field to check")
+	}
+	thresholds := r.Above != nil || r.Below != nil
+	if (r.Expect == "") == !thresholds {
+		return fmt.Errorf("give either expect, or above and/or below")
+	}
+	if _, err := path.Match(r.Match, ""); err != nil {
+		return fmt.Errorf("invalid match %q: %v", r.Match, err)
+	}
+	switch r.Severity {
+	case "", analysis.Info, analysis.Warning, analysis.Critical:
+	default:
+		return fmt.Errorf("unknown severity %q, expected info, warning or critical", r.Severity)
+	}
+	for _, name := range r.Notifiers {
+		if e.notifiers[name] == nil {
+			return fmt.Errorf("no notifier named %v", name)
+		}
+	}
+	return nil
+}
+
+// Rules lists the rules the Engine evaluates, with their defaults filled in.
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Evaluate checks every rule against the observations made at now, sends the Events for alerts that started
+// firing, are due to be sent again, or recovered, and returns them.  Resources that aren't observed keep their alerts.
+func (e *Engine) Evaluate(ctx context.Context, now time.Time, observations []Observation) []Event {
+	var events []Event
+	for _, r := range e.rules {
+		for _, o := range observations {
+			if o.Resource != r.Resource {
+				continue
+			}
+			if matched, _ := path.Match(r.Match, o.Name); r.Match != "" && !matched {
+				continue
+			}
+			value, ok := o.Field(r.Field)
+			if !ok {
+				continue
+			}
+			ev, send := e.transition(r, o, value, now)
+			if send {
+				e.send(ctx, r, ev)
+				events = append(events, ev)
+			}
+		}
+	}
+	return events
+}
+
+// transition updates the alert of rule r for the observed resource, returning the Event and whether it is due to
+// be sent.
+func (e *Engine) transition(r Rule, o Observation, value string, now time.Time) (Event, bool) {
+	key := r.Name + "\x00" + o.Resource + "\x00" + o.Name
+	ev := Event{Rule: r.Name, Severity: r.Severity, Status: Firing, Resource: o.Resource, Name: o.Name, Field: r.Field,
+		Value: value, Condition: condition(r), Since: now, Time: now}
+	a := e.alerts[key]
+	switch {
+	case breached(r, value) && a == nil:
+		e.alerts[key] = &alert{since: now, notified: now}
+		return ev, true
+	case breached(r, value):
+		ev.Since, ev.Repeat = a.since, true
+		renotify := e.renotify
+		if r.Renotify > 0 {
+			renotify = r.Renotify
+		}
+		if renotify <= 0 || now.Sub(a.notified) < renotify {
+			return ev, false
+		}
+		a.notified = now
+		return ev, true
+	case a != nil:
+		delete(e.alerts, key)
+		ev.Status, ev.Since = Resolved, a.since
+		return ev, !r.NoRecovery
+	}
+	return ev, false
+}
+
+// breached reports whether value breaks rule r.  Values that aren't numbers never breach a threshold.
+func breached(r Rule, value string) bool {
+	if r.Expect != "" {
+		return value != r.Expect
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	return (r.Above != nil && v > *r.Above) || (r.Below != nil && v < *r.Below)
+}
+
+// condition describes what breaks rule r, e.g., "expected RUNNING" or "above 5".
+func condition(r Rule) string {
+	if r.Expect != "" {
+		return "expected " + r.Expect
+	}
+	var parts []string
+	if r.Above != nil {
+		parts = append(parts, "above "+strconv.FormatFloat(*r.Above, 'f', -1, 64))
+	}
+	if r.Below != nil {
+		parts = append(parts, "below "+strconv.FormatFloat(*r.Below, 'f', -1, 64))
+	}
+	return strings.Join(parts, " or ")
+}
+
+// send routes ev to the notifiers of rule r, or every notifier.
+func (e *Engine) send(ctx context.Context, r Rule, ev Event) {
+	names := r.Notifiers
+	if len(names) == 0 {
+		names = e.names
+	}
+	for _, name := range names {
+		if err := e.notifiers[name].Notify(ctx, ev); err != nil && e.OnError != nil {
+			e.OnError(fmt.Errorf("unable to notify %v: %v", name, err))
+		}
+	}
+}
+
+// Test sends a test Event to the named notifiers, or every notifier, returning the first error.
+func (e *Engine) Test(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		names = e.names
+	}
+	now := time.Now()
+	for _, name := range names {
+		n := e.notifiers[name]
+		if n == nil {
+			return fmt.Errorf("no notifier named %v", name)
+		}
+		ev := Event{Rule: "test", Severity: analysis.Info, Status: Firing, Resource: "notifier", Name: name, Field: "Status",
+			Value: "OK", Condition: "sent by remy notify test", Since: now, Time: now}
+		if err := n.Notify(ctx, ev); err != nil {
+			return fmt.Errorf("unable to notify %v: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy/analysis"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a Notifier remembering the Events it was sent.
+type recorder struct {
+	events []Event
+	err    error
+}
+
+func (r *recorder) Notify(ctx context.Context, e Event) error {
+	r.events = append(r.events, e)
+	return r.err
+}
+
+func server(name, state string) Observation {
+	return Observation{Resource: "servers", Name: name, Fields: map[string]string{"state": state, "openSocketsCurrentCount": "4"}}
+}
+
+func TestEvaluate(t *testing.T) {
+	ops := &recorder{}
+	rules := []Rule{{Name: "server-down", Resource: "servers", Match: "ms*", Field: "State", Expect: "RUNNING", Severity: analysis.Critical}}
+	e, err := NewEngine(time.Hour, rules, map[string]Notifier{"ops": ops})
+	assert.NoError(t, err)
+	ctx := context.Background()
+	start := time.Now()
+
+	assert.Empty(t, e.Evaluate(ctx, start, []Observation{server("ms1", "RUNNING"), server("AdminServer", "SHUTDOWN")}))
+
+	events := e.Evaluate(ctx, start.Add(time.Minute), []Observation{server("ms1", "SHUTDOWN")})
+	assert.Len(t, events, 1)
+	assert.Equal(t, "[FIRING] critical server-down: servers ms1 State is SHUTDOWN, expected RUNNING", events[0].String())
+
+	assert.Empty(t, e.Evaluate(ctx, start.Add(30*time.Minute), []Observation{server("ms1", "SHUTDOWN")}), "alerts should be deduplicated")
+	assert.Empty(t, e.Evaluate(ctx, start.Add(40*time.Minute), nil), "unobserved resources keep their alerts")
+
+	events = e.Evaluate(ctx, start.Add(61*time.Minute), []Observation{server("ms1", "FAILED")})
+	assert.Len(t, events, 1)
+	assert.True(t, events[0].Repeat)
+	assert.Equal(t, "servers ms1 State is FAILED, expected RUNNING, firing for 1h0m0s", events[0].Message())
+
+	events = e.Evaluate(ctx, start.Add(71*time.Minute), []Observation{server("ms1", "RUNNING")})
+	assert.Len(t, events, 1)
+	assert.Equal(t, Resolved, events[0].Status)
+	assert.Equal(t, "[RESOLVED] critical server-down: servers ms1 State is RUNNING, recovered after 1h10m0s", events[0].String())
+
+	assert.Len(t, ops.events, 3)
+}
+
+func TestEvaluateThresholds(t *testing.T) {
+	above, below := 10.0, 2.0
+	rules := []Rule{
+		{Name: "sockets", Resource: "servers", Field: "OpenSocketsCurrentCount", Above: &above, Below: &below, NoRecovery: true},
+	}
+	e, err := NewEngine(0, rules, map[string]Notifier{"ops": &recorder{}})
+	assert.NoError(t, err)
+	assert.Equal(t, analysis.Warning, e.Rules()[0].Severity)
+	ctx := context.Background()
+	now := time.Now()
+
+	o := server("ms1", "RUNNING")
+	assert.Empty(t, e.Evaluate(ctx, now, []Observation{o}))
+	o.Fields["opensocketscurrentcount"] = "11"
+	events := e.Evaluate(ctx, now, []Observation{o})
+	assert.Len(t, events, 1)
+	assert.Equal(t, "above 10 or below 2", events[0].Condition)
+	assert.Empty(t, e.Evaluate(ctx, now.Add(24*time.Hour), []Observation{o}), "renotify of 0 sends an alert once")
+	o.Fields["opensocketscurrentcount"] = "5"
+	assert.Empty(t, e.Evaluate(ctx, now, []Observation{o}), "norecovery should not send a recovery notice")
+	o.Fields["opensocketscurrentcount"] = "1"
+	assert.Len(t, e.Evaluate(ctx, now, []Observation{o}), 1)
+}
+
+func TestRouting(t *testing.T) {
+	ops, oncall := &recorder{}, &recorder{err: errors.New("unreachable")}
+	rules := []Rule{
+		{Name: "down", Resource: "servers", Field: "State", Expect: "RUNNING", Notifiers: []string{"oncall"}},
+		{Name: "all", Resource: "servers", Field: "State", Expect: "RUNNING", Renotify: time.Minute},
+	}
+	e, err := NewEngine(time.Hour, rules, map[string]Notifier{"ops": ops, "oncall": oncall})
+	assert.NoError(t, err)
+	var errs []error
+	e.OnError = func(err error) { errs = append(errs, err) }
+
+	now := time.Now()
+	assert.Len(t, e.Evaluate(context.Background(), now, []Observation{server("ms1", "SHUTDOWN")}), 2)
+	assert.Len(t, oncall.events, 2)
+	assert.Len(t, ops.events, 1)
+	assert.Equal(t, "all", ops.events[0].Rule)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "unable to notify oncall: unreachable")
+
+	events := e.Evaluate(context.Background(), now.Add(2*time.Minute), []Observation{server("ms1", "SHUTDOWN")})
+	assert.Len(t, events, 1, "a rule's renotify should override the default")
+	assert.Equal(t, "all", events[0].Rule)
+}
+
+func TestNewEngineErrors(t *testing.T) {
+	above := 1.0
+	notifiers := map[string]Notifier{"ops": &recorder{}}
+	for _, r := range []Rule{
+		{Resource: "machines", Field: "State", Expect: "RUNNING"},
+		{Resource: "servers", Expect: "RUNNING"},
+		{Resource: "servers", Field: "State"},
+		{Resource: "servers", Field: "State", Expect: "RUNNING", Above: &above},
+		{Resource: "servers", Field: "State", Expect: "RUNNING", Match: "["},
+		{Resource: "servers", Field: "State", Expect: "RUNNING", Severity: "page"},
+		{Resource: "servers", Field: "State", Expect: "RUNNING", Notifiers: []string{"pager"}},
+	} {
+		_, err := NewEngine(0, []Rule{r}, notifiers)
+		assert.Error(t, err, "%+v", r)
+	}
+	_, err := New(Config{Notifiers: map[string]NotifierConfig{"bad": {Type: "pager"}}})
+	assert.EqualError(t, err, `notifier bad: unknown type "pager", expected webhook, slack, teams, email or syslog`)
+}
+
+func TestTest(t *testing.T) {
+	ops := &recorder{}
+	e, err := NewEngine(0, nil, map[string]Notifier{"ops": ops})
+	assert.NoError(t, err)
+	assert.NoError(t, e.Test(context.Background()))
+	assert.Len(t, ops.events, 1)
+	assert.Equal(t, "ops", ops.events[0].Name)
+	assert.Error(t, e.Test(context.Background(), "pager"))
+}
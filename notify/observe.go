@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/history"
+)
+
+// Resources are the resources rules can be evaluated against, named as collect names them.
+var Resources = map[string]bool{
+	history.ServersResource:      true,
+	history.ClustersResource:     true,
+	history.DataSourcesResource:  true,
+	history.ApplicationsResource: true,
+}
+
+// Observation is the value of every field of a resource at one poll, formatted as a string.
+type Observation struct {
+	Resource string
+	Name     string
+	Fields   map[string]string
+}
+
+// Field returns the value of the named field, ignoring case, and whether the resource has it.
+func (o Observation) Field(name string) (string, bool) {
+	v, ok := o.Fields[strings.ToLower(name)]
+	return v, ok
+}
+
+// Observe makes an Observation of every resource in a poll, named as collect names their samples: servers by name,
+// datasource instances ds/server, cluster members cluster/server, and applications by name, with their work managers,
+// constraints and request classes app/item/server and fields prefixed by kind, e.g., MaxThreadsConstraint.DeferredRequests.
+func Observe(p *history.Poll) []Observation {
+	var observations []Observation
+	observe := func(resource, name, prefix string, v interface{}) {
+		observations = append(observations, Observation{Resource: resource, Name: name, Fields: fields(prefix, v)})
+	}
+	for _, s := range p.Servers {
+		observe(history.ServersResource, s.Name, "", s)
+	}
+	for _, c := range p.Clusters {
+		for _, m := range c.Servers {
+			observe(history.ClustersResource, c.Name+"/"+m.Name, "", m)
+		}
+	}
+	for _, ds := range p.DataSources {
+		for _, inst := range ds.Instances {
+			observe(history.DataSourcesResource, ds.Name+"/"+inst.Server, "", inst)
+		}
+	}
+	for _, app := range p.Applications {
+		observe(history.ApplicationsResource, app.Name, "", app)
+		observeApplication(app, observe)
+	}
+	return observations
+}
+
+// observeApplication observes the work managers, constraints and request classes of app.
+func observeApplication(app remy.Application, observe func(resource, name, prefix string, v interface{})) {
+	for _, wm := range app.WorkManagers {
+		observe(history.ApplicationsResource, app.Name+"/"+wm.Name+"/"+wm.Server, "WorkManager.", wm)
+	}
+	for _, c := range app.MinThreadsConstraints {
+		observe(history.ApplicationsResource, app.Name+"/"+c.Name+"/"+c.Server, "MinThreadsConstraint.", c)
+	}
+	for _, c := range app.MaxThreadsConstraints {
+		observe(history.ApplicationsResource, app.Name+"/"+c.Name+"/"+c.Server, "MaxThreadsConstraint.", c)
+	}
+	for _, rc := range app.RequestClasses {
+		observe(history.ApplicationsResource, app.Name+"/"+rc.Name+"/"+rc.Server, "RequestClass.", rc)
+	}
+}
+
+// fields formats every string, number and bool field of the struct v, keyed by its lower-cased name with the prefix.
+func fields(prefix string, v interface{}) map[string]string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	m := make(map[string]string)
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Field(i)
+		var value string
+		switch f.Kind() {
+		case reflect.String:
+			value = f.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value = strconv.FormatInt(f.Int(), 10)
+		case reflect.Float32, reflect.Float64:
+			value = strconv.FormatFloat(f.Float(), 'f', -1, 64)
+		case reflect.Bool:
+			value = strconv.FormatBool(f.Bool())
+		default:
+			continue
+		}
+		m[strings.ToLower(prefix+rv.Type().Field(i).Name)] = value
+	}
+	return m
+}
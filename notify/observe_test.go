@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/remy/history"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	d := remytest.DefaultDomain()
+	observations := Observe(&history.Poll{Time: time.Now(), Servers: d.Servers, Clusters: d.Clusters, DataSources: d.DataSources, Applications: d.Applications})
+
+	byName := make(map[string]Observation)
+	for _, o := range observations {
+		byName[o.Resource+" "+o.Name] = o
+	}
+	v, ok := byName["servers ms1"].Field("State")
+	assert.True(t, ok)
+	assert.Equal(t, "RUNNING", v)
+	v, _ = byName["servers ms1"].Field("jvmprocessorload")
+	assert.Equal(t, "0.1", v)
+	v, _ = byName["clusters cluster1/ms1"].Field("IsClusterMaster")
+	assert.Equal(t, "true", v)
+	v, _ = byName["datasources ds1/ms2"].Field("State")
+	assert.Equal(t, "Running", v)
+	v, _ = byName["applications app1"].Field("Health")
+	assert.Equal(t, "HEALTH_OK", v)
+	v, _ = byName["applications app1/default/ms1"].Field("WorkManager.CompletedRequests")
+	assert.Equal(t, "100", v)
+	_, ok = byName["applications app1"].Field("TargetStates")
+	assert.False(t, ok)
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/klauern/remy/analysis"
+)
+
+// Syslog writes every Event as an RFC 5424 message to a syslog server at Address over Network (udp or tcp), from the
+// local0 facility.  The defaults are udp, localhost:514 and the tag remy.
+type Syslog struct {
+	Network string
+	Address string
+	Tag     string
+}
+
+// local0 is the syslog facility messages are sent from.
+const local0 = 16
+
+// syslogSeverities are the syslog severities of each Severity, and of a resolved alert (notice).
+var syslogSeverities = map[analysis.Severity]int{
+	analysis.Critical: 2,
+	analysis.Warning:  4,
+	analysis.Info:     6,
+}
+
+// Notify writes e to the syslog server.
+func (s *Syslog) Notify(ctx context.Context, e Event) error {
+	network, address, tag := s.Network, s.Address, s.Tag
+	if network == "" {
+		network = "udp"
+	}
+	if address == "" {
+		address = "localhost:514"
+	}
+	if tag == "" {
+		tag = "remy"
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	msg := s.message(e, tag)
+	if network != "udp" {
+		// octet counting framing, RFC 6587
+		msg = fmt.Sprintf("%d %v", len(msg), msg)
+	}
+	_, err = conn.Write([]byte(msg))
+	return err
+}
+
+// message formats e as an RFC 5424 message.
+func (s *Syslog) message(e Event, tag string) string {
+	severity, ok := syslogSeverities[e.Severity]
+	if !ok {
+		severity = syslogSeverities[analysis.Warning]
+	}
+	if e.Status == Resolved {
+		severity = 5
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "-"
+	}
+	return fmt.Sprintf("<%d>1 %v %v %v - - - %v", local0*8+severity, e.Time.Format(time.RFC3339), host, tag, e.String())
+}
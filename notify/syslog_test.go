@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	n, err := NewNotifier(NotifierConfig{Type: SyslogType, Address: conn.LocalAddr().String(), Tag: "wls"})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	size, _, err := conn.ReadFrom(buf)
+	assert.NoError(t, err)
+	msg := string(buf[:size])
+	assert.True(t, strings.HasPrefix(msg, "<130>1 2017-11-01T12:00:00Z "), msg)
+	assert.Contains(t, msg, " wls - - - [FIRING] critical server-down: servers ms1 State is SHUTDOWN")
+
+	e := testEvent()
+	e.Status = Resolved
+	assert.True(t, strings.HasPrefix((&Syslog{}).message(e, "remy"), "<133>1 "))
+}
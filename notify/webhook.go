@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/klauern/remy/analysis"
+)
+
+// Webhook posts every Event as JSON to a URL, with any extra Headers, e.g., for authentication.
+type Webhook struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// Notify posts e as JSON.
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	return post(ctx, w.Client, w.URL, w.Headers, e)
+}
+
+// Slack posts every Event to a Slack-compatible incoming webhook, which Mattermost and Rocket.Chat accept too.
+type Slack struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify posts e as the text of a message.
+func (s *Slack) Notify(ctx context.Context, e Event) error {
+	return post(ctx, s.Client, s.URL, nil, map[string]string{"text": e.String()})
+}
+
+// Teams posts every Event to a Microsoft Teams incoming webhook as a message card, coloured by severity.
+type Teams struct {
+	URL    string
+	Client *http.Client
+}
+
+// teamsColors are the theme colours of a message card for each severity, and for a resolved alert.
+var teamsColors = map[analysis.Severity]string{
+	analysis.Info:     "0078D7",
+	analysis.Warning:  "FFA500",
+	analysis.Critical: "D13438",
+}
+
+// Notify posts e as a message card.
+func (t *Teams) Notify(ctx context.Context, e Event) error {
+	color := teamsColors[e.Severity]
+	if e.Status == Resolved {
+		color = "2EB886"
+	}
+	card := map[string]string{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    e.Title(),
+		"title":      e.Title(),
+		"text":       e.Message(),
+		"themeColor": color,
+	}
+	return post(ctx, t.Client, t.URL, nil, card)
+}
+
+// post sends body as JSON to url, failing unless the response is a 2xx.
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%v responded %v", url, resp.Status)
+	}
+	return nil
+}
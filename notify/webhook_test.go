@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy/analysis"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEvent() Event {
+	since := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	return Event{Rule: "server-down", Severity: analysis.Critical, Status: Firing, Resource: "servers", Name: "ms1",
+		Field: "State", Value: "SHUTDOWN", Condition: "expected RUNNING", Since: since, Time: since}
+}
+
+// standIn is a webhook receiving JSON posts, responding with status.
+func standIn(t *testing.T, status int, received *map[string]interface{}, headers *http.Header) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		if headers != nil {
+			*headers = r.Header
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		w.WriteHeader(status)
+	}))
+}
+
+func TestWebhook(t *testing.T) {
+	var body map[string]interface{}
+	var headers http.Header
+	ts := standIn(t, http.StatusNoContent, &body, &headers)
+	defer ts.Close()
+
+	n, err := NewNotifier(NotifierConfig{Type: WebhookType, URL: ts.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Equal(t, "server-down", body["rule"])
+	assert.Equal(t, "firing", body["status"])
+	assert.Equal(t, "SHUTDOWN", body["value"])
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+}
+
+func TestSlack(t *testing.T) {
+	var body map[string]interface{}
+	ts := standIn(t, http.StatusOK, &body, nil)
+	defer ts.Close()
+
+	n, err := NewNotifier(NotifierConfig{Type: SlackType, URL: ts.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Equal(t, "[FIRING] critical server-down: servers ms1 State is SHUTDOWN, expected RUNNING", body["text"])
+}
+
+func TestTeams(t *testing.T) {
+	var body map[string]interface{}
+	ts := standIn(t, http.StatusOK, &body, nil)
+	defer ts.Close()
+
+	n, err := NewNotifier(NotifierConfig{Type: TeamsType, URL: ts.URL})
+	assert.NoError(t, err)
+	e := testEvent()
+	assert.NoError(t, n.Notify(context.Background(), e))
+	assert.Equal(t, "MessageCard", body["@type"])
+	assert.Equal(t, "[FIRING] server-down: servers ms1", body["title"])
+	assert.Equal(t, "D13438", body["themeColor"])
+
+	e.Status = Resolved
+	assert.NoError(t, n.Notify(context.Background(), e))
+	assert.Equal(t, "2EB886", body["themeColor"])
+}
+
+func TestWebhookFailure(t *testing.T) {
+	var body map[string]interface{}
+	ts := standIn(t, http.StatusBadRequest, &body, nil)
+	defer ts.Close()
+
+	n, err := NewNotifier(NotifierConfig{Type: WebhookType, URL: ts.URL})
+	assert.NoError(t, err)
+	assert.Error(t, n.Notify(context.Background(), testEvent()))
+
+	_, err = NewNotifier(NotifierConfig{Type: SlackType})
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package remy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// Partition is a domain partition of a WebLogic 12.2.1 multi-tenant domain: a slice of the domain, with its own
+// resource groups, applications and data sources, that can be started and stopped on its own.  In full format, it has
+// the life cycle of each of its resource groups and the resources it is consuming on each server it is running on.
+type Partition struct {
+	Name           string
+	State          string
+	ResourceGroups []ResourceGroup   `json:"resourceGroups,omitempty"`
+	Servers        []PartitionServer `json:"servers,omitempty"`
+}
+
+// ResourceGroup is the life cycle State of one of a Partition's resource groups, e.g., RUNNING or SHUTDOWN.
+type ResourceGroup struct {
+	Name  string
+	State string
+}
+
+// PartitionServer is a Partition running on a server, with its State there and the resources it is consuming.
+type PartitionServer struct {
+	Server string
+	State  string
+	PartitionResources
+}
+
+// PartitionResources is the resource consumption of a Partition, as tracked by WebLogic's resource consumption
+// management.  CPUUtilization is the percentage of the server's CPU the Partition is using.
+type PartitionResources struct {
+	CPUUtilization         float64
+	CPUTimeNanos           int64
+	HeapAllocatedTotal     int64
+	ThreadCount            int
+	CurrentOpenFileCount   int
+	CurrentOpenSocketCount int
+}
+
+// Total adds up the resources the Partition is consuming on each of its servers.
+func (p *Partition) Total() PartitionResources {
+	var total PartitionResources
+	for _, s := range p.Servers {
+		total.CPUUtilization += s.CPUUtilization
+		total.CPUTimeNanos += s.CPUTimeNanos
+		total.HeapAllocatedTotal += s.HeapAllocatedTotal
+		total.ThreadCount += s.ThreadCount
+		total.CurrentOpenFileCount += s.CurrentOpenFileCount
+		total.CurrentOpenSocketCount += s.CurrentOpenSocketCount
+	}
+	return total
+}
+
+// GoString creates a GoString of the Partition type for use in command-line applications.  Resource groups and
+// servers are only displayed when the Partition has them, followed by the total across its servers.
+func (p *Partition) GoString() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Name: %-30v| State: %-14v\n", p.Name, p.State))
+	if len(p.ResourceGroups) > 0 {
+		buffer.WriteString("Resource Groups\n")
+		for _, rg := range p.ResourceGroups {
+			buffer.WriteString(fmt.Sprintf("\tName: %-30v| State: %-14v\n", rg.Name, rg.State))
+		}
+	}
+	if len(p.Servers) > 0 {
+		buffer.WriteString("Servers\n")
+		for _, s := range p.Servers {
+			buffer.WriteString(fmt.Sprintf("\tServer: %-14v| State: %-14v| %v\n", s.Server, s.State, s.PartitionResources.String()))
+		}
+		total := p.Total()
+		buffer.WriteString(fmt.Sprintf("\tTotal:  %-14v| %-21v| %v\n", "", "", total.String()))
+	}
+	return buffer.String()
+}
+
+// String formats the resources on a single line.
+func (r PartitionResources) String() string {
+	return fmt.Sprintf("CPU: %6.2f%% | Heap Allocated: %-12v| Threads: %-5v| Open Files: %-5v| Open Sockets: %-5v",
+		r.CPUUtilization, r.HeapAllocatedTotal, r.ThreadCount, r.CurrentOpenFileCount, r.CurrentOpenSocketCount)
+}
+
+// WithPartition scopes the Client's servers, data sources and applications to a domain partition: only the servers
+// the partition is running on, with the applications and data sources deployed to the partition in place of the
+// domain's.  Clusters aren't scoped.  Partitions are only in the REST tree of WebLogic 12.2.1 and later, so requests
+// fail against an AdminServer only serving tenant-monitoring.
+func WithPartition(name string) ClientOption {
+	return func(c *Client) {
+		c.partition = name
+	}
+}
+
+// Partitions returns every domain partition and its life cycle State.  The full format of opts adds the partition's
+// resource groups and the resources it is consuming on each server it is running on.
+func (c *Client) Partitions(ctx context.Context, opts QueryOptions) (list []Partition, err error) {
+	ctx, span := c.startSpan(ctx, "Partitions", "partitions", "")
+	defer func() { endSpan(span, err) }()
+	if opts, err = opts.resolve(Partition{}); err != nil {
+		return nil, err
+	}
+	defer func() { opts.apply(&list) }()
+	if err := c.requireWebLogicTree(ctx); err != nil {
+		return nil, err
+	}
+	return c.runtimePartitions(ctx, opts)
+}
+
+// Partition returns a single domain partition in full format.  See Partitions.
+func (c *Client) Partition(ctx context.Context, name string) (_ *Partition, err error) {
+	ctx, span := c.startSpan(ctx, "Partition", "partitions", name)
+	defer func() { endSpan(span, err) }()
+	if err := c.requireWebLogicTree(ctx); err != nil {
+		return nil, err
+	}
+	partitions, err := c.runtimePartitions(ctx, QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := range partitions {
+		if partitions[i].Name == name {
+			return &partitions[i], nil
+		}
+	}
+	return nil, notFound("partitions", name)
+}
+
+// Partitions returns every domain partition using a default Client.  See Client.Partitions.
+//
+// Deprecated: Partitions only chooses between the short and full format.  Use Client.Partitions, whose QueryOptions
+// also select fields and page through the partitions.
+func (a *AdminServer) Partitions(isFullFormat bool) ([]Partition, error) {
+	return NewClient(a).Partitions(context.Background(), QueryOptions{Full: isFullFormat})
+}
+
+// Partition returns a single domain partition using a default Client.  See Client.Partition.
+func (a *AdminServer) Partition(name string) (*Partition, error) {
+	return NewClient(a).Partition(context.Background(), name)
+}
+
+// requireWebLogicTree fails when the Client reads resources from tenant-monitoring, which has no partitions.
+func (c *Client) requireWebLogicTree(ctx context.Context) error {
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return err
+	}
+	if tree != WebLogic {
+		return c.partitionsUnsupported(tree)
+	}
+	return nil
+}
+
+// partitionsUnsupported is the error for asking about partitions of an AdminServer read from tree.
+func (c *Client) partitionsUnsupported(tree Tree) error {
+	return fmt.Errorf("partitions need the %v REST tree of WebLogic 12.2.1 or later, but %v is read from %v", WebLogic,
+		c.server.AdminURL, tree)
+}
@@ -0,0 +1,118 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var partitionsJSON = `{
+  "domainPartitionRuntimes": {"items": [
+    {"name": "p1", "partitionLifeCycleRuntime": {"state": "RUNNING", "resourceGroupLifeCycleRuntimes": {"items": [
+      {"name": "p1-rg", "state": "RUNNING"}
+    ]}}},
+    {"name": "p2", "partitionLifeCycleRuntime": {"state": "SHUTDOWN", "resourceGroupLifeCycleRuntimes": {"items": []}}}
+  ]},
+  "serverLifeCycleRuntimes": {"items": [
+    {"name": "AdminServer", "state": "RUNNING"},
+    {"name": "ms1", "state": "RUNNING"},
+    {"name": "ms2", "state": "RUNNING"}
+  ]},
+  "serverRuntimes": {"items": [
+    {"name": "AdminServer", "healthState": {"state": "ok"}, "partitionRuntimes": {"items": []}},
+    {
+      "name": "ms1",
+      "healthState": {"state": "ok"},
+      "JDBCServiceRuntime": {"JDBCDataSourceRuntimeMBeans": {"items": [{"name": "ds1", "state": "Running"}]}},
+      "applicationRuntimes": {"items": [{"name": "app1", "healthState": {"state": "ok"}}]},
+      "partitionRuntimes": {"items": [
+        {
+          "name": "p1",
+          "state": "RUNNING",
+          "partitionResourceMetricsRuntime": {"cpuUtilization": 2.5, "heapAllocatedTotal": 1024, "threadCount": 12, "currentOpenFileCount": 40},
+          "JDBCPartitionRuntime": {"JDBCDataSourceRuntimeMBeans": {"items": [{"name": "p1-ds1", "state": "Running", "enabled": true}]}},
+          "applicationRuntimes": {"items": [{"name": "p1-app1", "healthState": {"state": "warn"}}]}
+        }
+      ]}
+    },
+    {
+      "name": "ms2",
+      "healthState": {"state": "ok"},
+      "partitionRuntimes": {"items": [
+        {"name": "p1", "state": "ADMIN", "partitionResourceMetricsRuntime": {"cpuUtilization": 1.5, "heapAllocatedTotal": 2048, "threadCount": 3}}
+      ]}
+    }
+  ]}
+}`
+
+// newPartitionsClient is a Client for an AdminServer serving the WLS 12.2.1 REST trees of a domain with partitions.
+func newPartitionsClient(opts ...ClientOption) (*Client, func()) {
+	r := latestRouter("12.2.1.3.0", true)
+	r.HandleFunc(RuntimePath+"/search", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(partitionsJSON))
+	}).Methods("POST")
+	ts := httptest.NewServer(r)
+	return NewClient(&AdminServer{AdminURL: ts.URL}, opts...), ts.Close
+}
+
+func TestPartitions(t *testing.T) {
+	client, cleanup := newPartitionsClient()
+	defer cleanup()
+	ctx := context.Background()
+
+	partitions, err := client.Partitions(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Partition{{Name: "p1", State: "RUNNING"}, {Name: "p2", State: "SHUTDOWN"}}, partitions)
+
+	p1, err := client.Partition(ctx, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceGroup{{Name: "p1-rg", State: "RUNNING"}}, p1.ResourceGroups)
+	assert.Equal(t, []PartitionServer{
+		{Server: "ms1", State: "RUNNING", PartitionResources: PartitionResources{CPUUtilization: 2.5, HeapAllocatedTotal: 1024, ThreadCount: 12, CurrentOpenFileCount: 40}},
+		{Server: "ms2", State: "ADMIN", PartitionResources: PartitionResources{CPUUtilization: 1.5, HeapAllocatedTotal: 2048, ThreadCount: 3}},
+	}, p1.Servers)
+	assert.Equal(t, PartitionResources{CPUUtilization: 4, HeapAllocatedTotal: 3072, ThreadCount: 15, CurrentOpenFileCount: 40}, p1.Total())
+	assert.Contains(t, p1.GoString(), "p1-rg")
+
+	_, err = client.Partition(ctx, "p3")
+	assert.True(t, strings.HasPrefix(err.Error(), "Invalid Response Code: 404"))
+}
+
+func TestWithPartition(t *testing.T) {
+	client, cleanup := newPartitionsClient(WithPartition("p1"))
+	defer cleanup()
+	ctx := context.Background()
+
+	servers, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Server{{Name: "ms1", State: "RUNNING", Health: "HEALTH_OK"}, {Name: "ms2", State: "RUNNING", Health: "HEALTH_OK"}}, servers)
+
+	dataSources, err := client.DataSources(ctx, QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []DataSource{{Name: "p1-ds1", Instances: []DataSourceInstance{{Server: "ms1", State: "Running", Enabled: true}}}}, dataSources)
+
+	apps, err := client.Applications(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Application{{Name: "p1-app1", State: "STATE_ACTIVE", Health: "HEALTH_WARN"}}, apps)
+
+	client, cleanup = newPartitionsClient(WithPartition("p3"))
+	defer cleanup()
+	_, err = client.Servers(ctx, QueryOptions{})
+	assert.True(t, strings.HasPrefix(err.Error(), "Invalid Response Code: 404"))
+}
+
+func TestPartitionsNeedWebLogicTree(t *testing.T) {
+	client, cleanup := newPartitionsClient(WithTree(TenantMonitoring))
+	defer cleanup()
+	_, err := client.Partitions(context.Background(), QueryOptions{})
+	assert.Contains(t, err.Error(), "partitions need the weblogic REST tree")
+
+	client, cleanup = newPartitionsClient(WithTree(TenantMonitoring), WithPartition("p1"))
+	defer cleanup()
+	_, err = client.Servers(context.Background(), QueryOptions{})
+	assert.Contains(t, err.Error(), "partitions need the weblogic REST tree")
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// path is a field of a listed type, possibly nested through structs and slices of them, e.g., Servers.State of a
+// remy.Cluster.  A path through a slice has a value for each element.
+type path struct {
+	name  string
+	steps [][]int
+	leaf  reflect.Type
+}
+
+// resolve finds the field name of t.  Each dot-separated part of name is matched, ignoring case, against the Go and
+// JSON names of a field, or else the start of exactly one field's Go name, so cluster finds a remy.Server's
+// ClusterName.
+func resolve(t reflect.Type, name string) (*path, error) {
+	p := &path{}
+	var names []string
+	for _, part := range strings.Split(name, ".") {
+		t = elem(t)
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%v has no fields, so it has no %q", strings.Join(names, "."), part)
+		}
+		f, err := lookup(t, part)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, f.Name)
+		p.steps = append(p.steps, f.Index)
+		t = f.Type
+	}
+	p.name = strings.Join(names, ".")
+	p.leaf = elem(t)
+	return p, nil
+}
+
+// scalar resolves name, which must end at a string, bool or number.
+func scalar(t reflect.Type, name string) (*path, error) {
+	p, err := resolve(t, name)
+	if err != nil {
+		return nil, err
+	}
+	if !isScalar(p.leaf.Kind()) {
+		return nil, fmt.Errorf("%v is a %v, not a string, number or bool; use one of its fields, e.g., %v.Name", p.name,
+			p.leaf, p.name)
+	}
+	return p, nil
+}
+
+// lookup finds the field of the struct type t called name.
+func lookup(t reflect.Type, name string) (reflect.StructField, error) {
+	var prefixed []reflect.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if f.PkgPath != "" || f.Anonymous {
+			continue
+		}
+		if strings.EqualFold(f.Name, name) || strings.EqualFold(jsonName(f), name) {
+			return f, nil
+		}
+		if name != "" && strings.HasPrefix(strings.ToLower(f.Name), strings.ToLower(name)) {
+			prefixed = append(prefixed, f)
+		}
+	}
+	switch len(prefixed) {
+	case 0:
+		return reflect.StructField{}, fmt.Errorf("%v has no field %q", t.Name(), name)
+	case 1:
+		return prefixed[0], nil
+	}
+	var names []string
+	for _, f := range prefixed {
+		names = append(names, f.Name)
+	}
+	return reflect.StructField{}, fmt.Errorf("%q could be any of %v's %v", name, t.Name(), strings.Join(names, ", "))
+}
+
+// jsonName is the name of f in its json tag, if it has one.
+func jsonName(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("json"), ",")[0]
+}
+
+// elem is t without any pointers, slices or arrays around it.
+func elem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isScalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isNumber(k reflect.Kind) bool {
+	return isScalar(k) && k != reflect.String && k != reflect.Bool
+}
+
+// values are the values of the path in v: none when it passes through an empty slice or nil pointer, or one for each
+// element of the slices it passes through.
+func (p *path) values(v reflect.Value) []reflect.Value {
+	vals := flatten(v)
+	for _, step := range p.steps {
+		var next []reflect.Value
+		for _, v := range vals {
+			next = append(next, flatten(v.FieldByIndex(step))...)
+		}
+		vals = next
+	}
+	return vals
+}
+
+// flatten dereferences pointers and expands slices and arrays.
+func flatten(v reflect.Value) []reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return flatten(v.Elem())
+	case reflect.Slice, reflect.Array:
+		var vals []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			vals = append(vals, flatten(v.Index(i))...)
+		}
+		return vals
+	}
+	return []reflect.Value{v}
+}
+
+// number is the value of a numeric field as a float64.
+func number(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// compareValues orders two values of the same scalar kind, returning -1, 0 or 1.
+func compareValues(a, b reflect.Value) int {
+	switch {
+	case a.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case a.Kind() == reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	}
+	return compareNumbers(number(a), number(b))
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	}
+	return 1
+}
+
+func compareNumbers(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// format writes a scalar value for a table.
+func format(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
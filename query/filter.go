@@ -0,0 +1,376 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed filter expression, matching the items of a list whose fields meet it.  An expression compares
+// fields to values with ==, !=, <, <=, >, >=, =~ (matches a regular expression) or !~ (doesn't match one), combined
+// with && and ||, negated with !, and grouped with parentheses, e.g.:
+//
+//	state!=RUNNING && cluster==c1
+//	heapFreeCurrent < 100000000 || !(health == HEALTH_OK)
+//	name =~ '^ms[0-9]+$'
+//
+// A bool field on its own is the same as comparing it to true.  Values with spaces or operators in them are quoted
+// with ' or ".  Fields are found as described for Options.  A field through a slice, such as Servers.State of a
+// remy.Cluster, meets a comparison when any of its values does.  A Filter is not safe for concurrent use.
+type Filter struct {
+	expr  node
+	bound reflect.Type
+}
+
+// node is part of a parsed filter expression.
+type node interface {
+	// bind resolves the fields the node uses on the listed type t, and checks the values they are compared to.
+	bind(t reflect.Type) error
+	eval(v reflect.Value) bool
+}
+
+// ParseFilter parses a filter expression.  See Filter.
+func ParseFilter(filter string) (*Filter, error) {
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", filter, err)
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err == nil && p.peek().kind != tokEOF {
+		err = p.errorf("unexpected %q", p.peek().text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", filter, err)
+	}
+	return &Filter{expr: expr}, nil
+}
+
+// Match reports whether item, a struct or pointer to one, meets the Filter.  It fails when the Filter uses a field
+// item doesn't have, or compares a field to a value of a different type.
+func (f *Filter) Match(item interface{}) (bool, error) {
+	v := reflect.ValueOf(item)
+	if err := f.bind(v.Type()); err != nil {
+		return false, err
+	}
+	return f.expr.eval(v), nil
+}
+
+// bind resolves the Filter's fields on t, unless they already are.
+func (f *Filter) bind(t reflect.Type) error {
+	t = elem(t)
+	if f.bound == t {
+		return nil
+	}
+	if err := f.expr.bind(t); err != nil {
+		return err
+	}
+	f.bound = t
+	return nil
+}
+
+type and struct{ left, right node }
+
+func (n *and) bind(t reflect.Type) error {
+	if err := n.left.bind(t); err != nil {
+		return err
+	}
+	return n.right.bind(t)
+}
+
+func (n *and) eval(v reflect.Value) bool { return n.left.eval(v) && n.right.eval(v) }
+
+type or struct{ left, right node }
+
+func (n *or) bind(t reflect.Type) error {
+	if err := n.left.bind(t); err != nil {
+		return err
+	}
+	return n.right.bind(t)
+}
+
+func (n *or) eval(v reflect.Value) bool { return n.left.eval(v) || n.right.eval(v) }
+
+type not struct{ operand node }
+
+func (n *not) bind(t reflect.Type) error { return n.operand.bind(t) }
+
+func (n *not) eval(v reflect.Value) bool { return !n.operand.eval(v) }
+
+// comparison compares a field to a value.  A bare field is a comparison with no op.
+type comparison struct {
+	field string
+	op    string
+	value string
+
+	path   *path
+	str    string
+	num    float64
+	truth  bool
+	regexp *regexp.Regexp
+}
+
+func (n *comparison) bind(t reflect.Type) error {
+	p, err := scalar(t, n.field)
+	if err != nil {
+		return err
+	}
+	n.path = p
+	kind := p.leaf.Kind()
+	if n.op == "" {
+		if kind != reflect.Bool {
+			return fmt.Errorf("%v isn't true or false, so compare it to a value, e.g., %v==%v", p.name, n.field, example(kind))
+		}
+		n.truth = true
+		return nil
+	}
+	switch {
+	case kind == reflect.String:
+		n.str = n.value
+		if n.op == "=~" || n.op == "!~" {
+			if n.regexp, err = regexp.Compile(n.value); err != nil {
+				return fmt.Errorf("invalid regular expression for %v: %v", p.name, err)
+			}
+		}
+		return nil
+	case n.op == "=~" || n.op == "!~":
+		return fmt.Errorf("%v is a %v, so it can't be matched with %v", p.name, kind, n.op)
+	case kind == reflect.Bool:
+		if n.op != "==" && n.op != "!=" {
+			return fmt.Errorf("%v is true or false, so it can only be compared with == or !=", p.name)
+		}
+		if n.truth, err = strconv.ParseBool(n.value); err != nil {
+			return fmt.Errorf("%v is true or false, not %q", p.name, n.value)
+		}
+		return nil
+	}
+	if n.num, err = strconv.ParseFloat(n.value, 64); err != nil {
+		return fmt.Errorf("%v is a number, not %q", p.name, n.value)
+	}
+	return nil
+}
+
+func (n *comparison) eval(v reflect.Value) bool {
+	for _, val := range n.path.values(v) {
+		if n.test(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// test compares a single value of the field.
+func (n *comparison) test(v reflect.Value) bool {
+	var c int
+	switch {
+	case n.op == "":
+		return v.Bool()
+	case n.regexp != nil:
+		return n.regexp.MatchString(v.String()) == (n.op == "=~")
+	case v.Kind() == reflect.String:
+		c = strings.Compare(v.String(), n.str)
+	case v.Kind() == reflect.Bool:
+		c = compareBools(v.Bool(), n.truth)
+	default:
+		c = compareNumbers(number(v), n.num)
+	}
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// example is a value of the kind to show in an error.
+func example(kind reflect.Kind) string {
+	if kind == reflect.String {
+		return "value"
+	}
+	return "0"
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// ops are the comparison operators, longest first so <= isn't read as <.
+var ops = []string{"==", "!=", "<=", ">=", "=~", "!~", "<", ">"}
+
+// lex splits a filter expression into tokens.  Quoted strings become a tokWord without their quotes.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case strings.HasPrefix(s[i:], "&&"):
+			tokens = append(tokens, token{tokAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, token{tokOr, "||", i})
+			i += 2
+		case c == '\'' || c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokWord, b.String(), i})
+			i = j + 1
+		default:
+			if op := opAt(s[i:]); op != "" {
+				tokens = append(tokens, token{tokOp, op, i})
+				i += len(op)
+				continue
+			}
+			if c == '!' {
+				tokens = append(tokens, token{tokNot, "!", i})
+				i++
+				continue
+			}
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n()!<>=&|~'\"", rune(s[j])) {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("unexpected %q at %d", c, i)
+			}
+			tokens = append(tokens, token{tokWord, s[i:j], i})
+			i = j
+		}
+	}
+	return append(tokens, token{tokEOF, "end of filter", len(s)}), nil
+}
+
+// opAt is the comparison operator s starts with, if any.
+func opAt(s string) string {
+	for _, op := range ops {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parser is a recursive descent parser of filter expressions, where && binds more tightly than ||.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%v at %d", fmt.Sprintf(format, args...), p.peek().pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek().kind == tokOr {
+		p.next()
+		var right node
+		if right, err = p.parseAnd(); err == nil {
+			left = &or{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.peek().kind == tokAnd {
+		p.next()
+		var right node
+		if right, err = p.parseUnary(); err == nil {
+			left = &and{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek().kind {
+	case tokNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand}, nil
+	case tokLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.errorf("expected ) but found %q", p.peek().text)
+		}
+		p.next()
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	if p.peek().kind != tokWord {
+		return nil, p.errorf("expected a field but found %q", p.peek().text)
+	}
+	n := &comparison{field: p.next().text}
+	if p.peek().kind != tokOp {
+		return n, nil
+	}
+	n.op = p.next().text
+	if p.peek().kind != tokWord {
+		return nil, p.errorf("expected a value to compare %v to but found %q", n.field, p.peek().text)
+	}
+	n.value = p.next().text
+	return n, nil
+}
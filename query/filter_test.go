@@ -0,0 +1,99 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+var ms1 = remy.Server{Name: "ms1", State: "RUNNING", Health: "HEALTH_OK", ClusterName: "c1", HeapFreeCurrent: 100, JvmProcessorLoad: 0.5}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{"state==RUNNING", true},
+		{"state!=RUNNING && cluster==c1", false},
+		{"state==RUNNING && cluster==c1", true},
+		{"State == 'RUNNING' || name == ms2", true},
+		{"heapFreeCurrent < 200 && jvmProcessorLoad >= 0.5", true},
+		{"heapfree > 100", false},
+		{"!(health == HEALTH_OK)", false},
+		{"name =~ '^ms[0-9]+$'", true},
+		{"name !~ \"^ms\"", false},
+		{"clusterName == c2 || state == SHUTDOWN || health == HEALTH_OK", true},
+		{"(clusterName == c2 || state == SHUTDOWN) && health == HEALTH_OK", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			assert.NoError(t, err)
+			match, err := f.Match(ms1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.match, match)
+		})
+	}
+}
+
+func TestFilterSlices(t *testing.T) {
+	cluster := remy.Cluster{Name: "c1", Servers: []remy.ClusterServer{
+		{Name: "ms1", State: "RUNNING", IsClusterMaster: true},
+		{Name: "ms2", State: "SHUTDOWN"},
+	}}
+	for filter, want := range map[string]bool{
+		"servers.state != RUNNING":          true,
+		"servers.state == FAILED":           false,
+		"servers.isClusterMaster":           true,
+		"servers.clusterMaster == false":    true,
+		"!servers.isClusterMaster":          false,
+		"servers.fragmentsSentCount > 0":    false,
+		"servers.name == ms2 && name == c1": true,
+	} {
+		f, err := ParseFilter(filter)
+		assert.NoError(t, err)
+		match, err := f.Match(&cluster)
+		assert.NoError(t, err, filter)
+		assert.Equal(t, want, match, filter)
+	}
+
+	f, _ := ParseFilter("servers.state == RUNNING")
+	match, err := f.Match(remy.Cluster{Name: "empty"})
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestFilterErrors(t *testing.T) {
+	for filter, want := range map[string]string{
+		"state = RUNNING":       `unexpected '='`,
+		"state == 'RUNNING":     "unterminated string",
+		"(state == RUNNING":     "expected ) but found",
+		"state == RUNNING &&":   "expected a field",
+		"state ==":              "expected a value to compare state to",
+		"state == RUNNING name": `unexpected "name"`,
+		"&& state == RUNNING":   "expected a field",
+		"state == RUNNING )":    `unexpected ")"`,
+	} {
+		_, err := ParseFilter(filter)
+		if assert.Error(t, err, filter) {
+			assert.Contains(t, err.Error(), want, filter)
+		}
+	}
+
+	for filter, want := range map[string]string{
+		"status == RUNNING":     `Server has no field "status"`,
+		"o == Linux":            `"o" could be any of Server's OpenSocketsCurrentCount, OsName, OsVersion`,
+		"heapFreeCurrent == 1G": `HeapFreeCurrent is a number, not "1G"`,
+		"state":                 "State isn't true or false",
+		"heapFreeCurrent =~ 1":  "can't be matched with =~",
+		"name =~ '['":           "invalid regular expression for Name",
+	} {
+		f, err := ParseFilter(filter)
+		assert.NoError(t, err)
+		_, err = f.Match(ms1)
+		if assert.Error(t, err, filter) {
+			assert.Contains(t, err.Error(), want, filter)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package query filters, sorts, limits and tabulates lists of remy resources, such as the []remy.Server returned by
+// Client.Servers, on the client side.  It works on any slice of structs by reflection, naming fields as in the
+// structs or their JSON, so the same Options apply to servers, clusters, data sources, applications and partitions.
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Options are how to narrow and order a list of resources.  Fields are named by their Go or JSON names, ignoring
+// case, or by the start of a single field's name, so cluster is a remy.Server's ClusterName.  Fields of nested
+// structs and slices are dot-separated, e.g., Servers.State of a remy.Cluster.
+type Options struct {
+	// Filter keeps only the items meeting a filter expression.  See Filter.
+	Filter string
+	// SortBy orders the items by each field in turn, ascending, or descending when the field starts with -.  A field
+	// through a slice sorts by its first value.
+	SortBy []string
+	// Columns are the fields NewTable tabulates.
+	Columns []string
+	// Offset skips the first Offset items, after filtering and sorting.
+	Offset int
+	// Limit keeps only the first Limit items, after filtering, sorting and Offset.  Zero keeps them all.
+	Limit int
+}
+
+// Apply filters, sorts and limits the slice of structs list points to, in place.  It fails without changing the
+// list when any Options name a field the structs don't have.
+func Apply(list interface{}, opts Options) error {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("query: Apply needs a pointer to a slice, not %T", list)
+	}
+	items := v.Elem()
+	t := elem(items.Type())
+
+	var filter *Filter
+	if opts.Filter != "" {
+		var err error
+		if filter, err = ParseFilter(opts.Filter); err != nil {
+			return err
+		}
+		if err := filter.bind(t); err != nil {
+			return err
+		}
+	}
+	keys, err := sortKeys(t, opts.SortBy)
+	if err != nil {
+		return err
+	}
+	if _, err := columns(t, opts.Columns); err != nil {
+		return err
+	}
+
+	kept := reflect.MakeSlice(items.Type(), 0, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		if filter == nil || filter.expr.eval(items.Index(i)) {
+			kept = reflect.Append(kept, items.Index(i))
+		}
+	}
+	if len(keys) > 0 {
+		sort.SliceStable(kept.Interface(), func(i, j int) bool {
+			return less(keys, kept.Index(i), kept.Index(j))
+		})
+	}
+	if opts.Offset > 0 {
+		offset := opts.Offset
+		if offset > kept.Len() {
+			offset = kept.Len()
+		}
+		kept = kept.Slice(offset, kept.Len())
+	}
+	if opts.Limit > 0 && kept.Len() > opts.Limit {
+		kept = kept.Slice(0, opts.Limit)
+	}
+	items.Set(kept)
+	return nil
+}
+
+// sortKey is a field to sort by.
+type sortKey struct {
+	path       *path
+	descending bool
+}
+
+func sortKeys(t reflect.Type, fields []string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, field := range fields {
+		key := sortKey{descending: strings.HasPrefix(field, "-")}
+		p, err := scalar(t, strings.TrimLeft(field, "+-"))
+		if err != nil {
+			return nil, err
+		}
+		key.path = p
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// less orders a before b by the first key they differ on.  Items without a value for a key, such as a cluster with
+// no servers, come first.
+func less(keys []sortKey, a, b reflect.Value) bool {
+	for _, key := range keys {
+		av, bv := key.path.values(a), key.path.values(b)
+		var c int
+		switch {
+		case len(av) == 0 && len(bv) == 0:
+			continue
+		case len(av) == 0:
+			c = -1
+		case len(bv) == 0:
+			c = 1
+		default:
+			c = compareValues(av[0], bv[0])
+		}
+		if key.descending {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+// Table is a list of resources tabulated by the Columns of Options, with a row of values for each item.  A column
+// through a slice has its values comma-separated.
+type Table struct {
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// NewTable tabulates the fields named by columnNames of each item in list, a slice of structs.
+func NewTable(list interface{}, columnNames []string) (*Table, error) {
+	items := reflect.ValueOf(list)
+	if items.Kind() == reflect.Ptr {
+		items = items.Elem()
+	}
+	if items.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("query: NewTable needs a slice, not %T", list)
+	}
+	paths, err := columns(elem(items.Type()), columnNames)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{Rows: [][]string{}}
+	for _, p := range paths {
+		table.Headers = append(table.Headers, p.name)
+	}
+	for i := 0; i < items.Len(); i++ {
+		row := make([]string, len(paths))
+		for j, p := range paths {
+			var vals []string
+			for _, v := range p.values(items.Index(i)) {
+				vals = append(vals, format(v))
+			}
+			row[j] = strings.Join(vals, ",")
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+func columns(t reflect.Type, names []string) ([]*path, error) {
+	var paths []*path
+	for _, name := range names {
+		p, err := scalar(t, name)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// String formats the Table in aligned columns, headed by the upper-cased field names.
+func (t *Table) String() string {
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.Headers, "\t"))+"\t")
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t")+"\t")
+	}
+	tw.Flush()
+	return buffer.String()
+}
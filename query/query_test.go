@@ -0,0 +1,97 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func servers() []remy.Server {
+	return []remy.Server{
+		{Name: "AdminServer", State: "RUNNING", HeapFreeCurrent: 300},
+		{Name: "ms1", State: "RUNNING", ClusterName: "c1", HeapFreeCurrent: 100},
+		{Name: "ms2", State: "SHUTDOWN", ClusterName: "c1"},
+		{Name: "ms3", State: "RUNNING", ClusterName: "c2", HeapFreeCurrent: 200},
+	}
+}
+
+func names(servers []remy.Server) []string {
+	var names []string
+	for _, s := range servers {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"none", Options{}, []string{"AdminServer", "ms1", "ms2", "ms3"}},
+		{"filter", Options{Filter: "state==RUNNING && cluster!=''"}, []string{"ms1", "ms3"}},
+		{"sort", Options{SortBy: []string{"HeapFreeCurrent"}}, []string{"ms2", "ms1", "ms3", "AdminServer"}},
+		{"sort descending", Options{SortBy: []string{"-heapFreeCurrent"}}, []string{"AdminServer", "ms3", "ms1", "ms2"}},
+		{"sort by two fields", Options{SortBy: []string{"cluster", "-name"}}, []string{"AdminServer", "ms2", "ms1", "ms3"}},
+		{"limit", Options{SortBy: []string{"-heapFree"}, Limit: 2}, []string{"AdminServer", "ms3"}},
+		{"limit above length", Options{Limit: 10}, []string{"AdminServer", "ms1", "ms2", "ms3"}},
+		{"offset", Options{SortBy: []string{"-heapFree"}, Offset: 1, Limit: 2}, []string{"ms3", "ms1"}},
+		{"offset above length", Options{Offset: 10}, nil},
+		{"filter all", Options{Filter: "state==FAILED"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := servers()
+			assert.NoError(t, Apply(&list, tt.opts))
+			assert.Equal(t, tt.want, names(list))
+		})
+	}
+}
+
+func TestApplySortsSlicesByFirstValue(t *testing.T) {
+	clusters := []remy.Cluster{
+		{Name: "c1", Servers: []remy.ClusterServer{{Name: "ms2"}, {Name: "ms1"}}},
+		{Name: "c2"},
+		{Name: "c3", Servers: []remy.ClusterServer{{Name: "ms0"}}},
+	}
+	assert.NoError(t, Apply(&clusters, Options{SortBy: []string{"servers.name"}}))
+	assert.Equal(t, []string{"c2", "c3", "c1"}, []string{clusters[0].Name, clusters[1].Name, clusters[2].Name})
+}
+
+func TestApplyErrors(t *testing.T) {
+	list := servers()
+	assert.EqualError(t, Apply(list, Options{}), "query: Apply needs a pointer to a slice, not []remy.Server")
+	assert.EqualError(t, Apply(&list, Options{SortBy: []string{"bogus"}}), `Server has no field "bogus"`)
+	assert.EqualError(t, Apply(&list, Options{Columns: []string{"name", "bogus"}}), `Server has no field "bogus"`)
+	assert.Error(t, Apply(&list, Options{Filter: "state =="}))
+	assert.Len(t, list, 4)
+
+	clusters := []remy.Cluster{}
+	assert.EqualError(t, Apply(&clusters, Options{SortBy: []string{"servers"}}),
+		"Servers is a remy.ClusterServer, not a string, number or bool; use one of its fields, e.g., Servers.Name")
+}
+
+func TestTable(t *testing.T) {
+	clusters := []remy.Cluster{
+		{Name: "c1", Servers: []remy.ClusterServer{{Name: "ms1", State: "RUNNING"}, {Name: "ms2", State: "SHUTDOWN"}}},
+		{Name: "c2"},
+	}
+	table, err := NewTable(clusters, []string{"name", "servers.name", "servers.state"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Servers.Name", "Servers.State"}, table.Headers)
+	assert.Equal(t, [][]string{{"c1", "ms1,ms2", "RUNNING,SHUTDOWN"}, {"c2", "", ""}}, table.Rows)
+	assert.Equal(t, ""+
+		"NAME  SERVERS.NAME  SERVERS.STATE     \n"+
+		"c1    ms1,ms2       RUNNING,SHUTDOWN  \n"+
+		"c2                                    \n", table.String())
+
+	partitions := []remy.Partition{{Name: "p1", Servers: []remy.PartitionServer{{Server: "ms1", PartitionResources: remy.PartitionResources{CPUUtilization: 2.5}}}}}
+	table, err = NewTable(&partitions, []string{"name", "servers.cpuUtilization"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"p1", "2.5"}}, table.Rows)
+
+	_, err = NewTable(clusters[0], []string{"name"})
+	assert.EqualError(t, err, "query: NewTable needs a slice, not remy.Cluster")
+}
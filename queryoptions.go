@@ -0,0 +1,164 @@
+package remy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// QueryOptions shape a list of resources requested from an AdminServer.  The zero QueryOptions lists every resource in
+// the short format.
+//
+// Against the REST tree of WebLogic 12.2.1 and later, fields left out with Fields or ExcludeFields aren't requested at
+// all, so leaving out, e.g., an Application's WorkManagers, constraints and request classes, or a DataSource's
+// Instances, makes full-format lists much lighter.  tenant-monitoring always answers with the whole format, so there
+// the Client leaves the fields out itself.  Neither tree pages lists, so the Client applies Offset and Limit after
+// each list is read.  Links between resources are never requested, as none of the resource types have them.
+type QueryOptions struct {
+	// Full requests the full format, with every run-time statistic, rather than only each resource's name, state and
+	// health.
+	Full bool
+	// Fields, when set, keeps only these fields of each resource, named as in its struct or JSON, ignoring case, e.g.,
+	// HeapFreeCurrent or clusterName.  Name is always kept.
+	Fields []string
+	// ExcludeFields leaves these fields out of each resource.
+	ExcludeFields []string
+	// Offset skips the first resources, in the order the AdminServer lists them.
+	Offset int
+	// Limit keeps at most Limit resources after Offset.  Zero keeps them all.
+	Limit int
+}
+
+// resolve checks the fields of o are fields of resource, returning a copy of o with their names as in its struct.
+func (o QueryOptions) resolve(resource interface{}) (QueryOptions, error) {
+	t := reflect.TypeOf(resource)
+	var err error
+	if o.Fields, err = fieldNames(t, o.Fields); err != nil {
+		return o, err
+	}
+	if o.ExcludeFields, err = fieldNames(t, o.ExcludeFields); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+func fieldNames(t reflect.Type, names []string) ([]string, error) {
+	var resolved []string
+	for _, name := range names {
+		f, ok := t.FieldByNameFunc(func(field string) bool {
+			if strings.EqualFold(field, name) {
+				return true
+			}
+			sf, _ := t.FieldByName(field)
+			return strings.EqualFold(strings.Split(sf.Tag.Get("json"), ",")[0], name)
+		})
+		if !ok {
+			return nil, fmt.Errorf("%v has no field %q", t.Name(), name)
+		}
+		resolved = append(resolved, f.Name)
+	}
+	return resolved, nil
+}
+
+// keeps reports whether the field, named as in its struct, is wanted.
+func (o QueryOptions) keeps(field string) bool {
+	if field == "Name" {
+		return true
+	}
+	return (len(o.Fields) == 0 || contains(o.Fields, field)) && !contains(o.ExcludeFields, field)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// apply leaves the unwanted fields out of the resources in the slice list points to, then pages it.
+func (o QueryOptions) apply(list interface{}) {
+	items := reflect.ValueOf(list).Elem()
+	t := items.Type().Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if o.keeps(t.Field(i).Name) {
+			continue
+		}
+		for j := 0; j < items.Len(); j++ {
+			f := items.Index(j).Field(i)
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+
+	start, end := o.Offset, items.Len()
+	if start < 0 {
+		start = 0
+	}
+	if start > end {
+		start = end
+	}
+	if o.Limit > 0 && start+o.Limit < end {
+		end = start + o.Limit
+	}
+	if start > 0 || end < items.Len() {
+		items.Set(items.Slice(start, end))
+	}
+}
+
+// beans maps the fields of a resource to the beans and bean fields of a search they are read from, as dot-separated
+// paths through its children.  A path ending in * narrows the bean to its name.
+type beans map[string][]string
+
+// search narrows a search of a REST tree to the beans and bean fields of the wanted fields.  short are the fields of
+// the short format, the only ones wanted unless o.Full.
+func (o QueryOptions) search(search string, read beans, short ...string) string {
+	var tree map[string]interface{}
+	if err := json.Unmarshal([]byte(search), &tree); err != nil {
+		panic(fmt.Sprintf("invalid search %v: %v", search, err))
+	}
+	for field, paths := range read {
+		if o.keeps(field) && (o.Full || contains(short, field)) {
+			continue
+		}
+		for _, p := range paths {
+			drop(tree, strings.Split(p, "."))
+		}
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		panic(fmt.Sprintf("invalid search %v: %v", search, err))
+	}
+	return string(data)
+}
+
+// drop removes the child bean or field at path from a search.
+func drop(search map[string]interface{}, path []string) {
+	for len(path) > 1 {
+		children, _ := search["children"].(map[string]interface{})
+		child, ok := children[path[0]].(map[string]interface{})
+		if !ok {
+			return
+		}
+		if path[1] == "*" {
+			child["fields"] = []string{"name"}
+			return
+		}
+		search, path = child, path[1:]
+	}
+	if children, ok := search["children"].(map[string]interface{}); ok {
+		if _, ok := children[path[0]]; ok {
+			delete(children, path[0])
+			return
+		}
+	}
+	fields, _ := search["fields"].([]interface{})
+	kept := []interface{}{}
+	for _, f := range fields {
+		if f != path[0] {
+			kept = append(kept, f)
+		}
+	}
+	search["fields"] = kept
+}
@@ -0,0 +1,72 @@
+package remy
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryOptionsFields(t *testing.T) {
+	client, searches, cleanup := newRuntimeClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	servers, err := client.Servers(ctx, QueryOptions{Full: true, Fields: []string{"heapFreeCurrent", "clusterName"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Server{
+		{Name: "AdminServer", HeapFreeCurrent: 268435456},
+		{Name: "ms1", ClusterName: "cluster1", HeapFreeCurrent: 536870912},
+		{Name: "ms2"},
+	}, servers)
+	assert.Contains(t, (*searches)[0], "heapFreeCurrent")
+	assert.Contains(t, (*searches)[0], "clusterRuntime")
+	assert.NotContains(t, (*searches)[0], "javaVersion")
+	assert.NotContains(t, (*searches)[0], "healthState")
+
+	apps, err := client.Applications(ctx, QueryOptions{Full: true, ExcludeFields: []string{"workManagers", "RequestClasses"}})
+	assert.NoError(t, err)
+	assert.Nil(t, apps[0].WorkManagers)
+	assert.Len(t, apps[0].MaxThreadsConstraints, 1)
+	assert.NotContains(t, (*searches)[1], "workManagerRuntimes")
+	assert.NotContains(t, (*searches)[1], "requestClassRuntimes")
+	assert.Contains(t, (*searches)[1], "maxThreadsConstraintRuntimes")
+
+	_, err = client.Servers(ctx, QueryOptions{Fields: []string{"heap"}})
+	assert.EqualError(t, err, `Server has no field "heap"`)
+	assert.Len(t, *searches, 2)
+}
+
+func TestQueryOptionsShortFormatSearches(t *testing.T) {
+	short := QueryOptions{}
+	assert.Contains(t, short.search(serversSearch, serverBeans, "Health"), `"JVMRuntime":{"fields":[],"links":[]}`)
+	assert.Contains(t, short.search(serversSearch, serverBeans, "Health"), "healthState")
+	assert.Contains(t, short.search(dataSourcesSearch, dataSourceBeans), `"JDBCDataSourceRuntimeMBeans":{"fields":["name"]`)
+	assert.NotContains(t, short.search(applicationsSearch, applicationBeans, "Health"), "workManagerRuntimes")
+	assert.Contains(t, QueryOptions{Full: true}.search(applicationsSearch, applicationBeans, "Health"), "workManagerRuntimes")
+}
+
+func TestQueryOptionsPages(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+	ctx := context.Background()
+
+	servers, err := client.Servers(ctx, QueryOptions{Offset: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []Server{{Name: "ms1", State: "SHUTDOWN"}}, servers)
+
+	servers, err = client.Servers(ctx, QueryOptions{Limit: 1, ExcludeFields: []string{"state"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Server{{Name: "adminserver", Health: " HEALTH_OK "}}, servers)
+
+	servers, err = client.Servers(ctx, QueryOptions{Offset: 5})
+	assert.NoError(t, err)
+	assert.Empty(t, servers)
+
+	servers, err = client.Servers(ctx, QueryOptions{Offset: -1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "adminserver", servers[0].Name, "a negative offset starts at the first")
+	assert.Len(t, servers, 1)
+}
@@ -0,0 +1,229 @@
+package remy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RateLimitStats counts the requests an AdminServer's rate limit let through, and how long they waited to be sent.
+type RateLimitStats struct {
+	// Requests is the number of requests let through.
+	Requests int64 `json:"requests"`
+	// Delayed is the number of those requests that had to wait for a token or a free slot.
+	Delayed int64 `json:"delayed"`
+	// Waited is the total time requests waited.
+	Waited time.Duration `json:"waited"`
+	// MaxWait is the longest any one request waited.
+	MaxWait time.Duration `json:"maxWait"`
+	// Waiting is the number of requests waiting for a token or a free slot now.
+	Waiting int64 `json:"waiting"`
+	// InFlight is the number of requests sent and not yet finished now.  It is only counted with a MaxInFlight.
+	InFlight int64 `json:"inFlight"`
+}
+
+// rateLimitKey identifies the rateLimiter shared by every Client of an AdminServer with the same limits.
+type rateLimitKey struct {
+	adminURL    string
+	rate        float64
+	burst       int
+	maxInFlight int
+}
+
+// rateLimiters are the rateLimiters of every rate limited AdminServer in the process, so that Clients created
+// separately for the same AdminServer, e.g., one per request by a library caller, or for two serve profiles naming
+// the same AdminServer, share one limit.
+var rateLimiters = struct {
+	sync.Mutex
+	m map[rateLimitKey]*rateLimiter
+}{m: map[rateLimitKey]*rateLimiter{}}
+
+// rateLimiter is a token bucket refilled at rate tokens a second up to burst tokens, and a cap of maxInFlight requests
+// sent at once.  A zero rate or maxInFlight doesn't limit.
+type rateLimiter struct {
+	rate     float64
+	burst    float64
+	inFlight chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+	now    func() time.Time
+}
+
+// rateLimiterFor returns the rateLimiter shared by Clients of the AdminServer, or nil when it isn't limited.
+func rateLimiterFor(server *AdminServer) *rateLimiter {
+	if server.RateLimit <= 0 && server.MaxInFlight <= 0 {
+		return nil
+	}
+	key := rateLimitKey{server.AdminURL, server.RateLimit, server.Burst, server.MaxInFlight}
+	rateLimiters.Lock()
+	defer rateLimiters.Unlock()
+	if l, ok := rateLimiters.m[key]; ok {
+		return l
+	}
+	l := newRateLimiter(server.RateLimit, server.Burst, server.MaxInFlight)
+	rateLimiters.m[key] = l
+	return l
+}
+
+// newRateLimiter creates a rateLimiter whose bucket starts full.  A burst below 1 is 1.
+func newRateLimiter(rate float64, burst, maxInFlight int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+	l.last = l.now()
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// reserve takes a token from the bucket, returning how long to wait until it is due.  The bucket goes in to debt, so
+// requests waiting for tokens are sent in the order they arrived.
+func (l *rateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved by a request that gave up waiting for it.
+func (l *rateLimiter) cancel() {
+	if l.rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+// wait blocks until a request may be sent, or ctx is done, returning how long it waited.  Unless wait fails, the
+// caller must call release once the request is finished.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	l.mu.Lock()
+	l.stats.Waiting++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.stats.Waiting--
+		l.mu.Unlock()
+	}()
+	if delay := l.reserve(); delay > 0 {
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			l.cancel()
+			return time.Since(start), ctx.Err()
+		}
+	}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			l.cancel()
+			return time.Since(start), ctx.Err()
+		}
+	}
+	waited := time.Since(start)
+	l.mu.Lock()
+	l.stats.Requests++
+	if waited > time.Millisecond {
+		l.stats.Delayed++
+		l.stats.Waited += waited
+		if waited > l.stats.MaxWait {
+			l.stats.MaxWait = waited
+		}
+	}
+	l.mu.Unlock()
+	return waited, nil
+}
+
+// release frees the in-flight slot of a finished request.
+func (l *rateLimiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+// snapshot returns the counts so far, and the requests waiting and in flight now.
+func (l *rateLimiter) snapshot() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := l.stats
+	stats.InFlight = int64(len(l.inFlight))
+	return stats
+}
+
+// rateLimitWaitKey is the context key of the *int64 nanoseconds send's requests waited on the rate limit, summed
+// across retries.
+type rateLimitWaitKey struct{}
+
+// roundTripper wraps next, waiting for the rate limit before sending each request, and holding its in-flight slot
+// until the response body is closed.
+func (l *rateLimiter) roundTripper(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		waited, err := l.wait(ctx)
+		trace.SpanFromContext(ctx).SetAttributes(RateLimitWaitAttribute.Float64(waited.Seconds()))
+		if total, ok := ctx.Value(rateLimitWaitKey{}).(*int64); ok {
+			atomic.AddInt64(total, int64(waited))
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			l.release()
+			return nil, err
+		}
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: l.release}
+		return resp, nil
+	})
+}
+
+// releasingBody is a response body that frees its request's in-flight slot when closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and frees the slot, once.
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// RateLimitStats returns how many requests the rate limit of the Client's AdminServer let through and how long they
+// waited, across every Client of that AdminServer in the process, and how many are waiting and in flight now.  See
+// AdminServer.RateLimit.
+func (c *Client) RateLimitStats() RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+	return c.limiter.snapshot()
+}
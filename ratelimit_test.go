@@ -0,0 +1,125 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(10, 2, 0)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	assert.Zero(t, l.reserve())
+	assert.Zero(t, l.reserve(), "the burst is sent at once")
+	assert.Equal(t, 100*time.Millisecond, l.reserve())
+	assert.Equal(t, 200*time.Millisecond, l.reserve(), "waiting requests queue up")
+	l.cancel()
+	assert.Equal(t, 200*time.Millisecond, l.reserve(), "a cancelled request gives its token back")
+
+	now = now.Add(time.Hour)
+	assert.Zero(t, l.reserve())
+	assert.Zero(t, l.reserve())
+	assert.NotZero(t, l.reserve(), "the bucket holds no more than the burst")
+}
+
+func TestRateLimit(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+	server := &AdminServer{AdminURL: ts.URL, RateLimit: 50, Burst: 2}
+	client := NewClient(server)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.Servers(ctx, QueryOptions{})
+		assert.NoError(t, err)
+	}
+	assert.True(t, time.Since(start) >= 50*time.Millisecond, "5 requests took %v", time.Since(start))
+
+	stats := NewClient(server).RateLimitStats()
+	assert.Equal(t, int64(6), stats.Requests, "Clients of an AdminServer share its rate limit, as does probing its version")
+	assert.True(t, stats.Delayed >= 2, "%d requests delayed", stats.Delayed)
+	assert.True(t, stats.MaxWait > 0 && stats.Waited >= stats.MaxWait)
+	assert.Equal(t, RateLimitStats{}, NewClient(&AdminServer{AdminURL: ts.URL}).RateLimitStats())
+
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		client.Servers(ctx, QueryOptions{})
+	}
+	_, err := client.Servers(ctx, QueryOptions{})
+	assert.Error(t, err, "requests give up waiting when their context is done")
+}
+
+func TestMaxInFlight(t *testing.T) {
+	var inFlight, most int32
+	var client *Client
+	var waiting int64
+	r := mux.NewRouter()
+	r.HandleFunc(MonitorPath+"/servers", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		if stats := client.RateLimitStats(); stats.Waiting > 0 {
+			atomic.StoreInt64(&waiting, stats.Waiting)
+			assert.True(t, stats.InFlight > 0 && stats.InFlight <= 2, "%d in flight", stats.InFlight)
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(serversJSON))
+	})
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+	client = NewClient(&AdminServer{AdminURL: ts.URL, MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Servers(context.Background(), QueryOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.True(t, most > 0 && most <= 2, "%d requests in flight at once", most)
+	stats := client.RateLimitStats()
+	assert.Equal(t, int64(9), stats.Requests)
+	assert.NotZero(t, stats.Delayed)
+	assert.NotZero(t, atomic.LoadInt64(&waiting), "requests waiting for a slot are counted while they wait")
+	assert.Zero(t, stats.Waiting)
+	assert.Zero(t, stats.InFlight)
+}
+
+func TestRateLimiterCancelledWaitingForSlot(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(1, 2, 1)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	_, err := l.wait(context.Background())
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.wait(ctx)
+	assert.Error(t, err, "the only slot is taken")
+	assert.Equal(t, int64(1), l.snapshot().InFlight)
+	assert.Zero(t, l.snapshot().Waiting)
+	l.release()
+	assert.Zero(t, l.snapshot().InFlight)
+	assert.Zero(t, l.reserve(), "the cancelled request gave its token back")
+}
@@ -0,0 +1,580 @@
+package remytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/klauern/remy"
+)
+
+// WithConfig sets the configuration served under remy.ConfigPath and changed under remy.EditPath.  By default, it is
+// ConfigFor the Domain.
+func WithConfig(cfg remy.DomainConfig) Option {
+	return func(s *Server) {
+		s.config = &cfg
+	}
+}
+
+// Config returns a copy of the activated configuration.
+func (s *Server) Config() remy.DomainConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyConfig(*s.config)
+}
+
+// ConfigFor creates the configuration of a Domain: every server listening on its own port, data sources with an Oracle
+// URL targeted to the clusters (or servers) they have instances on, and applications deployed from /u01/apps.
+func ConfigFor(d Domain) remy.DomainConfig {
+	var cfg remy.DomainConfig
+	if len(d.Servers) > 0 {
+		cfg.AdminServerName = d.Servers[0].Name
+	}
+	for _, c := range d.Clusters {
+		cfg.Clusters = append(cfg.Clusters, c.Name)
+	}
+	clusterOf := make(map[string]string)
+	for i, srv := range d.Servers {
+		sc := remy.ServerConfig{Name: srv.Name, ListenPort: 7001 + i}
+		if srv.ClusterName != "" {
+			sc.Cluster = remy.ClusterIdentity(srv.ClusterName)
+			clusterOf[srv.Name] = srv.ClusterName
+		}
+		cfg.Servers = append(cfg.Servers, sc)
+	}
+	targetsFor := func(servers []string) []remy.Identity {
+		var ids []remy.Identity
+		seen := make(map[string]bool)
+		for _, name := range servers {
+			id := remy.ServerIdentity(name)
+			if cluster, ok := clusterOf[name]; ok {
+				id = remy.ClusterIdentity(cluster)
+			}
+			if !seen[id.Name()] {
+				seen[id.Name()] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	for _, ds := range d.DataSources {
+		var servers []string
+		for _, inst := range ds.Instances {
+			servers = append(servers, inst.Server)
+		}
+		cfg.DataSources = append(cfg.DataSources, remy.DataSourceConfig{
+			Name:            ds.Name,
+			URL:             "jdbc:oracle:thin:@db:1521/ORCL",
+			DriverName:      "oracle.jdbc.OracleDriver",
+			JNDINames:       []string{"jdbc/" + ds.Name},
+			InitialCapacity: 1,
+			MinCapacity:     1,
+			MaxCapacity:     15,
+			Targets:         targetsFor(servers),
+		})
+	}
+	isCluster := make(map[string]bool)
+	for _, c := range cfg.Clusters {
+		isCluster[c] = true
+	}
+	for _, app := range d.Applications {
+		dc := remy.DeploymentConfig{Name: app.Name, SourcePath: "/u01/apps/" + app.Name + ".ear"}
+		for _, t := range app.TargetStates {
+			if isCluster[t.Target] {
+				dc.Targets = append(dc.Targets, remy.ClusterIdentity(t.Target))
+			} else {
+				dc.Targets = append(dc.Targets, remy.ServerIdentity(t.Target))
+			}
+		}
+		cfg.Deployments = append(cfg.Deployments, dc)
+	}
+	return cfg
+}
+
+func (s *Server) configRoutes(r *mux.Router) {
+	r.HandleFunc(remy.ConfigPath+"/search", s.search).Methods(http.MethodPost)
+	r.HandleFunc(remy.EditPath+"/changeManager/{operation}", s.changeManager).Methods(http.MethodPost)
+
+	edit := r.PathPrefix(remy.EditPath).Subrouter()
+	edit.Use(s.requireEdit)
+	edit.HandleFunc("/servers", s.createServer).Methods(http.MethodPost)
+	edit.HandleFunc("/servers/{name}", s.updateServer).Methods(http.MethodPost)
+	edit.HandleFunc("/servers/{name}", s.deleteServer).Methods(http.MethodDelete)
+	edit.HandleFunc("/JDBCSystemResources", s.createDataSource).Methods(http.MethodPost)
+	edit.HandleFunc("/JDBCSystemResources/{name}", s.updateDataSource).Methods(http.MethodPost)
+	edit.HandleFunc("/JDBCSystemResources/{name}", s.deleteDataSource).Methods(http.MethodDelete)
+	edit.HandleFunc("/JDBCSystemResources/{name}/JDBCResource/{params}", s.updateDataSourceParams).Methods(http.MethodPost)
+	edit.HandleFunc("/appDeployments", s.createDeployment).Methods(http.MethodPost)
+	edit.HandleFunc("/appDeployments/{name}", s.updateDeployment).Methods(http.MethodPost)
+	edit.HandleFunc("/appDeployments/{name}", s.deleteDeployment).Methods(http.MethodDelete)
+}
+
+// writeConfigError writes an error the way the WLS 12.2.1 REST API does, without the tenant-monitoring envelope.
+func writeConfigError(w http.ResponseWriter, statusCode int, detail string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{"type": "http://oracle/TBD/WlsRestMessageSchema", "title": "FAILURE", "status": statusCode, "detail": detail})
+}
+
+func writeConfig(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+type target struct {
+	Identity remy.Identity `json:"identity"`
+}
+
+func toTargets(ids []remy.Identity) []target {
+	targets := []target{}
+	for _, id := range ids {
+		targets = append(targets, target{id})
+	}
+	return targets
+}
+
+// search answers a search of the domainConfig tree with every field remy.Client.Configuration asks for.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Requested-By") == "" {
+		writeConfigError(w, http.StatusBadRequest, "X-Requested-By header is required")
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cfg := s.config
+
+	type items struct {
+		Items []interface{} `json:"items"`
+	}
+	clusters, servers, dataSources, deployments := items{[]interface{}{}}, items{[]interface{}{}}, items{[]interface{}{}}, items{[]interface{}{}}
+	for _, c := range cfg.Clusters {
+		clusters.Items = append(clusters.Items, map[string]interface{}{"name": c})
+	}
+	for _, srv := range cfg.Servers {
+		servers.Items = append(servers.Items, srv)
+	}
+	for _, ds := range cfg.DataSources {
+		dataSources.Items = append(dataSources.Items, map[string]interface{}{
+			"name":    ds.Name,
+			"targets": toTargets(ds.Targets),
+			"JDBCResource": map[string]interface{}{
+				"JDBCDriverParams":     map[string]interface{}{"url": ds.URL, "driverName": ds.DriverName},
+				"JDBCDataSourceParams": map[string]interface{}{"JNDINames": ds.JNDINames},
+				"JDBCConnectionPoolParams": map[string]interface{}{
+					"initialCapacity": ds.InitialCapacity, "minCapacity": ds.MinCapacity, "maxCapacity": ds.MaxCapacity,
+				},
+			},
+		})
+	}
+	for _, d := range cfg.Deployments {
+		deployments.Items = append(deployments.Items, map[string]interface{}{"name": d.Name, "sourcePath": d.SourcePath, "targets": toTargets(d.Targets)})
+	}
+	writeConfig(w, map[string]interface{}{
+		"adminServerName":     cfg.AdminServerName,
+		"clusters":            clusters,
+		"servers":             servers,
+		"JDBCSystemResources": dataSources,
+		"appDeployments":      deployments,
+	})
+}
+
+// changeManager starts, activates or cancels the edit session.  Activating applies the pending configuration to the
+// Domain as well, so new servers, data sources and deployments show up in the monitoring resources.
+func (s *Server) changeManager(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Requested-By") == "" {
+		writeConfigError(w, http.StatusBadRequest, "X-Requested-By header is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch mux.Vars(r)["operation"] {
+	case "startEdit":
+		if s.pending == nil {
+			pending := copyConfig(*s.config)
+			s.pending = &pending
+		}
+	case "activate":
+		if s.pending == nil {
+			writeConfigError(w, http.StatusBadRequest, "No edit session to activate")
+			return
+		}
+		s.config = s.pending
+		s.pending = nil
+		applyConfig(&s.domain, *s.config)
+	case "cancelEdit":
+		s.pending = nil
+	default:
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("Unknown change manager operation %v", mux.Vars(r)["operation"]))
+		return
+	}
+	writeConfig(w, map[string]interface{}{})
+}
+
+// requireEdit rejects changes made without the X-Requested-By header or outside of an edit session, and holds the
+// Server's lock for those made inside one.
+func (s *Server) requireEdit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Requested-By") == "" {
+			writeConfigError(w, http.StatusBadRequest, "X-Requested-By header is required")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.pending == nil {
+			writeConfigError(w, http.StatusBadRequest, "No edit session started, call changeManager/startEdit first")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeFields reads the JSON object in the request body, keyed by field.  Only the fields given are changed.
+func decodeFields(r *http.Request) (map[string]json.RawMessage, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// setFields unmarshals each field into the matching destination.
+func setFields(fields map[string]json.RawMessage, dest map[string]interface{}) error {
+	for k, v := range fields {
+		if d, ok := dest[k]; ok {
+			if err := json.Unmarshal(v, d); err != nil {
+				return fmt.Errorf("invalid %v: %v", k, err)
+			}
+		}
+	}
+	return nil
+}
+
+func setTargets(fields map[string]json.RawMessage, ids *[]remy.Identity) error {
+	raw, ok := fields["targets"]
+	if !ok {
+		return nil
+	}
+	var targets []target
+	if err := json.Unmarshal(raw, &targets); err != nil {
+		return fmt.Errorf("invalid targets: %v", err)
+	}
+	*ids = nil
+	for _, t := range targets {
+		*ids = append(*ids, t.Identity)
+	}
+	return nil
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request) {
+	fields, err := decodeFields(r)
+	var srv remy.ServerConfig
+	if err == nil {
+		err = setFields(fields, map[string]interface{}{"name": &srv.Name, "listenPort": &srv.ListenPort, "cluster": &srv.Cluster})
+	}
+	if err != nil || srv.Name == "" {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("invalid server: %v", err))
+		return
+	}
+	if findServerConfig(s.pending, srv.Name) >= 0 {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("Server %v already exists", srv.Name))
+		return
+	}
+	s.pending.Servers = append(s.pending.Servers, srv)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, r *http.Request) {
+	i := findServerConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("Server %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	srv := &s.pending.Servers[i]
+	fields, err := decodeFields(r)
+	if err == nil {
+		err = setFields(fields, map[string]interface{}{"listenPort": &srv.ListenPort, "cluster": &srv.Cluster})
+	}
+	if err != nil {
+		writeConfigError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) deleteServer(w http.ResponseWriter, r *http.Request) {
+	i := findServerConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("Server %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	s.pending.Servers = append(s.pending.Servers[:i], s.pending.Servers[i+1:]...)
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) createDataSource(w http.ResponseWriter, r *http.Request) {
+	fields, err := decodeFields(r)
+	var ds remy.DataSourceConfig
+	if err == nil {
+		err = setFields(fields, map[string]interface{}{"name": &ds.Name})
+	}
+	if err == nil {
+		err = setTargets(fields, &ds.Targets)
+	}
+	if err != nil || ds.Name == "" {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("invalid JDBC system resource: %v", err))
+		return
+	}
+	if findDataSourceConfig(s.pending, ds.Name) >= 0 {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("JDBC system resource %v already exists", ds.Name))
+		return
+	}
+	s.pending.DataSources = append(s.pending.DataSources, ds)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) updateDataSource(w http.ResponseWriter, r *http.Request) {
+	i := findDataSourceConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("JDBC system resource %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	fields, err := decodeFields(r)
+	if err == nil {
+		err = setTargets(fields, &s.pending.DataSources[i].Targets)
+	}
+	if err != nil {
+		writeConfigError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) updateDataSourceParams(w http.ResponseWriter, r *http.Request) {
+	i := findDataSourceConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("JDBC system resource %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	ds := &s.pending.DataSources[i]
+	var dest map[string]interface{}
+	switch mux.Vars(r)["params"] {
+	case "JDBCDriverParams":
+		dest = map[string]interface{}{"url": &ds.URL, "driverName": &ds.DriverName}
+	case "JDBCDataSourceParams":
+		dest = map[string]interface{}{"JNDINames": &ds.JNDINames}
+	case "JDBCConnectionPoolParams":
+		dest = map[string]interface{}{"initialCapacity": &ds.InitialCapacity, "minCapacity": &ds.MinCapacity, "maxCapacity": &ds.MaxCapacity}
+	default:
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("No resource found at %v", r.URL.Path))
+		return
+	}
+	fields, err := decodeFields(r)
+	if err == nil {
+		err = setFields(fields, dest)
+	}
+	if err != nil {
+		writeConfigError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) deleteDataSource(w http.ResponseWriter, r *http.Request) {
+	i := findDataSourceConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("JDBC system resource %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	s.pending.DataSources = append(s.pending.DataSources[:i], s.pending.DataSources[i+1:]...)
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request) {
+	fields, err := decodeFields(r)
+	var d remy.DeploymentConfig
+	if err == nil {
+		err = setFields(fields, map[string]interface{}{"name": &d.Name, "sourcePath": &d.SourcePath})
+	}
+	if err == nil {
+		err = setTargets(fields, &d.Targets)
+	}
+	if err != nil || d.Name == "" || d.SourcePath == "" {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("invalid deployment, name and sourcePath are required: %v", err))
+		return
+	}
+	if findDeploymentConfig(s.pending, d.Name) >= 0 {
+		writeConfigError(w, http.StatusBadRequest, fmt.Sprintf("Deployment %v already exists", d.Name))
+		return
+	}
+	s.pending.Deployments = append(s.pending.Deployments, d)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) updateDeployment(w http.ResponseWriter, r *http.Request) {
+	i := findDeploymentConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("Deployment %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	fields, err := decodeFields(r)
+	if err == nil {
+		err = setTargets(fields, &s.pending.Deployments[i].Targets)
+	}
+	if err != nil {
+		writeConfigError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeConfig(w, map[string]interface{}{})
+}
+
+func (s *Server) deleteDeployment(w http.ResponseWriter, r *http.Request) {
+	i := findDeploymentConfig(s.pending, mux.Vars(r)["name"])
+	if i < 0 {
+		writeConfigError(w, http.StatusNotFound, fmt.Sprintf("Deployment %v not found", mux.Vars(r)["name"]))
+		return
+	}
+	s.pending.Deployments = append(s.pending.Deployments[:i], s.pending.Deployments[i+1:]...)
+	writeConfig(w, map[string]interface{}{})
+}
+
+func findServerConfig(cfg *remy.DomainConfig, name string) int {
+	for i := range cfg.Servers {
+		if cfg.Servers[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func findDataSourceConfig(cfg *remy.DomainConfig, name string) int {
+	for i := range cfg.DataSources {
+		if cfg.DataSources[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func findDeploymentConfig(cfg *remy.DomainConfig, name string) int {
+	for i := range cfg.Deployments {
+		if cfg.Deployments[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyConfig brings the run-time Domain in line with an activated configuration.  New servers are shut down, new data
+// sources get an instance on every server they target, and new applications are active on their targets.
+func applyConfig(d *Domain, cfg remy.DomainConfig) {
+	members := make(map[string][]string)
+	servers := make(map[string]remy.Server)
+	for _, srv := range d.Servers {
+		servers[srv.Name] = srv
+	}
+	d.Servers = nil
+	for i, sc := range cfg.Servers {
+		srv, ok := servers[sc.Name]
+		if !ok {
+			srv = newServer(sc.Name, "", i)
+			srv.State, srv.Health = "SHUTDOWN", ""
+		}
+		srv.ClusterName = sc.Cluster.Name()
+		if srv.ClusterName != "" {
+			members[srv.ClusterName] = append(members[srv.ClusterName], srv.Name)
+		}
+		d.Servers = append(d.Servers, srv)
+	}
+
+	for i := range d.Clusters {
+		c := &d.Clusters[i]
+		existing := make(map[string]remy.ClusterServer)
+		for _, m := range c.Servers {
+			existing[m.Name] = m
+		}
+		c.Servers = nil
+		for _, name := range members[c.Name] {
+			m, ok := existing[name]
+			if !ok {
+				m = newClusterMember(name, len(existing) == 0 && len(c.Servers) == 0)
+				m.State, m.Health = "SHUTDOWN", ""
+			}
+			c.Servers = append(c.Servers, m)
+		}
+	}
+
+	expand := func(targets []remy.Identity) []string {
+		var names []string
+		for _, t := range targets {
+			if len(t) > 0 && t[0] == "clusters" {
+				names = append(names, members[t.Name()]...)
+			} else {
+				names = append(names, t.Name())
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	dataSources := make(map[string]remy.DataSource)
+	for _, ds := range d.DataSources {
+		dataSources[ds.Name] = ds
+	}
+	d.DataSources = nil
+	for _, dc := range cfg.DataSources {
+		ds, ok := dataSources[dc.Name]
+		if !ok {
+			ds = remy.DataSource{Name: dc.Name, Type: "Generic"}
+		}
+		instances := make(map[string]remy.DataSourceInstance)
+		for _, inst := range ds.Instances {
+			instances[inst.Server] = inst
+		}
+		ds.Instances = nil
+		for _, server := range expand(dc.Targets) {
+			inst, ok := instances[server]
+			if !ok {
+				inst = newDataSourceInstance(server)
+			}
+			ds.Instances = append(ds.Instances, inst)
+		}
+		d.DataSources = append(d.DataSources, ds)
+	}
+
+	applications := make(map[string]remy.Application)
+	for _, app := range d.Applications {
+		applications[app.Name] = app
+	}
+	d.Applications = nil
+	for _, dc := range cfg.Deployments {
+		app, ok := applications[dc.Name]
+		if !ok {
+			app = remy.Application{Name: dc.Name, AppType: "ear", State: "STATE_ACTIVE", Health: "HEALTH_OK"}
+		}
+		app.TargetStates = nil
+		for _, t := range dc.Targets {
+			app.TargetStates = append(app.TargetStates, remy.TargetState{Target: t.Name(), State: "STATE_ACTIVE"})
+		}
+		d.Applications = append(d.Applications, app)
+	}
+}
+
+// copyConfig deep copies cfg by round-tripping it through JSON.
+func copyConfig(cfg remy.DomainConfig) remy.DomainConfig {
+	var c remy.DomainConfig
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("unable to copy configuration: %v", err))
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic(fmt.Sprintf("unable to copy configuration: %v", err))
+	}
+	return c
+}
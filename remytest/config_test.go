@@ -0,0 +1,57 @@
+package remytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFor(t *testing.T) {
+	cfg := ConfigFor(DefaultDomain())
+	assert.Equal(t, "AdminServer", cfg.AdminServerName)
+	assert.Equal(t, []string{"cluster1"}, cfg.Clusters)
+	assert.Nil(t, cfg.Servers[0].Cluster)
+	assert.Equal(t, remy.ClusterIdentity("cluster1"), cfg.Servers[1].Cluster)
+	assert.Equal(t, []remy.Identity{remy.ClusterIdentity("cluster1")}, cfg.DataSources[0].Targets)
+	assert.Equal(t, []remy.Identity{remy.ClusterIdentity("cluster1")}, cfg.Deployments[0].Targets)
+}
+
+func TestEditSession(t *testing.T) {
+	fake := New()
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+	ctx := context.Background()
+
+	assert.Error(t, client.CreateServer(ctx, remy.ServerConfig{Name: "ms3"}), "changes need an edit session")
+
+	assert.NoError(t, client.StartEdit(ctx))
+	assert.NoError(t, client.CreateServer(ctx, remy.ServerConfig{Name: "ms3", ListenPort: 7010, Cluster: remy.ClusterIdentity("cluster1")}))
+	assert.NoError(t, client.CreateDeployment(ctx, remy.DeploymentConfig{Name: "app2", SourcePath: "/u01/apps/app2.war", Targets: []remy.Identity{remy.ServerIdentity("ms3")}}))
+	assert.NoError(t, client.DeleteDataSource(ctx, "ds1"))
+
+	cfg, err := client.Configuration(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Servers, 3, "changes should be pending until activated")
+
+	assert.NoError(t, client.Activate(ctx))
+	cfg, err = client.Configuration(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Servers, 4)
+	assert.Empty(t, cfg.DataSources)
+	assert.Len(t, cfg.Deployments, 2)
+
+	d := fake.Domain()
+	assert.Equal(t, "ms3", d.Servers[3].Name)
+	assert.Equal(t, "SHUTDOWN", d.Servers[3].State)
+	assert.Len(t, d.Clusters[0].Servers, 3)
+	assert.Empty(t, d.DataSources)
+	assert.Equal(t, "ms3", d.Applications[1].TargetStates[0].Target)
+
+	assert.NoError(t, client.StartEdit(ctx))
+	assert.NoError(t, client.DeleteServer(ctx, "ms3"))
+	assert.NoError(t, client.CancelEdit(ctx))
+	assert.Len(t, fake.Config().Servers, 4, "cancelled changes should be discarded")
+}
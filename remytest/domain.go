@@ -0,0 +1,140 @@
+package remytest
+
+import (
+	"fmt"
+
+	"github.com/klauern/remy"
+)
+
+// DomainConfig sizes a Domain created with GenerateDomain.
+type DomainConfig struct {
+	// ManagedServers is the number of managed servers, in addition to the AdminServer.
+	ManagedServers int
+	// Clusters is the number of clusters the managed servers are spread across.  Zero leaves them unclustered.
+	Clusters int
+	// DataSources is the number of JDBC data sources, each deployed to every managed server.
+	DataSources int
+	// Applications is the number of applications, each targeted to the first cluster (or every managed server).
+	Applications int
+	// Partitions is the number of domain partitions, each running on every managed server with its own data source
+	// and application.  They are only served when emulating 12.2.1.x.
+	Partitions int
+}
+
+// DefaultDomain is a small domain with an AdminServer, two managed servers in one cluster, a data source and an
+// application.
+func DefaultDomain() Domain {
+	return GenerateDomain(DomainConfig{ManagedServers: 2, Clusters: 1, DataSources: 1, Applications: 1})
+}
+
+// GenerateDomain creates a healthy, running Domain sized by cfg.  Names are predictable: managed servers are ms1..msN,
+// clusters cluster1..clusterN, data sources ds1..dsN, applications app1..appN and partitions p1..pN.
+func GenerateDomain(cfg DomainConfig) Domain {
+	var d Domain
+	d.Servers = append(d.Servers, newServer("AdminServer", "", 0))
+	clusters := make([]remy.Cluster, cfg.Clusters)
+	for i := range clusters {
+		clusters[i].Name = fmt.Sprintf("cluster%d", i+1)
+	}
+	for i := 1; i <= cfg.ManagedServers; i++ {
+		cluster := ""
+		if len(clusters) > 0 {
+			c := &clusters[(i-1)%len(clusters)]
+			cluster = c.Name
+			c.Servers = append(c.Servers, newClusterMember(fmt.Sprintf("ms%d", i), len(c.Servers) == 0))
+		}
+		d.Servers = append(d.Servers, newServer(fmt.Sprintf("ms%d", i), cluster, i))
+	}
+	d.Clusters = clusters
+
+	for i := 1; i <= cfg.DataSources; i++ {
+		ds := remy.DataSource{Name: fmt.Sprintf("ds%d", i), Type: "Generic"}
+		for _, s := range d.Servers[1:] {
+			ds.Instances = append(ds.Instances, newDataSourceInstance(s.Name))
+		}
+		d.DataSources = append(d.DataSources, ds)
+	}
+
+	for i := 1; i <= cfg.Applications; i++ {
+		d.Applications = append(d.Applications, newApplication(fmt.Sprintf("app%d", i), d))
+	}
+
+	for i := 1; i <= cfg.Partitions; i++ {
+		d.Partitions = append(d.Partitions, newPartition(fmt.Sprintf("p%d", i), d))
+	}
+	return d
+}
+
+func newServer(name, cluster string, i int) remy.Server {
+	return remy.Server{
+		Name:                    name,
+		State:                   "RUNNING",
+		Health:                  "HEALTH_OK",
+		ClusterName:             cluster,
+		CurrentMachine:          fmt.Sprintf("machine-%d", i),
+		WebLogicVersion:         "WebLogic Server 12.1.3.0.0 Wed May 21 18:53:34 PDT 2014 1604337",
+		OpenSocketsCurrentCount: 4,
+		HeapSizeCurrent:         536870912,
+		HeapFreeCurrent:         268435456,
+		JavaVersion:             "1.8.0_144",
+		OsName:                  "Linux",
+		OsVersion:               "3.10.0-693.el7.x86_64",
+		JvmProcessorLoad:        0.1,
+	}
+}
+
+func newClusterMember(name string, master bool) remy.ClusterServer {
+	return remy.ClusterServer{
+		Name:                   name,
+		State:                  "RUNNING",
+		Health:                 "HEALTH_OK",
+		IsClusterMaster:        master,
+		DropOutFrequency:       "Never",
+		FragmentsSentCount:     3708,
+		FragmentsReceivedCount: 3631,
+	}
+}
+
+func newDataSourceInstance(server string) remy.DataSourceInstance {
+	return remy.DataSourceInstance{
+		Server:                           server,
+		State:                            "Running",
+		Enabled:                          true,
+		VersionJDBCDriver:                "oracle.jdbc.OracleDriver",
+		ActiveConnectionsCurrentCount:    2,
+		ActiveConnectionsHighCount:       5,
+		ActiveConnectionsAverageCount:    1,
+		ConnectionsTotalCount:            10,
+		CurrCapacity:                     10,
+		CurrCapacityHighCount:            10,
+		HighestNumAvailable:              10,
+		NumAvailable:                     8,
+		PrepStmtCacheAccessCount:         1000,
+		PrepStmtCacheAddCount:            50,
+		PrepStmtCacheCurrentSize:         10,
+		PrepStmtCacheHitCount:            950,
+		PrepStmtCacheMissCount:           50,
+		ReserveRequestCount:              1200,
+		WaitingForConnectionTotal:        3,
+		WaitingForConnectionSuccessTotal: 3,
+	}
+}
+
+func newApplication(name string, d Domain) remy.Application {
+	app := remy.Application{Name: name, AppType: "ear", State: "STATE_ACTIVE", Health: "HEALTH_OK"}
+	if len(d.Clusters) > 0 {
+		app.TargetStates = append(app.TargetStates, remy.TargetState{Target: d.Clusters[0].Name, State: "STATE_ACTIVE"})
+	}
+	for _, s := range d.Servers[1:] {
+		if len(d.Clusters) == 0 {
+			app.TargetStates = append(app.TargetStates, remy.TargetState{Target: s.Name, State: "STATE_ACTIVE"})
+		} else if s.ClusterName != d.Clusters[0].Name {
+			continue
+		}
+		for _, ds := range d.DataSources {
+			app.DataSources = append(app.DataSources, remy.AppDataSource{Name: ds.Name, Server: s.Name, State: "Running"})
+		}
+		app.WorkManagers = append(app.WorkManagers, remy.WorkManager{Name: "default", Server: s.Name, CompletedRequests: 100})
+	}
+	return app
+}
@@ -0,0 +1,91 @@
+package remytest
+
+import (
+	"github.com/klauern/remy"
+)
+
+// Partition is a domain partition of the simulated domain, with the applications and data sources deployed to it
+// rather than the domain.
+type Partition struct {
+	remy.Partition
+	Applications []remy.Application
+	DataSources  []remy.DataSource
+}
+
+// newPartition creates a running partition on every managed server of d, with a resource group, a data source and an
+// application targeted like the domain's.
+func newPartition(name string, d Domain) Partition {
+	p := Partition{Partition: remy.Partition{
+		Name:           name,
+		State:          "RUNNING",
+		ResourceGroups: []remy.ResourceGroup{{Name: name + "-rg", State: "RUNNING"}},
+	}}
+	ds := remy.DataSource{Name: name + "-ds1", Type: "Generic"}
+	for _, s := range d.Servers[1:] {
+		p.Servers = append(p.Servers, remy.PartitionServer{
+			Server: s.Name,
+			State:  "RUNNING",
+			PartitionResources: remy.PartitionResources{
+				CPUUtilization:         2.5,
+				CPUTimeNanos:           120000000000,
+				HeapAllocatedTotal:     67108864,
+				ThreadCount:            12,
+				CurrentOpenFileCount:   40,
+				CurrentOpenSocketCount: 3,
+			},
+		})
+		ds.Instances = append(ds.Instances, newDataSourceInstance(s.Name))
+	}
+	p.DataSources = []remy.DataSource{ds}
+	p.Applications = []remy.Application{
+		newApplication(name+"-app1", Domain{Servers: d.Servers, Clusters: d.Clusters, DataSources: p.DataSources}),
+	}
+	return p
+}
+
+// servesPartitions reports whether the emulated release has domain partitions: 12.2.1 added them to the domainRuntime
+// tree, and 14.1.1 removed them.
+func (s *Server) servesPartitions() bool {
+	v, _ := remy.ParseVersion(s.version)
+	return v.AtLeast(12, 2, 1) && !v.AtLeast(14, 1, 1)
+}
+
+// domainPartitionRuntimes are the DomainPartitionRuntime beans of the partitions, with their life cycles.
+func domainPartitionRuntimes(partitions []Partition) map[string]interface{} {
+	beans := []interface{}{}
+	for _, p := range partitions {
+		groups := []interface{}{}
+		for _, rg := range p.ResourceGroups {
+			groups = append(groups, map[string]interface{}{"name": rg.Name, "state": rg.State})
+		}
+		beans = append(beans, map[string]interface{}{
+			"name": p.Name,
+			"partitionLifeCycleRuntime": map[string]interface{}{
+				"state":                          p.State,
+				"resourceGroupLifeCycleRuntimes": map[string]interface{}{"items": groups},
+			},
+		})
+	}
+	return map[string]interface{}{"items": beans}
+}
+
+// partitionRuntimes are the PartitionRuntime beans of the partitions running on srv, with their resource metrics,
+// data sources and applications.
+func partitionRuntimes(partitions []Partition, srv remy.Server) map[string]interface{} {
+	beans := []interface{}{}
+	for _, p := range partitions {
+		for _, ps := range p.Servers {
+			if ps.Server != srv.Name {
+				continue
+			}
+			beans = append(beans, map[string]interface{}{
+				"name":                            p.Name,
+				"state":                           ps.State,
+				"partitionResourceMetricsRuntime": beanFields(ps.PartitionResources),
+				"JDBCPartitionRuntime":            map[string]interface{}{"JDBCDataSourceRuntimeMBeans": dataSourceRuntimes(p.DataSources, srv.Name)},
+				"applicationRuntimes":             applicationRuntimes(p.Applications, srv),
+			})
+		}
+	}
+	return map[string]interface{}{"items": beans}
+}
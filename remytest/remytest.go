@@ -0,0 +1,389 @@
+// Package remytest provides a stateful, simulated WebLogic AdminServer that answers the RESTful Management Extensions
+// tenant-monitoring resources, the domainConfig and edit trees of the WLS 12.2.1 REST API for the configuration remy
+// can change, and, when emulating 12.2.1 or later, searches of its domainRuntime tree, including domain partitions.
+// It can be used to develop against remy without a real domain, and in tests through Start, which runs it on an
+// httptest.Server.
+package remytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/gorilla/mux"
+	"github.com/klauern/remy"
+)
+
+// Domain is the state of the simulated WebLogic domain served by a Server.
+type Domain struct {
+	Servers      []remy.Server
+	Clusters     []remy.Cluster
+	DataSources  []remy.DataSource
+	Applications []remy.Application
+	Partitions   []Partition
+}
+
+// Server is a fake AdminServer serving a Domain under remy.MonitorPath.  It is safe for concurrent use; the Domain can
+// be changed while it is serving with Update.
+type Server struct {
+	mu       sync.RWMutex
+	domain   Domain
+	config   *remy.DomainConfig
+	pending  *remy.DomainConfig
+	username string
+	password string
+	latency  time.Duration
+	version  string
+	errors   map[string]int
+	router   *mux.Router
+}
+
+// Option configures a Server when it is created with New.
+type Option func(*Server)
+
+// WithDomain sets the Domain served.  By default, DefaultDomain() is used.
+func WithDomain(d Domain) Option {
+	return func(s *Server) {
+		s.domain = d
+	}
+}
+
+// WithCredentials requires every request to use Basic Authentication with the given username and password.  Without
+// it, any (or no) credentials are accepted.
+func WithCredentials(username, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithLatency delays every response by d.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// WithError makes every request for resource fail with statusCode.  See InjectError.
+func WithError(resource string, statusCode int) Option {
+	return func(s *Server) {
+		s.errors[resource] = statusCode
+	}
+}
+
+// New creates a Server, applying each Option in order.
+func New(opts ...Option) *Server {
+	s := &Server{
+		domain:  DefaultDomain(),
+		version: DefaultVersion,
+		errors:  make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.version != DefaultVersion {
+		for i := range s.domain.Servers {
+			s.domain.Servers[i].WebLogicVersion = "WebLogic Server " + s.version
+		}
+	}
+	if s.config == nil {
+		cfg := ConfigFor(s.domain)
+		s.config = &cfg
+	}
+	s.router = s.routes()
+	return s
+}
+
+// Start runs the Server on a new httptest.Server.  Callers should Close it when finished.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// AdminServer returns the connection details for talking to this Server at url, usually the URL of the
+// httptest.Server returned by Start.
+func (s *Server) AdminServer(url string) *remy.AdminServer {
+	return &remy.AdminServer{AdminURL: url, Username: s.username, Password: s.password}
+}
+
+// Domain returns a copy of the Domain currently being served.
+func (s *Server) Domain() Domain {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyDomain(s.domain)
+}
+
+// Update changes the Domain being served.  fn is called while holding the Server's lock, so requests see either all
+// or none of its changes.  The configuration is not changed to match.
+func (s *Server) Update(fn func(d *Domain)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.domain)
+}
+
+// InjectError makes every request for resource fail with statusCode until ClearErrors is called.  resource is the
+// path below remy.MonitorPath, such as "servers" or "servers/ms1", the full path of a configuration resource, such as
+// remy.EditPath + "/servers", or "*" to fail every request.
+func (s *Server) InjectError(resource string, statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[resource] = statusCode
+}
+
+// ClearErrors removes every error added with InjectError or WithError.
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = make(map[string]int)
+}
+
+// ServeHTTP applies latency, authentication and injected errors before serving the requested resource.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	latency := s.latency
+	username, password := s.username, s.password
+	status, failed := s.injectedError(strings.TrimPrefix(r.URL.Path, remy.MonitorPath+"/"))
+	s.mu.RUnlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if username != "" || password != "" {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != username || p != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="weblogic"`)
+			writeMessages(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+	}
+	if failed {
+		writeMessages(w, status, fmt.Sprintf("Injected error for %v", r.URL.Path))
+		return
+	}
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *Server) injectedError(resource string) (int, bool) {
+	if code, ok := s.errors[resource]; ok {
+		return code, true
+	}
+	code, ok := s.errors["*"]
+	return code, ok
+}
+
+func (s *Server) routes() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc(remy.MonitorPath+"/servers", s.tenantMonitoring(s.servers))
+	r.HandleFunc(remy.MonitorPath+"/servers/{name}", s.tenantMonitoring(s.server))
+	r.HandleFunc(remy.MonitorPath+"/clusters", s.tenantMonitoring(s.clusters))
+	r.HandleFunc(remy.MonitorPath+"/clusters/{name}", s.tenantMonitoring(s.cluster))
+	r.HandleFunc(remy.MonitorPath+"/datasources", s.tenantMonitoring(s.dataSources))
+	r.HandleFunc(remy.MonitorPath+"/datasources/{name}", s.tenantMonitoring(s.dataSource))
+	r.HandleFunc(remy.MonitorPath+"/applications", s.tenantMonitoring(s.applications))
+	r.HandleFunc(remy.MonitorPath+"/applications/{name}", s.tenantMonitoring(s.application))
+	r.HandleFunc(remy.VersionPath, s.latest).Methods("GET")
+	r.HandleFunc(remy.RuntimePath+"/search", s.runtimeSearch).Methods("POST")
+	s.configRoutes(r)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMessages(w, http.StatusNotFound, fmt.Sprintf("No resource found at %v", r.URL.Path))
+	})
+	return r
+}
+
+func isFullFormat(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "full"
+}
+
+func (s *Server) servers(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	servers := make([]remy.Server, len(s.domain.Servers))
+	for i, srv := range s.domain.Servers {
+		if isFullFormat(r) {
+			servers[i] = srv
+		} else {
+			servers[i] = remy.Server{Name: srv.Name, State: srv.State, Health: srv.Health}
+		}
+	}
+	writeItems(w, servers)
+}
+
+func (s *Server) server(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name := mux.Vars(r)["name"]
+	for _, srv := range s.domain.Servers {
+		if srv.Name == name {
+			writeItem(w, srv)
+			return
+		}
+	}
+	writeMessages(w, http.StatusNotFound, fmt.Sprintf("Server %v not found", name))
+}
+
+func (s *Server) clusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clusters := copyDomain(Domain{Clusters: s.domain.Clusters}).Clusters
+	if !isFullFormat(r) {
+		for i := range clusters {
+			for j := range clusters[i].Servers {
+				m := &clusters[i].Servers[j]
+				m.IsClusterMaster = false
+				m.DropOutFrequency = ""
+				m.ResendRequestsCount = 0
+				m.FragmentsSentCount = 0
+				m.FragmentsReceivedCount = 0
+			}
+		}
+	}
+	writeItems(w, clusters)
+}
+
+func (s *Server) cluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name := mux.Vars(r)["name"]
+	for _, c := range s.domain.Clusters {
+		if c.Name == name {
+			writeItem(w, c)
+			return
+		}
+	}
+	writeMessages(w, http.StatusNotFound, fmt.Sprintf("Cluster %v not found", name))
+}
+
+func (s *Server) dataSources(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dataSources := make([]remy.DataSource, len(s.domain.DataSources))
+	for i, ds := range s.domain.DataSources {
+		if isFullFormat(r) {
+			dataSources[i] = ds
+		} else {
+			dataSources[i] = remy.DataSource{Name: ds.Name, Type: ds.Type}
+		}
+	}
+	writeItems(w, dataSources)
+}
+
+func (s *Server) dataSource(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name := mux.Vars(r)["name"]
+	for _, ds := range s.domain.DataSources {
+		if ds.Name == name {
+			writeItem(w, ds)
+			return
+		}
+	}
+	writeMessages(w, http.StatusNotFound, fmt.Sprintf("Data source %v not found", name))
+}
+
+func (s *Server) applications(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	applications := make([]remy.Application, len(s.domain.Applications))
+	for i, app := range s.domain.Applications {
+		if isFullFormat(r) {
+			applications[i] = app
+		} else {
+			applications[i] = remy.Application{Name: app.Name, AppType: app.AppType, State: app.State, Health: app.Health}
+		}
+	}
+	writeItems(w, applications)
+}
+
+func (s *Server) application(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name := mux.Vars(r)["name"]
+	for _, app := range s.domain.Applications {
+		if app.Name == name {
+			writeItem(w, app)
+			return
+		}
+	}
+	writeMessages(w, http.StatusNotFound, fmt.Sprintf("Application %v not found", name))
+}
+
+func writeItems(w http.ResponseWriter, items interface{}) {
+	writeBody(w, http.StatusOK, map[string]interface{}{"items": items}, []string{})
+}
+
+func writeItem(w http.ResponseWriter, item interface{}) {
+	writeBody(w, http.StatusOK, map[string]interface{}{"item": item}, []string{})
+}
+
+func writeMessages(w http.ResponseWriter, statusCode int, messages ...string) {
+	writeBody(w, statusCode, map[string]interface{}{}, messages)
+}
+
+// writeBody writes the same {"body": ..., "messages": [...]} envelope WebLogic does, with the lowerCamelCase keys it
+// uses.
+func writeBody(w http.ResponseWriter, statusCode int, body interface{}, messages []string) {
+	data, err := json.Marshal(map[string]interface{}{"body": body, "messages": messages})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(lowerCamelKeys(generic))
+}
+
+func lowerCamelKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[lowerCamel(k)] = lowerCamelKeys(val)
+		}
+		return m
+	case []interface{}:
+		for i := range t {
+			t[i] = lowerCamelKeys(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+// lowerCamel lower-cases the leading upper-case run of a key, e.g., JvmProcessorLoad => jvmProcessorLoad and
+// RCLBBased => rclbBased.
+func lowerCamel(s string) string {
+	r := []rune(s)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// copyDomain deep copies d by round-tripping it through JSON, so callers can't reach in to the Server's state.
+func copyDomain(d Domain) Domain {
+	var c Domain
+	data, err := json.Marshal(d)
+	if err != nil {
+		panic(fmt.Sprintf("unable to copy domain: %v", err))
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic(fmt.Sprintf("unable to copy domain: %v", err))
+	}
+	return c
+}
@@ -0,0 +1,189 @@
+package remytest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortAndFullFormat(t *testing.T) {
+	fake := New()
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+
+	short, err := client.Servers(context.Background(), remy.QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, short, 3)
+	assert.Equal(t, "AdminServer", short[0].Name)
+	assert.Equal(t, "RUNNING", short[0].State)
+	assert.Zero(t, short[1].HeapSizeCurrent)
+
+	full, err := client.Servers(context.Background(), remy.QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster1", full[1].ClusterName)
+	assert.Equal(t, 536870912, full[1].HeapSizeCurrent)
+
+	dataSources, err := client.DataSources(context.Background(), remy.QueryOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, dataSources[0].Instances)
+
+	ds, err := client.DataSource(context.Background(), "ds1")
+	assert.NoError(t, err)
+	assert.Len(t, ds.Instances, 2)
+
+	cluster, err := client.Cluster(context.Background(), "cluster1")
+	assert.NoError(t, err)
+	assert.Len(t, cluster.Servers, 2)
+	assert.True(t, cluster.Servers[0].IsClusterMaster)
+	assert.Equal(t, 3708, cluster.Servers[0].FragmentsSentCount)
+
+	fake.Update(func(d *Domain) {
+		d.Applications[0].Name = "Healthcare UI"
+	})
+	app, err := client.Application(context.Background(), "Healthcare UI")
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster1", app.TargetStates[0].Target)
+}
+
+func TestUpdateDomain(t *testing.T) {
+	fake := New()
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+
+	fake.Update(func(d *Domain) {
+		d.Servers[2].State = "SHUTDOWN"
+		d.Servers[2].Health = ""
+	})
+	server, err := client.Server(context.Background(), "ms2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SHUTDOWN", server.State)
+
+	_, err = client.Server(context.Background(), "ms99")
+	assert.Error(t, err)
+}
+
+func TestCredentials(t *testing.T) {
+	fake := New(WithCredentials("weblogic", "welcome1"))
+	ts := fake.Start()
+	defer ts.Close()
+
+	_, err := remy.NewClient(fake.AdminServer(ts.URL)).Servers(context.Background(), remy.QueryOptions{})
+	assert.NoError(t, err)
+
+	_, err = remy.NewClient(&remy.AdminServer{AdminURL: ts.URL, Username: "weblogic", Password: "wrong"}).Servers(context.Background(), remy.QueryOptions{})
+	assert.Error(t, err)
+}
+
+func TestInjectedErrors(t *testing.T) {
+	fake := New(WithError("clusters", http.StatusServiceUnavailable))
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL))
+
+	_, err := client.Clusters(context.Background(), remy.QueryOptions{})
+	assert.Error(t, err)
+	_, err = client.Servers(context.Background(), remy.QueryOptions{})
+	assert.NoError(t, err)
+
+	fake.InjectError("*", http.StatusInternalServerError)
+	_, err = client.Servers(context.Background(), remy.QueryOptions{})
+	assert.Error(t, err)
+
+	fake.ClearErrors()
+	_, err = client.Clusters(context.Background(), remy.QueryOptions{})
+	assert.NoError(t, err)
+}
+
+func TestLatency(t *testing.T) {
+	fake := New(WithLatency(50 * time.Millisecond))
+	ts := fake.Start()
+	defer ts.Close()
+	client := remy.NewClient(fake.AdminServer(ts.URL), remy.WithTimeout(10*time.Millisecond))
+
+	_, err := client.Servers(context.Background(), remy.QueryOptions{})
+	assert.Error(t, err)
+}
+
+func TestLowerCamel(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out string
+	}{
+		{"JvmProcessorLoad", "jvmProcessorLoad"},
+		{"name", "name"},
+		{"VersionJDBCDriver", "versionJDBCDriver"},
+		{"RCLBBased", "rclbBased"},
+		{"ID", "id"},
+	}
+	for _, tt := range tests {
+		if got := lowerCamel(tt.in); got != tt.out {
+			t.Errorf("want %q, got %q", tt.out, got)
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	for _, version := range []string{DefaultVersion, "12.2.1.3.0", "14.1.1.0.0"} {
+		t.Run(version, func(t *testing.T) {
+			fake := New(WithVersion(version))
+			ts := fake.Start()
+			defer ts.Close()
+			client := remy.NewClient(fake.AdminServer(ts.URL))
+			ctx := context.Background()
+
+			caps, err := client.Capabilities(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, version, caps.Version.String())
+			assert.Equal(t, caps.Version.AtLeast(12, 2, 1), caps.Has(remy.WebLogic))
+			assert.Equal(t, !caps.Version.AtLeast(14, 1, 1), caps.Has(remy.TenantMonitoring))
+
+			servers, err := client.Servers(ctx, remy.QueryOptions{Full: true})
+			assert.NoError(t, err)
+			assert.Equal(t, fake.Domain().Servers, servers)
+
+			cluster, err := client.Cluster(ctx, "cluster1")
+			assert.NoError(t, err)
+			assert.Len(t, cluster.Servers, len(fake.Domain().Clusters[0].Servers))
+			for i := range cluster.Servers {
+				assert.Equal(t, fake.Domain().Clusters[0].Servers[i].Name, cluster.Servers[i].Name)
+				assert.Equal(t, fake.Domain().Clusters[0].Servers[i].FragmentsSentCount, cluster.Servers[i].FragmentsSentCount)
+			}
+
+			ds, err := client.DataSource(ctx, "ds1")
+			assert.NoError(t, err)
+			assert.Equal(t, fake.Domain().DataSources[0].Instances, ds.Instances)
+
+			app, err := client.Application(ctx, "app1")
+			assert.NoError(t, err)
+			assert.Equal(t, fake.Domain().Applications[0].WorkManagers, app.WorkManagers)
+		})
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	fake := New(WithVersion("12.2.1.3.0"), WithDomain(GenerateDomain(DomainConfig{ManagedServers: 2, Clusters: 1, Partitions: 2})))
+	ts := fake.Start()
+	defer ts.Close()
+	ctx := context.Background()
+
+	partitions, err := remy.NewClient(fake.AdminServer(ts.URL)).Partitions(ctx, remy.QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []remy.Partition{fake.Domain().Partitions[0].Partition, fake.Domain().Partitions[1].Partition}, partitions)
+
+	client := remy.NewClient(fake.AdminServer(ts.URL), remy.WithPartition("p2"))
+	servers, err := client.Servers(ctx, remy.QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+	apps, err := client.Applications(ctx, remy.QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Equal(t, fake.Domain().Partitions[1].Applications[0].WorkManagers, apps[0].WorkManagers)
+	dataSources, err := client.DataSources(ctx, remy.QueryOptions{Full: true})
+	assert.NoError(t, err)
+	assert.Equal(t, fake.Domain().Partitions[1].DataSources[0].Instances, dataSources[0].Instances)
+}
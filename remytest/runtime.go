@@ -0,0 +1,209 @@
+package remytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/klauern/remy"
+)
+
+// DefaultVersion is the WebLogic release a Server emulates unless given WithVersion.
+const DefaultVersion = "12.1.3.0.0"
+
+// WithVersion makes the Server emulate a WebLogic release, e.g., "12.2.1.3.0", serving the REST trees it has:
+// tenant-monitoring before 14.1.1, and remy.VersionPath, with its domainRuntime search, from 12.2.1.  Every server in
+// the Domain reports the release as its WebLogicVersion.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// serves reports whether the emulated release has the tree.
+func (s *Server) serves(tree remy.Tree) bool {
+	v, _ := remy.ParseVersion(s.version)
+	if tree == remy.WebLogic {
+		return v.AtLeast(12, 2, 1)
+	}
+	return !v.AtLeast(14, 1, 1)
+}
+
+// tenantMonitoring serves h only when the emulated release has the tenant-monitoring tree.
+func (s *Server) tenantMonitoring(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.serves(remy.TenantMonitoring) {
+			s.router.NotFoundHandler.ServeHTTP(w, r)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// latest answers remy.VersionPath with the emulated release.
+func (s *Server) latest(w http.ResponseWriter, r *http.Request) {
+	if !s.serves(remy.WebLogic) {
+		s.router.NotFoundHandler.ServeHTTP(w, r)
+		return
+	}
+	writeConfig(w, map[string]interface{}{"version": s.version, "isLatest": true, "lifecycle": "active"})
+}
+
+// runtimeSearch answers any search of the domainRuntime tree with every bean remy asks for: the life cycle of every
+// server, the run-time beans of the servers that are running, and, on 12.2.1.x, the domain's partitions.
+func (s *Server) runtimeSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.serves(remy.WebLogic) {
+		s.router.NotFoundHandler.ServeHTTP(w, r)
+		return
+	}
+	if r.Header.Get("X-Requested-By") == "" {
+		writeConfigError(w, http.StatusBadRequest, "X-Requested-By header is required")
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lifeCycles, runtimes := []interface{}{}, []interface{}{}
+	for _, srv := range s.domain.Servers {
+		lifeCycles = append(lifeCycles, map[string]interface{}{"name": srv.Name, "state": srv.State})
+		if running(srv.State) {
+			runtimes = append(runtimes, s.serverRuntime(srv))
+		}
+	}
+	result := map[string]interface{}{
+		"serverLifeCycleRuntimes": map[string]interface{}{"items": lifeCycles},
+		"serverRuntimes":          map[string]interface{}{"items": runtimes},
+	}
+	if s.servesPartitions() {
+		result["domainPartitionRuntimes"] = domainPartitionRuntimes(s.domain.Partitions)
+	}
+	writeConfig(w, result)
+}
+
+// running reports whether a server in the state has run-time beans.
+func running(state string) bool {
+	switch state {
+	case "SHUTDOWN", "FAILED", "UNKNOWN", "FAILED_NOT_RESTARTABLE":
+		return false
+	}
+	return true
+}
+
+// serverRuntime is the ServerRuntime bean of srv, with its JVM, cluster, data source and application run-times.
+func (s *Server) serverRuntime(srv remy.Server) map[string]interface{} {
+	rt := map[string]interface{}{
+		"name":                    srv.Name,
+		"state":                   srv.State,
+		"healthState":             healthState(srv.Health),
+		"currentMachine":          srv.CurrentMachine,
+		"weblogicVersion":         srv.WebLogicVersion,
+		"openSocketsCurrentCount": srv.OpenSocketsCurrentCount,
+		"JVMRuntime": map[string]interface{}{
+			"heapSizeCurrent": srv.HeapSizeCurrent,
+			"heapFreeCurrent": srv.HeapFreeCurrent,
+			"javaVersion":     srv.JavaVersion,
+			"OSName":          srv.OsName,
+			"OSVersion":       srv.OsVersion,
+			"processCpuLoad":  srv.JvmProcessorLoad,
+		},
+	}
+	for _, c := range s.domain.Clusters {
+		for _, m := range c.Servers {
+			if m.Name != srv.Name {
+				continue
+			}
+			rt["clusterRuntime"] = map[string]interface{}{
+				"name":                   c.Name,
+				"resendRequestsCount":    m.ResendRequestsCount,
+				"fragmentsSentCount":     m.FragmentsSentCount,
+				"fragmentsReceivedCount": m.FragmentsReceivedCount,
+			}
+			rt["serverMigrationRuntime"] = map[string]interface{}{"clusterMaster": m.IsClusterMaster}
+		}
+	}
+
+	rt["JDBCServiceRuntime"] = map[string]interface{}{"JDBCDataSourceRuntimeMBeans": dataSourceRuntimes(s.domain.DataSources, srv.Name)}
+	rt["applicationRuntimes"] = applicationRuntimes(s.domain.Applications, srv)
+	if s.servesPartitions() {
+		rt["partitionRuntimes"] = partitionRuntimes(s.domain.Partitions, srv)
+	}
+	return rt
+}
+
+// dataSourceRuntimes are the JDBCDataSourceRuntime beans of the instances of dataSources on a server.
+func dataSourceRuntimes(dataSources []remy.DataSource, server string) map[string]interface{} {
+	beans := []interface{}{}
+	for _, ds := range dataSources {
+		for _, inst := range ds.Instances {
+			if inst.Server == server {
+				bean := beanFields(inst)
+				delete(bean, "server")
+				delete(bean, "racInstances")
+				bean["name"] = ds.Name
+				beans = append(beans, bean)
+			}
+		}
+	}
+	return map[string]interface{}{"items": beans}
+}
+
+// applicationRuntimes are the ApplicationRuntime beans of the applications deployed to srv.
+func applicationRuntimes(applications []remy.Application, srv remy.Server) map[string]interface{} {
+	beans := []interface{}{}
+	for _, app := range applications {
+		if deployedTo(app, srv) {
+			beans = append(beans, applicationRuntime(app, srv.Name))
+		}
+	}
+	return map[string]interface{}{"items": beans}
+}
+
+// deployedTo reports whether app targets srv or its cluster.
+func deployedTo(app remy.Application, srv remy.Server) bool {
+	for _, t := range app.TargetStates {
+		if t.Target == srv.Name || (srv.ClusterName != "" && t.Target == srv.ClusterName) {
+			return true
+		}
+	}
+	return false
+}
+
+// applicationRuntime is the ApplicationRuntime bean of app on a server, with the work managers, constraints and
+// request classes the Domain has for that server.
+func applicationRuntime(app remy.Application, server string) map[string]interface{} {
+	onServer := func(items interface{}) map[string]interface{} {
+		beans := []interface{}{}
+		data, _ := json.Marshal(items)
+		var generic []map[string]interface{}
+		json.Unmarshal(data, &generic)
+		for _, item := range generic {
+			if item["Server"] == server {
+				bean := lowerCamelKeys(item).(map[string]interface{})
+				delete(bean, "server")
+				beans = append(beans, bean)
+			}
+		}
+		return map[string]interface{}{"items": beans}
+	}
+	return map[string]interface{}{
+		"name":                         app.Name,
+		"healthState":                  healthState(app.Health),
+		"workManagerRuntimes":          onServer(app.WorkManagers),
+		"minThreadsConstraintRuntimes": onServer(app.MinThreadsConstraints),
+		"maxThreadsConstraintRuntimes": onServer(app.MaxThreadsConstraints),
+		"requestClassRuntimes":         onServer(app.RequestClasses),
+	}
+}
+
+// healthState is how run-time beans write a tenant-monitoring health such as HEALTH_OK.
+func healthState(health string) map[string]interface{} {
+	return map[string]interface{}{"state": strings.ToLower(strings.TrimPrefix(health, "HEALTH_"))}
+}
+
+// beanFields is v with the lowerCamelCase keys of a bean.
+func beanFields(v interface{}) map[string]interface{} {
+	data, _ := json.Marshal(v)
+	var generic map[string]interface{}
+	json.Unmarshal(data, &generic)
+	return lowerCamelKeys(generic).(map[string]interface{})
+}
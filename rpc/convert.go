@@ -0,0 +1,152 @@
+package rpc
+
+import "github.com/klauern/remy"
+
+func newServer(s remy.Server) *Server {
+	return &Server{
+		Name:                    s.Name,
+		State:                   s.State,
+		Health:                  s.Health,
+		ClusterName:             s.ClusterName,
+		CurrentMachine:          s.CurrentMachine,
+		WeblogicVersion:         s.WebLogicVersion,
+		OpenSocketsCurrentCount: s.OpenSocketsCurrentCount,
+		HeapSizeCurrent:         int64(s.HeapSizeCurrent),
+		HeapFreeCurrent:         int64(s.HeapFreeCurrent),
+		JavaVersion:             s.JavaVersion,
+		OsName:                  s.OsName,
+		OsVersion:               s.OsVersion,
+		JvmProcessorLoad:        s.JvmProcessorLoad,
+	}
+}
+
+func newCluster(c remy.Cluster) *Cluster {
+	cluster := &Cluster{Name: c.Name}
+	for _, m := range c.Servers {
+		cluster.Servers = append(cluster.Servers, &ClusterServer{
+			Name:                   m.Name,
+			State:                  m.State,
+			Health:                 m.Health,
+			ClusterMaster:          m.IsClusterMaster,
+			DropOutFrequency:       m.DropOutFrequency,
+			ResendRequestsCount:    int64(m.ResendRequestsCount),
+			FragmentsSentCount:     int64(m.FragmentsSentCount),
+			FragmentsReceivedCount: int64(m.FragmentsReceivedCount),
+		})
+	}
+	return cluster
+}
+
+func newDataSource(d remy.DataSource) *DataSource {
+	ds := &DataSource{Name: d.Name, Type: d.Type}
+	for _, inst := range d.Instances {
+		ds.Instances = append(ds.Instances, newDataSourceInstance(inst))
+	}
+	return ds
+}
+
+func newDataSourceInstance(i remy.DataSourceInstance) *DataSourceInstance {
+	inst := &DataSourceInstance{
+		Server:                             i.Server,
+		State:                              i.State,
+		Enabled:                            i.Enabled,
+		VersionJdbcDriver:                  i.VersionJDBCDriver,
+		ActiveConnectionsAverageCount:      int64(i.ActiveConnectionsAverageCount),
+		ActiveConnectionsCurrentCount:      int64(i.ActiveConnectionsCurrentCount),
+		ActiveConnectionsHighCount:         int64(i.ActiveConnectionsHighCount),
+		ConnectionDelayTime:                int64(i.ConnectionDelayTime),
+		ConnectionsTotalCount:              int64(i.ConnectionsTotalCount),
+		CurrCapacity:                       int64(i.CurrCapacity),
+		CurrCapacityHighCount:              int64(i.CurrCapacityHighCount),
+		FailedReserveRequestCount:          int64(i.FailedReserveRequestCount),
+		FailuresToReconnectCount:           int64(i.FailuresToReconnectCount),
+		HighestNumAvailable:                int64(i.HighestNumAvailable),
+		LeakedConnectionCount:              int64(i.LeakedConnectionCount),
+		NumAvailable:                       int64(i.NumAvailable),
+		NumUnavailable:                     int64(i.NumUnavailable),
+		PrepStmtCacheAccessCount:           int64(i.PrepStmtCacheAccessCount),
+		PrepStmtCacheAddCount:              int64(i.PrepStmtCacheAddCount),
+		PrepStmtCacheCurrentSize:           int64(i.PrepStmtCacheCurrentSize),
+		PrepStmtCacheDeleteCount:           int64(i.PrepStmtCacheDeleteCount),
+		PrepStmtCacheHitCount:              int64(i.PrepStmtCacheHitCount),
+		PrepStmtCacheMissCount:             int64(i.PrepStmtCacheMissCount),
+		ReserveRequestCount:                int64(i.ReserveRequestCount),
+		WaitSecondsHighCount:               int64(i.WaitSecondsHighCount),
+		WaitingForConnectionCurrentCount:   int64(i.WaitingForConnectionCurrentCount),
+		WaitingForConnectionFailureTotal:   int64(i.WaitingForConnectionFailureTotal),
+		WaitingForConnectionHighCount:      int64(i.WaitingForConnectionHighCount),
+		WaitingForConnectionSuccessTotal:   int64(i.WaitingForConnectionSuccessTotal),
+		WaitingForConnectionTotal:          int64(i.WaitingForConnectionTotal),
+		SuccessfulRclbBasedBorrowCount:     int64(i.SuccessfulRCLBBasedBorrowCount),
+		FailedRclbBasedBorrowCount:         int64(i.FailedRCLBBasedBorrowCount),
+		SuccessfulAffinityBasedBorrowCount: int64(i.SuccessfulAffinityBasedBorrowCount),
+		FailedAffinityBasedBorrowCount:     int64(i.FailedAffinityBasedBorrowCount),
+	}
+	for _, rac := range i.RacInstances {
+		inst.RacInstances = append(inst.RacInstances, &RacInstance{
+			InstanceName:                  rac.InstanceName,
+			State:                         rac.State,
+			Enabled:                       rac.Enabled,
+			Signature:                     rac.Signature,
+			CurrentWeight:                 int64(rac.CurrentWeight),
+			ActiveConnectionsCurrentCount: int64(rac.ActiveConnectionsCurrentCount),
+			ReserveRequestCount:           int64(rac.ReserveRequestCount),
+			ConnectionsTotalCount:         int64(rac.ConnectionsTotalCount),
+			CurrCapacity:                  int64(rac.CurrCapacity),
+			NumAvailable:                  int64(rac.NumAvailable),
+			NumUnavailable:                int64(rac.NumUnavailable),
+		})
+	}
+	return inst
+}
+
+func newApplication(a remy.Application) *Application {
+	app := &Application{Name: a.Name, Type: a.AppType, State: a.State, Health: a.Health}
+	for _, t := range a.TargetStates {
+		app.TargetStates = append(app.TargetStates, &TargetState{Target: t.Target, State: t.State})
+	}
+	for _, ds := range a.DataSources {
+		app.DataSources = append(app.DataSources, &AppDataSource{Name: ds.Name, Server: ds.Server, State: ds.State})
+	}
+	for _, wm := range a.WorkManagers {
+		app.WorkManagers = append(app.WorkManagers, &WorkManager{
+			Name:              wm.Name,
+			Server:            wm.Server,
+			PendingRequests:   int64(wm.PendingRequests),
+			CompletedRequests: int64(wm.CompletedRequests),
+		})
+	}
+	for _, c := range a.MinThreadsConstraints {
+		app.MinThreadsConstraints = append(app.MinThreadsConstraints, &MinThreadsConstraint{
+			Name:                     c.Name,
+			Server:                   c.Server,
+			PendingRequests:          int64(c.PendingRequests),
+			CompletedRequests:        int64(c.CompletedRequests),
+			ExecutingRequests:        int64(c.ExecutingRequests),
+			OutOfOrderExecutionCount: int64(c.OutOfOrderExecutionCount),
+			MustRunCount:             int64(c.MustRunCount),
+			MaxWaitTime:              int64(c.MaxWaitTime),
+			CurrentWaitTime:          int64(c.CurrentWaitTime),
+		})
+	}
+	for _, c := range a.MaxThreadsConstraints {
+		app.MaxThreadsConstraints = append(app.MaxThreadsConstraints, &MaxThreadsConstraint{
+			Name:              c.Name,
+			Server:            c.Server,
+			ExecutingRequests: int64(c.ExecutingRequests),
+			DeferredRequests:  int64(c.DeferredRequests),
+		})
+	}
+	for _, rc := range a.RequestClasses {
+		app.RequestClasses = append(app.RequestClasses, &RequestClass{
+			Name:                 rc.Name,
+			Server:               rc.Server,
+			RequestClassType:     rc.RequestClassType,
+			CompletedCount:       int64(rc.CompletedCount),
+			TotalThreadUse:       int64(rc.TotalThreadUse),
+			PendingRequestCount:  int64(rc.PendingRequestCount),
+			VirtualTimeIncrement: int64(rc.VirtualTimeIncrement),
+		})
+	}
+	return app
+}
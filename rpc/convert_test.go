@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDataSource(t *testing.T) {
+	ds := newDataSource(remy.DataSource{Name: "ds1", Type: "Generic", Instances: []remy.DataSourceInstance{{
+		Server:                         "ms1",
+		Enabled:                        true,
+		WaitingForConnectionTotal:      7,
+		SuccessfulRCLBBasedBorrowCount: 3,
+		RacInstances:                   []remy.RacInstance{{InstanceName: "rac1", CurrentWeight: 50}},
+	}}})
+	assert.Equal(t, "Generic", ds.GetType())
+	inst := ds.GetInstances()[0]
+	assert.True(t, inst.GetEnabled())
+	assert.Equal(t, int64(7), inst.GetWaitingForConnectionTotal())
+	assert.Equal(t, int64(3), inst.GetSuccessfulRclbBasedBorrowCount())
+	assert.Equal(t, "rac1", inst.GetRacInstances()[0].GetInstanceName())
+	assert.Equal(t, int64(50), inst.GetRacInstances()[0].GetCurrentWeight())
+}
+
+func TestNewApplication(t *testing.T) {
+	app := newApplication(remy.Application{
+		Name:                  "app1",
+		AppType:               "ear",
+		TargetStates:          []remy.TargetState{{Target: "cluster1", State: "STATE_ACTIVE"}},
+		MaxThreadsConstraints: []remy.MaxThreadsConstraint{{Name: "max", Server: "ms1", DeferredRequests: 4}},
+	})
+	assert.Equal(t, "ear", app.GetType())
+	assert.Equal(t, "cluster1", app.GetTargetStates()[0].GetTarget())
+	assert.Equal(t, int64(4), app.GetMaxThreadsConstraints()[0].GetDeferredRequests())
+}
@@ -0,0 +1,2202 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: remy.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Change_Type int32
+
+const (
+	Change_TYPE_UNSPECIFIED Change_Type = 0
+	Change_ADDED            Change_Type = 1
+	Change_MODIFIED         Change_Type = 2
+	Change_REMOVED          Change_Type = 3
+)
+
+// Enum value maps for Change_Type.
+var (
+	Change_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "ADDED",
+		2: "MODIFIED",
+		3: "REMOVED",
+	}
+	Change_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"ADDED":            1,
+		"MODIFIED":         2,
+		"REMOVED":          3,
+	}
+)
+
+func (x Change_Type) Enum() *Change_Type {
+	p := new(Change_Type)
+	*p = x
+	return p
+}
+
+func (x Change_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Change_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_remy_proto_enumTypes[0].Descriptor()
+}
+
+func (Change_Type) Type() protoreflect.EnumType {
+	return &file_remy_proto_enumTypes[0]
+}
+
+func (x Change_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Change_Type.Descriptor instead.
+func (Change_Type) EnumDescriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{7, 0}
+}
+
+// GetRequest names the resource to get, which is always in full format.
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_remy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListRequest lists every resource of a kind, with every field when full_format is set.
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FullFormat    bool                   `protobuf:"varint,1,opt,name=full_format,json=fullFormat,proto3" json:"full_format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_remy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRequest) GetFullFormat() bool {
+	if x != nil {
+		return x.FullFormat
+	}
+	return false
+}
+
+type ListServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Servers       []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServersResponse) Reset() {
+	*x = ListServersResponse{}
+	mi := &file_remy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServersResponse) ProtoMessage() {}
+
+func (x *ListServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServersResponse.ProtoReflect.Descriptor instead.
+func (*ListServersResponse) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListServersResponse) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type ListClustersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []*Cluster             `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersResponse) Reset() {
+	*x = ListClustersResponse{}
+	mi := &file_remy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersResponse) ProtoMessage() {}
+
+func (x *ListClustersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersResponse.ProtoReflect.Descriptor instead.
+func (*ListClustersResponse) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListClustersResponse) GetClusters() []*Cluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type ListDataSourcesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataSources   []*DataSource          `protobuf:"bytes,1,rep,name=data_sources,json=dataSources,proto3" json:"data_sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDataSourcesResponse) Reset() {
+	*x = ListDataSourcesResponse{}
+	mi := &file_remy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDataSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDataSourcesResponse) ProtoMessage() {}
+
+func (x *ListDataSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDataSourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListDataSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListDataSourcesResponse) GetDataSources() []*DataSource {
+	if x != nil {
+		return x.DataSources
+	}
+	return nil
+}
+
+type ListApplicationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applications  []*Application         `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsResponse) Reset() {
+	*x = ListApplicationsResponse{}
+	mi := &file_remy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsResponse) ProtoMessage() {}
+
+func (x *ListApplicationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsResponse.ProtoReflect.Descriptor instead.
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListApplicationsResponse) GetApplications() []*Application {
+	if x != nil {
+		return x.Applications
+	}
+	return nil
+}
+
+// WatchRequest chooses what is watched, and how often the domain is polled.
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resources to watch: servers, clusters, datasources or applications.  Blank watches them all.
+	Resources []string `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	// Interval between polls, 10s when not set.
+	Interval      *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_remy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchRequest) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+// Change is a resource added, modified or removed between two polls.
+type Change struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  Change_Type            `protobuf:"varint,1,opt,name=type,proto3,enum=remy.v1.Change_Type" json:"type,omitempty"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Resource is servers, clusters, datasources or applications.
+	Resource string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Name     string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// The resource as it is now, or as it was last seen when REMOVED.
+	//
+	// Types that are valid to be assigned to Value:
+	//
+	//	*Change_Server
+	//	*Change_Cluster
+	//	*Change_DataSource
+	//	*Change_Application
+	Value         isChange_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_remy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Change) GetType() Change_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Change_TYPE_UNSPECIFIED
+}
+
+func (x *Change) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Change) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Change) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Change) GetValue() isChange_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Change) GetServer() *Server {
+	if x != nil {
+		if x, ok := x.Value.(*Change_Server); ok {
+			return x.Server
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetCluster() *Cluster {
+	if x != nil {
+		if x, ok := x.Value.(*Change_Cluster); ok {
+			return x.Cluster
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetDataSource() *DataSource {
+	if x != nil {
+		if x, ok := x.Value.(*Change_DataSource); ok {
+			return x.DataSource
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetApplication() *Application {
+	if x != nil {
+		if x, ok := x.Value.(*Change_Application); ok {
+			return x.Application
+		}
+	}
+	return nil
+}
+
+type isChange_Value interface {
+	isChange_Value()
+}
+
+type Change_Server struct {
+	Server *Server `protobuf:"bytes,5,opt,name=server,proto3,oneof"`
+}
+
+type Change_Cluster struct {
+	Cluster *Cluster `protobuf:"bytes,6,opt,name=cluster,proto3,oneof"`
+}
+
+type Change_DataSource struct {
+	DataSource *DataSource `protobuf:"bytes,7,opt,name=data_source,json=dataSource,proto3,oneof"`
+}
+
+type Change_Application struct {
+	Application *Application `protobuf:"bytes,8,opt,name=application,proto3,oneof"`
+}
+
+func (*Change_Server) isChange_Value() {}
+
+func (*Change_Cluster) isChange_Value() {}
+
+func (*Change_DataSource) isChange_Value() {}
+
+func (*Change_Application) isChange_Value() {}
+
+type Server struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Name                    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State                   string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Health                  string                 `protobuf:"bytes,3,opt,name=health,proto3" json:"health,omitempty"`
+	ClusterName             string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	CurrentMachine          string                 `protobuf:"bytes,5,opt,name=current_machine,json=currentMachine,proto3" json:"current_machine,omitempty"`
+	WeblogicVersion         string                 `protobuf:"bytes,6,opt,name=weblogic_version,json=weblogicVersion,proto3" json:"weblogic_version,omitempty"`
+	OpenSocketsCurrentCount float64                `protobuf:"fixed64,7,opt,name=open_sockets_current_count,json=openSocketsCurrentCount,proto3" json:"open_sockets_current_count,omitempty"`
+	HeapSizeCurrent         int64                  `protobuf:"varint,8,opt,name=heap_size_current,json=heapSizeCurrent,proto3" json:"heap_size_current,omitempty"`
+	HeapFreeCurrent         int64                  `protobuf:"varint,9,opt,name=heap_free_current,json=heapFreeCurrent,proto3" json:"heap_free_current,omitempty"`
+	JavaVersion             string                 `protobuf:"bytes,10,opt,name=java_version,json=javaVersion,proto3" json:"java_version,omitempty"`
+	OsName                  string                 `protobuf:"bytes,11,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
+	OsVersion               string                 `protobuf:"bytes,12,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	JvmProcessorLoad        float64                `protobuf:"fixed64,13,opt,name=jvm_processor_load,json=jvmProcessorLoad,proto3" json:"jvm_processor_load,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	mi := &file_remy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Server) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Server) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Server) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *Server) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *Server) GetCurrentMachine() string {
+	if x != nil {
+		return x.CurrentMachine
+	}
+	return ""
+}
+
+func (x *Server) GetWeblogicVersion() string {
+	if x != nil {
+		return x.WeblogicVersion
+	}
+	return ""
+}
+
+func (x *Server) GetOpenSocketsCurrentCount() float64 {
+	if x != nil {
+		return x.OpenSocketsCurrentCount
+	}
+	return 0
+}
+
+func (x *Server) GetHeapSizeCurrent() int64 {
+	if x != nil {
+		return x.HeapSizeCurrent
+	}
+	return 0
+}
+
+func (x *Server) GetHeapFreeCurrent() int64 {
+	if x != nil {
+		return x.HeapFreeCurrent
+	}
+	return 0
+}
+
+func (x *Server) GetJavaVersion() string {
+	if x != nil {
+		return x.JavaVersion
+	}
+	return ""
+}
+
+func (x *Server) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *Server) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *Server) GetJvmProcessorLoad() float64 {
+	if x != nil {
+		return x.JvmProcessorLoad
+	}
+	return 0
+}
+
+type Cluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Servers       []*ClusterServer       `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cluster) Reset() {
+	*x = Cluster{}
+	mi := &file_remy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cluster) ProtoMessage() {}
+
+func (x *Cluster) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cluster.ProtoReflect.Descriptor instead.
+func (*Cluster) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Cluster) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cluster) GetServers() []*ClusterServer {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type ClusterServer struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Name                   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State                  string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Health                 string                 `protobuf:"bytes,3,opt,name=health,proto3" json:"health,omitempty"`
+	ClusterMaster          bool                   `protobuf:"varint,4,opt,name=cluster_master,json=clusterMaster,proto3" json:"cluster_master,omitempty"`
+	DropOutFrequency       string                 `protobuf:"bytes,5,opt,name=drop_out_frequency,json=dropOutFrequency,proto3" json:"drop_out_frequency,omitempty"`
+	ResendRequestsCount    int64                  `protobuf:"varint,6,opt,name=resend_requests_count,json=resendRequestsCount,proto3" json:"resend_requests_count,omitempty"`
+	FragmentsSentCount     int64                  `protobuf:"varint,7,opt,name=fragments_sent_count,json=fragmentsSentCount,proto3" json:"fragments_sent_count,omitempty"`
+	FragmentsReceivedCount int64                  `protobuf:"varint,8,opt,name=fragments_received_count,json=fragmentsReceivedCount,proto3" json:"fragments_received_count,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ClusterServer) Reset() {
+	*x = ClusterServer{}
+	mi := &file_remy_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterServer) ProtoMessage() {}
+
+func (x *ClusterServer) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterServer.ProtoReflect.Descriptor instead.
+func (*ClusterServer) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClusterServer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClusterServer) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ClusterServer) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *ClusterServer) GetClusterMaster() bool {
+	if x != nil {
+		return x.ClusterMaster
+	}
+	return false
+}
+
+func (x *ClusterServer) GetDropOutFrequency() string {
+	if x != nil {
+		return x.DropOutFrequency
+	}
+	return ""
+}
+
+func (x *ClusterServer) GetResendRequestsCount() int64 {
+	if x != nil {
+		return x.ResendRequestsCount
+	}
+	return 0
+}
+
+func (x *ClusterServer) GetFragmentsSentCount() int64 {
+	if x != nil {
+		return x.FragmentsSentCount
+	}
+	return 0
+}
+
+func (x *ClusterServer) GetFragmentsReceivedCount() int64 {
+	if x != nil {
+		return x.FragmentsReceivedCount
+	}
+	return 0
+}
+
+type DataSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Instances     []*DataSourceInstance  `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataSource) Reset() {
+	*x = DataSource{}
+	mi := &file_remy_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSource) ProtoMessage() {}
+
+func (x *DataSource) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSource.ProtoReflect.Descriptor instead.
+func (*DataSource) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DataSource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataSource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DataSource) GetInstances() []*DataSourceInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type DataSourceInstance struct {
+	state                              protoimpl.MessageState `protogen:"open.v1"`
+	Server                             string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	State                              string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Enabled                            bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	VersionJdbcDriver                  string                 `protobuf:"bytes,4,opt,name=version_jdbc_driver,json=versionJdbcDriver,proto3" json:"version_jdbc_driver,omitempty"`
+	ActiveConnectionsAverageCount      int64                  `protobuf:"varint,5,opt,name=active_connections_average_count,json=activeConnectionsAverageCount,proto3" json:"active_connections_average_count,omitempty"`
+	ActiveConnectionsCurrentCount      int64                  `protobuf:"varint,6,opt,name=active_connections_current_count,json=activeConnectionsCurrentCount,proto3" json:"active_connections_current_count,omitempty"`
+	ActiveConnectionsHighCount         int64                  `protobuf:"varint,7,opt,name=active_connections_high_count,json=activeConnectionsHighCount,proto3" json:"active_connections_high_count,omitempty"`
+	ConnectionDelayTime                int64                  `protobuf:"varint,8,opt,name=connection_delay_time,json=connectionDelayTime,proto3" json:"connection_delay_time,omitempty"`
+	ConnectionsTotalCount              int64                  `protobuf:"varint,9,opt,name=connections_total_count,json=connectionsTotalCount,proto3" json:"connections_total_count,omitempty"`
+	CurrCapacity                       int64                  `protobuf:"varint,10,opt,name=curr_capacity,json=currCapacity,proto3" json:"curr_capacity,omitempty"`
+	CurrCapacityHighCount              int64                  `protobuf:"varint,11,opt,name=curr_capacity_high_count,json=currCapacityHighCount,proto3" json:"curr_capacity_high_count,omitempty"`
+	FailedReserveRequestCount          int64                  `protobuf:"varint,12,opt,name=failed_reserve_request_count,json=failedReserveRequestCount,proto3" json:"failed_reserve_request_count,omitempty"`
+	FailuresToReconnectCount           int64                  `protobuf:"varint,13,opt,name=failures_to_reconnect_count,json=failuresToReconnectCount,proto3" json:"failures_to_reconnect_count,omitempty"`
+	HighestNumAvailable                int64                  `protobuf:"varint,14,opt,name=highest_num_available,json=highestNumAvailable,proto3" json:"highest_num_available,omitempty"`
+	LeakedConnectionCount              int64                  `protobuf:"varint,15,opt,name=leaked_connection_count,json=leakedConnectionCount,proto3" json:"leaked_connection_count,omitempty"`
+	NumAvailable                       int64                  `protobuf:"varint,16,opt,name=num_available,json=numAvailable,proto3" json:"num_available,omitempty"`
+	NumUnavailable                     int64                  `protobuf:"varint,17,opt,name=num_unavailable,json=numUnavailable,proto3" json:"num_unavailable,omitempty"`
+	PrepStmtCacheAccessCount           int64                  `protobuf:"varint,18,opt,name=prep_stmt_cache_access_count,json=prepStmtCacheAccessCount,proto3" json:"prep_stmt_cache_access_count,omitempty"`
+	PrepStmtCacheAddCount              int64                  `protobuf:"varint,19,opt,name=prep_stmt_cache_add_count,json=prepStmtCacheAddCount,proto3" json:"prep_stmt_cache_add_count,omitempty"`
+	PrepStmtCacheCurrentSize           int64                  `protobuf:"varint,20,opt,name=prep_stmt_cache_current_size,json=prepStmtCacheCurrentSize,proto3" json:"prep_stmt_cache_current_size,omitempty"`
+	PrepStmtCacheDeleteCount           int64                  `protobuf:"varint,21,opt,name=prep_stmt_cache_delete_count,json=prepStmtCacheDeleteCount,proto3" json:"prep_stmt_cache_delete_count,omitempty"`
+	PrepStmtCacheHitCount              int64                  `protobuf:"varint,22,opt,name=prep_stmt_cache_hit_count,json=prepStmtCacheHitCount,proto3" json:"prep_stmt_cache_hit_count,omitempty"`
+	PrepStmtCacheMissCount             int64                  `protobuf:"varint,23,opt,name=prep_stmt_cache_miss_count,json=prepStmtCacheMissCount,proto3" json:"prep_stmt_cache_miss_count,omitempty"`
+	ReserveRequestCount                int64                  `protobuf:"varint,24,opt,name=reserve_request_count,json=reserveRequestCount,proto3" json:"reserve_request_count,omitempty"`
+	WaitSecondsHighCount               int64                  `protobuf:"varint,25,opt,name=wait_seconds_high_count,json=waitSecondsHighCount,proto3" json:"wait_seconds_high_count,omitempty"`
+	WaitingForConnectionCurrentCount   int64                  `protobuf:"varint,26,opt,name=waiting_for_connection_current_count,json=waitingForConnectionCurrentCount,proto3" json:"waiting_for_connection_current_count,omitempty"`
+	WaitingForConnectionFailureTotal   int64                  `protobuf:"varint,27,opt,name=waiting_for_connection_failure_total,json=waitingForConnectionFailureTotal,proto3" json:"waiting_for_connection_failure_total,omitempty"`
+	WaitingForConnectionHighCount      int64                  `protobuf:"varint,28,opt,name=waiting_for_connection_high_count,json=waitingForConnectionHighCount,proto3" json:"waiting_for_connection_high_count,omitempty"`
+	WaitingForConnectionSuccessTotal   int64                  `protobuf:"varint,29,opt,name=waiting_for_connection_success_total,json=waitingForConnectionSuccessTotal,proto3" json:"waiting_for_connection_success_total,omitempty"`
+	WaitingForConnectionTotal          int64                  `protobuf:"varint,30,opt,name=waiting_for_connection_total,json=waitingForConnectionTotal,proto3" json:"waiting_for_connection_total,omitempty"`
+	SuccessfulRclbBasedBorrowCount     int64                  `protobuf:"varint,31,opt,name=successful_rclb_based_borrow_count,json=successfulRclbBasedBorrowCount,proto3" json:"successful_rclb_based_borrow_count,omitempty"`
+	FailedRclbBasedBorrowCount         int64                  `protobuf:"varint,32,opt,name=failed_rclb_based_borrow_count,json=failedRclbBasedBorrowCount,proto3" json:"failed_rclb_based_borrow_count,omitempty"`
+	SuccessfulAffinityBasedBorrowCount int64                  `protobuf:"varint,33,opt,name=successful_affinity_based_borrow_count,json=successfulAffinityBasedBorrowCount,proto3" json:"successful_affinity_based_borrow_count,omitempty"`
+	FailedAffinityBasedBorrowCount     int64                  `protobuf:"varint,34,opt,name=failed_affinity_based_borrow_count,json=failedAffinityBasedBorrowCount,proto3" json:"failed_affinity_based_borrow_count,omitempty"`
+	RacInstances                       []*RacInstance         `protobuf:"bytes,35,rep,name=rac_instances,json=racInstances,proto3" json:"rac_instances,omitempty"`
+	unknownFields                      protoimpl.UnknownFields
+	sizeCache                          protoimpl.SizeCache
+}
+
+func (x *DataSourceInstance) Reset() {
+	*x = DataSourceInstance{}
+	mi := &file_remy_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataSourceInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSourceInstance) ProtoMessage() {}
+
+func (x *DataSourceInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSourceInstance.ProtoReflect.Descriptor instead.
+func (*DataSourceInstance) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DataSourceInstance) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *DataSourceInstance) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *DataSourceInstance) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *DataSourceInstance) GetVersionJdbcDriver() string {
+	if x != nil {
+		return x.VersionJdbcDriver
+	}
+	return ""
+}
+
+func (x *DataSourceInstance) GetActiveConnectionsAverageCount() int64 {
+	if x != nil {
+		return x.ActiveConnectionsAverageCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetActiveConnectionsCurrentCount() int64 {
+	if x != nil {
+		return x.ActiveConnectionsCurrentCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetActiveConnectionsHighCount() int64 {
+	if x != nil {
+		return x.ActiveConnectionsHighCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetConnectionDelayTime() int64 {
+	if x != nil {
+		return x.ConnectionDelayTime
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetConnectionsTotalCount() int64 {
+	if x != nil {
+		return x.ConnectionsTotalCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetCurrCapacity() int64 {
+	if x != nil {
+		return x.CurrCapacity
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetCurrCapacityHighCount() int64 {
+	if x != nil {
+		return x.CurrCapacityHighCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetFailedReserveRequestCount() int64 {
+	if x != nil {
+		return x.FailedReserveRequestCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetFailuresToReconnectCount() int64 {
+	if x != nil {
+		return x.FailuresToReconnectCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetHighestNumAvailable() int64 {
+	if x != nil {
+		return x.HighestNumAvailable
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetLeakedConnectionCount() int64 {
+	if x != nil {
+		return x.LeakedConnectionCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetNumAvailable() int64 {
+	if x != nil {
+		return x.NumAvailable
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetNumUnavailable() int64 {
+	if x != nil {
+		return x.NumUnavailable
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheAccessCount() int64 {
+	if x != nil {
+		return x.PrepStmtCacheAccessCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheAddCount() int64 {
+	if x != nil {
+		return x.PrepStmtCacheAddCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheCurrentSize() int64 {
+	if x != nil {
+		return x.PrepStmtCacheCurrentSize
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheDeleteCount() int64 {
+	if x != nil {
+		return x.PrepStmtCacheDeleteCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheHitCount() int64 {
+	if x != nil {
+		return x.PrepStmtCacheHitCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetPrepStmtCacheMissCount() int64 {
+	if x != nil {
+		return x.PrepStmtCacheMissCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetReserveRequestCount() int64 {
+	if x != nil {
+		return x.ReserveRequestCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitSecondsHighCount() int64 {
+	if x != nil {
+		return x.WaitSecondsHighCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitingForConnectionCurrentCount() int64 {
+	if x != nil {
+		return x.WaitingForConnectionCurrentCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitingForConnectionFailureTotal() int64 {
+	if x != nil {
+		return x.WaitingForConnectionFailureTotal
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitingForConnectionHighCount() int64 {
+	if x != nil {
+		return x.WaitingForConnectionHighCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitingForConnectionSuccessTotal() int64 {
+	if x != nil {
+		return x.WaitingForConnectionSuccessTotal
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetWaitingForConnectionTotal() int64 {
+	if x != nil {
+		return x.WaitingForConnectionTotal
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetSuccessfulRclbBasedBorrowCount() int64 {
+	if x != nil {
+		return x.SuccessfulRclbBasedBorrowCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetFailedRclbBasedBorrowCount() int64 {
+	if x != nil {
+		return x.FailedRclbBasedBorrowCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetSuccessfulAffinityBasedBorrowCount() int64 {
+	if x != nil {
+		return x.SuccessfulAffinityBasedBorrowCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetFailedAffinityBasedBorrowCount() int64 {
+	if x != nil {
+		return x.FailedAffinityBasedBorrowCount
+	}
+	return 0
+}
+
+func (x *DataSourceInstance) GetRacInstances() []*RacInstance {
+	if x != nil {
+		return x.RacInstances
+	}
+	return nil
+}
+
+type RacInstance struct {
+	state                         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceName                  string                 `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	State                         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Enabled                       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Signature                     string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	CurrentWeight                 int64                  `protobuf:"varint,5,opt,name=current_weight,json=currentWeight,proto3" json:"current_weight,omitempty"`
+	ActiveConnectionsCurrentCount int64                  `protobuf:"varint,6,opt,name=active_connections_current_count,json=activeConnectionsCurrentCount,proto3" json:"active_connections_current_count,omitempty"`
+	ReserveRequestCount           int64                  `protobuf:"varint,7,opt,name=reserve_request_count,json=reserveRequestCount,proto3" json:"reserve_request_count,omitempty"`
+	ConnectionsTotalCount         int64                  `protobuf:"varint,8,opt,name=connections_total_count,json=connectionsTotalCount,proto3" json:"connections_total_count,omitempty"`
+	CurrCapacity                  int64                  `protobuf:"varint,9,opt,name=curr_capacity,json=currCapacity,proto3" json:"curr_capacity,omitempty"`
+	NumAvailable                  int64                  `protobuf:"varint,10,opt,name=num_available,json=numAvailable,proto3" json:"num_available,omitempty"`
+	NumUnavailable                int64                  `protobuf:"varint,11,opt,name=num_unavailable,json=numUnavailable,proto3" json:"num_unavailable,omitempty"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *RacInstance) Reset() {
+	*x = RacInstance{}
+	mi := &file_remy_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RacInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RacInstance) ProtoMessage() {}
+
+func (x *RacInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RacInstance.ProtoReflect.Descriptor instead.
+func (*RacInstance) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RacInstance) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *RacInstance) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *RacInstance) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RacInstance) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *RacInstance) GetCurrentWeight() int64 {
+	if x != nil {
+		return x.CurrentWeight
+	}
+	return 0
+}
+
+func (x *RacInstance) GetActiveConnectionsCurrentCount() int64 {
+	if x != nil {
+		return x.ActiveConnectionsCurrentCount
+	}
+	return 0
+}
+
+func (x *RacInstance) GetReserveRequestCount() int64 {
+	if x != nil {
+		return x.ReserveRequestCount
+	}
+	return 0
+}
+
+func (x *RacInstance) GetConnectionsTotalCount() int64 {
+	if x != nil {
+		return x.ConnectionsTotalCount
+	}
+	return 0
+}
+
+func (x *RacInstance) GetCurrCapacity() int64 {
+	if x != nil {
+		return x.CurrCapacity
+	}
+	return 0
+}
+
+func (x *RacInstance) GetNumAvailable() int64 {
+	if x != nil {
+		return x.NumAvailable
+	}
+	return 0
+}
+
+func (x *RacInstance) GetNumUnavailable() int64 {
+	if x != nil {
+		return x.NumUnavailable
+	}
+	return 0
+}
+
+type Application struct {
+	state                 protoimpl.MessageState  `protogen:"open.v1"`
+	Name                  string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                  string                  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State                 string                  `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Health                string                  `protobuf:"bytes,4,opt,name=health,proto3" json:"health,omitempty"`
+	TargetStates          []*TargetState          `protobuf:"bytes,5,rep,name=target_states,json=targetStates,proto3" json:"target_states,omitempty"`
+	DataSources           []*AppDataSource        `protobuf:"bytes,6,rep,name=data_sources,json=dataSources,proto3" json:"data_sources,omitempty"`
+	WorkManagers          []*WorkManager          `protobuf:"bytes,7,rep,name=work_managers,json=workManagers,proto3" json:"work_managers,omitempty"`
+	MinThreadsConstraints []*MinThreadsConstraint `protobuf:"bytes,8,rep,name=min_threads_constraints,json=minThreadsConstraints,proto3" json:"min_threads_constraints,omitempty"`
+	MaxThreadsConstraints []*MaxThreadsConstraint `protobuf:"bytes,9,rep,name=max_threads_constraints,json=maxThreadsConstraints,proto3" json:"max_threads_constraints,omitempty"`
+	RequestClasses        []*RequestClass         `protobuf:"bytes,10,rep,name=request_classes,json=requestClasses,proto3" json:"request_classes,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Application) Reset() {
+	*x = Application{}
+	mi := &file_remy_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Application) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Application) ProtoMessage() {}
+
+func (x *Application) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Application.ProtoReflect.Descriptor instead.
+func (*Application) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Application) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Application) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Application) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Application) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *Application) GetTargetStates() []*TargetState {
+	if x != nil {
+		return x.TargetStates
+	}
+	return nil
+}
+
+func (x *Application) GetDataSources() []*AppDataSource {
+	if x != nil {
+		return x.DataSources
+	}
+	return nil
+}
+
+func (x *Application) GetWorkManagers() []*WorkManager {
+	if x != nil {
+		return x.WorkManagers
+	}
+	return nil
+}
+
+func (x *Application) GetMinThreadsConstraints() []*MinThreadsConstraint {
+	if x != nil {
+		return x.MinThreadsConstraints
+	}
+	return nil
+}
+
+func (x *Application) GetMaxThreadsConstraints() []*MaxThreadsConstraint {
+	if x != nil {
+		return x.MaxThreadsConstraints
+	}
+	return nil
+}
+
+func (x *Application) GetRequestClasses() []*RequestClass {
+	if x != nil {
+		return x.RequestClasses
+	}
+	return nil
+}
+
+type TargetState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetState) Reset() {
+	*x = TargetState{}
+	mi := &file_remy_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetState) ProtoMessage() {}
+
+func (x *TargetState) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetState.ProtoReflect.Descriptor instead.
+func (*TargetState) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TargetState) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TargetState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type AppDataSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppDataSource) Reset() {
+	*x = AppDataSource{}
+	mi := &file_remy_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppDataSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppDataSource) ProtoMessage() {}
+
+func (x *AppDataSource) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppDataSource.ProtoReflect.Descriptor instead.
+func (*AppDataSource) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AppDataSource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppDataSource) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *AppDataSource) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type WorkManager struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Server            string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	PendingRequests   int64                  `protobuf:"varint,3,opt,name=pending_requests,json=pendingRequests,proto3" json:"pending_requests,omitempty"`
+	CompletedRequests int64                  `protobuf:"varint,4,opt,name=completed_requests,json=completedRequests,proto3" json:"completed_requests,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WorkManager) Reset() {
+	*x = WorkManager{}
+	mi := &file_remy_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkManager) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkManager) ProtoMessage() {}
+
+func (x *WorkManager) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkManager.ProtoReflect.Descriptor instead.
+func (*WorkManager) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WorkManager) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkManager) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *WorkManager) GetPendingRequests() int64 {
+	if x != nil {
+		return x.PendingRequests
+	}
+	return 0
+}
+
+func (x *WorkManager) GetCompletedRequests() int64 {
+	if x != nil {
+		return x.CompletedRequests
+	}
+	return 0
+}
+
+type MinThreadsConstraint struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Name                     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Server                   string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	PendingRequests          int64                  `protobuf:"varint,3,opt,name=pending_requests,json=pendingRequests,proto3" json:"pending_requests,omitempty"`
+	CompletedRequests        int64                  `protobuf:"varint,4,opt,name=completed_requests,json=completedRequests,proto3" json:"completed_requests,omitempty"`
+	ExecutingRequests        int64                  `protobuf:"varint,5,opt,name=executing_requests,json=executingRequests,proto3" json:"executing_requests,omitempty"`
+	OutOfOrderExecutionCount int64                  `protobuf:"varint,6,opt,name=out_of_order_execution_count,json=outOfOrderExecutionCount,proto3" json:"out_of_order_execution_count,omitempty"`
+	MustRunCount             int64                  `protobuf:"varint,7,opt,name=must_run_count,json=mustRunCount,proto3" json:"must_run_count,omitempty"`
+	MaxWaitTime              int64                  `protobuf:"varint,8,opt,name=max_wait_time,json=maxWaitTime,proto3" json:"max_wait_time,omitempty"`
+	CurrentWaitTime          int64                  `protobuf:"varint,9,opt,name=current_wait_time,json=currentWaitTime,proto3" json:"current_wait_time,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *MinThreadsConstraint) Reset() {
+	*x = MinThreadsConstraint{}
+	mi := &file_remy_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinThreadsConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinThreadsConstraint) ProtoMessage() {}
+
+func (x *MinThreadsConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinThreadsConstraint.ProtoReflect.Descriptor instead.
+func (*MinThreadsConstraint) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MinThreadsConstraint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MinThreadsConstraint) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *MinThreadsConstraint) GetPendingRequests() int64 {
+	if x != nil {
+		return x.PendingRequests
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetCompletedRequests() int64 {
+	if x != nil {
+		return x.CompletedRequests
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetExecutingRequests() int64 {
+	if x != nil {
+		return x.ExecutingRequests
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetOutOfOrderExecutionCount() int64 {
+	if x != nil {
+		return x.OutOfOrderExecutionCount
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetMustRunCount() int64 {
+	if x != nil {
+		return x.MustRunCount
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetMaxWaitTime() int64 {
+	if x != nil {
+		return x.MaxWaitTime
+	}
+	return 0
+}
+
+func (x *MinThreadsConstraint) GetCurrentWaitTime() int64 {
+	if x != nil {
+		return x.CurrentWaitTime
+	}
+	return 0
+}
+
+type MaxThreadsConstraint struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Server            string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	ExecutingRequests int64                  `protobuf:"varint,3,opt,name=executing_requests,json=executingRequests,proto3" json:"executing_requests,omitempty"`
+	DeferredRequests  int64                  `protobuf:"varint,4,opt,name=deferred_requests,json=deferredRequests,proto3" json:"deferred_requests,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MaxThreadsConstraint) Reset() {
+	*x = MaxThreadsConstraint{}
+	mi := &file_remy_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxThreadsConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxThreadsConstraint) ProtoMessage() {}
+
+func (x *MaxThreadsConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxThreadsConstraint.ProtoReflect.Descriptor instead.
+func (*MaxThreadsConstraint) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MaxThreadsConstraint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaxThreadsConstraint) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *MaxThreadsConstraint) GetExecutingRequests() int64 {
+	if x != nil {
+		return x.ExecutingRequests
+	}
+	return 0
+}
+
+func (x *MaxThreadsConstraint) GetDeferredRequests() int64 {
+	if x != nil {
+		return x.DeferredRequests
+	}
+	return 0
+}
+
+type RequestClass struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Server               string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	RequestClassType     string                 `protobuf:"bytes,3,opt,name=request_class_type,json=requestClassType,proto3" json:"request_class_type,omitempty"`
+	CompletedCount       int64                  `protobuf:"varint,4,opt,name=completed_count,json=completedCount,proto3" json:"completed_count,omitempty"`
+	TotalThreadUse       int64                  `protobuf:"varint,5,opt,name=total_thread_use,json=totalThreadUse,proto3" json:"total_thread_use,omitempty"`
+	PendingRequestCount  int64                  `protobuf:"varint,6,opt,name=pending_request_count,json=pendingRequestCount,proto3" json:"pending_request_count,omitempty"`
+	VirtualTimeIncrement int64                  `protobuf:"varint,7,opt,name=virtual_time_increment,json=virtualTimeIncrement,proto3" json:"virtual_time_increment,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RequestClass) Reset() {
+	*x = RequestClass{}
+	mi := &file_remy_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestClass) ProtoMessage() {}
+
+func (x *RequestClass) ProtoReflect() protoreflect.Message {
+	mi := &file_remy_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestClass.ProtoReflect.Descriptor instead.
+func (*RequestClass) Descriptor() ([]byte, []int) {
+	return file_remy_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RequestClass) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RequestClass) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *RequestClass) GetRequestClassType() string {
+	if x != nil {
+		return x.RequestClassType
+	}
+	return ""
+}
+
+func (x *RequestClass) GetCompletedCount() int64 {
+	if x != nil {
+		return x.CompletedCount
+	}
+	return 0
+}
+
+func (x *RequestClass) GetTotalThreadUse() int64 {
+	if x != nil {
+		return x.TotalThreadUse
+	}
+	return 0
+}
+
+func (x *RequestClass) GetPendingRequestCount() int64 {
+	if x != nil {
+		return x.PendingRequestCount
+	}
+	return 0
+}
+
+func (x *RequestClass) GetVirtualTimeIncrement() int64 {
+	if x != nil {
+		return x.VirtualTimeIncrement
+	}
+	return 0
+}
+
+var File_remy_proto protoreflect.FileDescriptor
+
+const file_remy_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"remy.proto\x12\aremy.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
+	"\n" +
+	"GetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\".\n" +
+	"\vListRequest\x12\x1f\n" +
+	"\vfull_format\x18\x01 \x01(\bR\n" +
+	"fullFormat\"@\n" +
+	"\x13ListServersResponse\x12)\n" +
+	"\aservers\x18\x01 \x03(\v2\x0f.remy.v1.ServerR\aservers\"D\n" +
+	"\x14ListClustersResponse\x12,\n" +
+	"\bclusters\x18\x01 \x03(\v2\x10.remy.v1.ClusterR\bclusters\"Q\n" +
+	"\x17ListDataSourcesResponse\x126\n" +
+	"\fdata_sources\x18\x01 \x03(\v2\x13.remy.v1.DataSourceR\vdataSources\"T\n" +
+	"\x18ListApplicationsResponse\x128\n" +
+	"\fapplications\x18\x01 \x03(\v2\x14.remy.v1.ApplicationR\fapplications\"c\n" +
+	"\fWatchRequest\x12\x1c\n" +
+	"\tresources\x18\x01 \x03(\tR\tresources\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\"\xaa\x03\n" +
+	"\x06Change\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.remy.v1.Change.TypeR\x04type\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12)\n" +
+	"\x06server\x18\x05 \x01(\v2\x0f.remy.v1.ServerH\x00R\x06server\x12,\n" +
+	"\acluster\x18\x06 \x01(\v2\x10.remy.v1.ClusterH\x00R\acluster\x126\n" +
+	"\vdata_source\x18\a \x01(\v2\x13.remy.v1.DataSourceH\x00R\n" +
+	"dataSource\x128\n" +
+	"\vapplication\x18\b \x01(\v2\x14.remy.v1.ApplicationH\x00R\vapplication\"B\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\f\n" +
+	"\bMODIFIED\x10\x02\x12\v\n" +
+	"\aREMOVED\x10\x03B\a\n" +
+	"\x05value\"\xdf\x03\n" +
+	"\x06Server\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
+	"\x06health\x18\x03 \x01(\tR\x06health\x12!\n" +
+	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\x12'\n" +
+	"\x0fcurrent_machine\x18\x05 \x01(\tR\x0ecurrentMachine\x12)\n" +
+	"\x10weblogic_version\x18\x06 \x01(\tR\x0fweblogicVersion\x12;\n" +
+	"\x1aopen_sockets_current_count\x18\a \x01(\x01R\x17openSocketsCurrentCount\x12*\n" +
+	"\x11heap_size_current\x18\b \x01(\x03R\x0fheapSizeCurrent\x12*\n" +
+	"\x11heap_free_current\x18\t \x01(\x03R\x0fheapFreeCurrent\x12!\n" +
+	"\fjava_version\x18\n" +
+	" \x01(\tR\vjavaVersion\x12\x17\n" +
+	"\aos_name\x18\v \x01(\tR\x06osName\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\f \x01(\tR\tosVersion\x12,\n" +
+	"\x12jvm_processor_load\x18\r \x01(\x01R\x10jvmProcessorLoad\"O\n" +
+	"\aCluster\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\aservers\x18\x02 \x03(\v2\x16.remy.v1.ClusterServerR\aservers\"\xc6\x02\n" +
+	"\rClusterServer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
+	"\x06health\x18\x03 \x01(\tR\x06health\x12%\n" +
+	"\x0ecluster_master\x18\x04 \x01(\bR\rclusterMaster\x12,\n" +
+	"\x12drop_out_frequency\x18\x05 \x01(\tR\x10dropOutFrequency\x122\n" +
+	"\x15resend_requests_count\x18\x06 \x01(\x03R\x13resendRequestsCount\x120\n" +
+	"\x14fragments_sent_count\x18\a \x01(\x03R\x12fragmentsSentCount\x128\n" +
+	"\x18fragments_received_count\x18\b \x01(\x03R\x16fragmentsReceivedCount\"o\n" +
+	"\n" +
+	"DataSource\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x129\n" +
+	"\tinstances\x18\x03 \x03(\v2\x1b.remy.v1.DataSourceInstanceR\tinstances\"\xa6\x10\n" +
+	"\x12DataSourceInstance\x12\x16\n" +
+	"\x06server\x18\x01 \x01(\tR\x06server\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12.\n" +
+	"\x13version_jdbc_driver\x18\x04 \x01(\tR\x11versionJdbcDriver\x12G\n" +
+	" active_connections_average_count\x18\x05 \x01(\x03R\x1dactiveConnectionsAverageCount\x12G\n" +
+	" active_connections_current_count\x18\x06 \x01(\x03R\x1dactiveConnectionsCurrentCount\x12A\n" +
+	"\x1dactive_connections_high_count\x18\a \x01(\x03R\x1aactiveConnectionsHighCount\x122\n" +
+	"\x15connection_delay_time\x18\b \x01(\x03R\x13connectionDelayTime\x126\n" +
+	"\x17connections_total_count\x18\t \x01(\x03R\x15connectionsTotalCount\x12#\n" +
+	"\rcurr_capacity\x18\n" +
+	" \x01(\x03R\fcurrCapacity\x127\n" +
+	"\x18curr_capacity_high_count\x18\v \x01(\x03R\x15currCapacityHighCount\x12?\n" +
+	"\x1cfailed_reserve_request_count\x18\f \x01(\x03R\x19failedReserveRequestCount\x12=\n" +
+	"\x1bfailures_to_reconnect_count\x18\r \x01(\x03R\x18failuresToReconnectCount\x122\n" +
+	"\x15highest_num_available\x18\x0e \x01(\x03R\x13highestNumAvailable\x126\n" +
+	"\x17leaked_connection_count\x18\x0f \x01(\x03R\x15leakedConnectionCount\x12#\n" +
+	"\rnum_available\x18\x10 \x01(\x03R\fnumAvailable\x12'\n" +
+	"\x0fnum_unavailable\x18\x11 \x01(\x03R\x0enumUnavailable\x12>\n" +
+	"\x1cprep_stmt_cache_access_count\x18\x12 \x01(\x03R\x18prepStmtCacheAccessCount\x128\n" +
+	"\x19prep_stmt_cache_add_count\x18\x13 \x01(\x03R\x15prepStmtCacheAddCount\x12>\n" +
+	"\x1cprep_stmt_cache_current_size\x18\x14 \x01(\x03R\x18prepStmtCacheCurrentSize\x12>\n" +
+	"\x1cprep_stmt_cache_delete_count\x18\x15 \x01(\x03R\x18prepStmtCacheDeleteCount\x128\n" +
+	"\x19prep_stmt_cache_hit_count\x18\x16 \x01(\x03R\x15prepStmtCacheHitCount\x12:\n" +
+	"\x1aprep_stmt_cache_miss_count\x18\x17 \x01(\x03R\x16prepStmtCacheMissCount\x122\n" +
+	"\x15reserve_request_count\x18\x18 \x01(\x03R\x13reserveRequestCount\x125\n" +
+	"\x17wait_seconds_high_count\x18\x19 \x01(\x03R\x14waitSecondsHighCount\x12N\n" +
+	"$waiting_for_connection_current_count\x18\x1a \x01(\x03R waitingForConnectionCurrentCount\x12N\n" +
+	"$waiting_for_connection_failure_total\x18\x1b \x01(\x03R waitingForConnectionFailureTotal\x12H\n" +
+	"!waiting_for_connection_high_count\x18\x1c \x01(\x03R\x1dwaitingForConnectionHighCount\x12N\n" +
+	"$waiting_for_connection_success_total\x18\x1d \x01(\x03R waitingForConnectionSuccessTotal\x12?\n" +
+	"\x1cwaiting_for_connection_total\x18\x1e \x01(\x03R\x19waitingForConnectionTotal\x12J\n" +
+	"\"successful_rclb_based_borrow_count\x18\x1f \x01(\x03R\x1esuccessfulRclbBasedBorrowCount\x12B\n" +
+	"\x1efailed_rclb_based_borrow_count\x18  \x01(\x03R\x1afailedRclbBasedBorrowCount\x12R\n" +
+	"&successful_affinity_based_borrow_count\x18! \x01(\x03R\"successfulAffinityBasedBorrowCount\x12J\n" +
+	"\"failed_affinity_based_borrow_count\x18\" \x01(\x03R\x1efailedAffinityBasedBorrowCount\x129\n" +
+	"\rrac_instances\x18# \x03(\v2\x14.remy.v1.RacInstanceR\fracInstances\"\xcf\x03\n" +
+	"\vRacInstance\x12#\n" +
+	"\rinstance_name\x18\x01 \x01(\tR\finstanceName\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12%\n" +
+	"\x0ecurrent_weight\x18\x05 \x01(\x03R\rcurrentWeight\x12G\n" +
+	" active_connections_current_count\x18\x06 \x01(\x03R\x1dactiveConnectionsCurrentCount\x122\n" +
+	"\x15reserve_request_count\x18\a \x01(\x03R\x13reserveRequestCount\x126\n" +
+	"\x17connections_total_count\x18\b \x01(\x03R\x15connectionsTotalCount\x12#\n" +
+	"\rcurr_capacity\x18\t \x01(\x03R\fcurrCapacity\x12#\n" +
+	"\rnum_available\x18\n" +
+	" \x01(\x03R\fnumAvailable\x12'\n" +
+	"\x0fnum_unavailable\x18\v \x01(\x03R\x0enumUnavailable\"\x82\x04\n" +
+	"\vApplication\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x16\n" +
+	"\x06health\x18\x04 \x01(\tR\x06health\x129\n" +
+	"\rtarget_states\x18\x05 \x03(\v2\x14.remy.v1.TargetStateR\ftargetStates\x129\n" +
+	"\fdata_sources\x18\x06 \x03(\v2\x16.remy.v1.AppDataSourceR\vdataSources\x129\n" +
+	"\rwork_managers\x18\a \x03(\v2\x14.remy.v1.WorkManagerR\fworkManagers\x12U\n" +
+	"\x17min_threads_constraints\x18\b \x03(\v2\x1d.remy.v1.MinThreadsConstraintR\x15minThreadsConstraints\x12U\n" +
+	"\x17max_threads_constraints\x18\t \x03(\v2\x1d.remy.v1.MaxThreadsConstraintR\x15maxThreadsConstraints\x12>\n" +
+	"\x0frequest_classes\x18\n" +
+	" \x03(\v2\x15.remy.v1.RequestClassR\x0erequestClasses\";\n" +
+	"\vTargetState\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"Q\n" +
+	"\rAppDataSource\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\"\x93\x01\n" +
+	"\vWorkManager\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12)\n" +
+	"\x10pending_requests\x18\x03 \x01(\x03R\x0fpendingRequests\x12-\n" +
+	"\x12completed_requests\x18\x04 \x01(\x03R\x11completedRequests\"\x81\x03\n" +
+	"\x14MinThreadsConstraint\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12)\n" +
+	"\x10pending_requests\x18\x03 \x01(\x03R\x0fpendingRequests\x12-\n" +
+	"\x12completed_requests\x18\x04 \x01(\x03R\x11completedRequests\x12-\n" +
+	"\x12executing_requests\x18\x05 \x01(\x03R\x11executingRequests\x12>\n" +
+	"\x1cout_of_order_execution_count\x18\x06 \x01(\x03R\x18outOfOrderExecutionCount\x12$\n" +
+	"\x0emust_run_count\x18\a \x01(\x03R\fmustRunCount\x12\"\n" +
+	"\rmax_wait_time\x18\b \x01(\x03R\vmaxWaitTime\x12*\n" +
+	"\x11current_wait_time\x18\t \x01(\x03R\x0fcurrentWaitTime\"\x9e\x01\n" +
+	"\x14MaxThreadsConstraint\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12-\n" +
+	"\x12executing_requests\x18\x03 \x01(\x03R\x11executingRequests\x12+\n" +
+	"\x11deferred_requests\x18\x04 \x01(\x03R\x10deferredRequests\"\xa5\x02\n" +
+	"\fRequestClass\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12,\n" +
+	"\x12request_class_type\x18\x03 \x01(\tR\x10requestClassType\x12'\n" +
+	"\x0fcompleted_count\x18\x04 \x01(\x03R\x0ecompletedCount\x12(\n" +
+	"\x10total_thread_use\x18\x05 \x01(\x03R\x0etotalThreadUse\x122\n" +
+	"\x15pending_request_count\x18\x06 \x01(\x03R\x13pendingRequestCount\x124\n" +
+	"\x16virtual_time_increment\x18\a \x01(\x03R\x14virtualTimeIncrement2\xb9\x04\n" +
+	"\x04Remy\x121\n" +
+	"\tGetServer\x12\x13.remy.v1.GetRequest\x1a\x0f.remy.v1.Server\x12A\n" +
+	"\vListServers\x12\x14.remy.v1.ListRequest\x1a\x1c.remy.v1.ListServersResponse\x123\n" +
+	"\n" +
+	"GetCluster\x12\x13.remy.v1.GetRequest\x1a\x10.remy.v1.Cluster\x12C\n" +
+	"\fListClusters\x12\x14.remy.v1.ListRequest\x1a\x1d.remy.v1.ListClustersResponse\x129\n" +
+	"\rGetDataSource\x12\x13.remy.v1.GetRequest\x1a\x13.remy.v1.DataSource\x12I\n" +
+	"\x0fListDataSources\x12\x14.remy.v1.ListRequest\x1a .remy.v1.ListDataSourcesResponse\x12;\n" +
+	"\x0eGetApplication\x12\x13.remy.v1.GetRequest\x1a\x14.remy.v1.Application\x12K\n" +
+	"\x10ListApplications\x12\x14.remy.v1.ListRequest\x1a!.remy.v1.ListApplicationsResponse\x121\n" +
+	"\x05Watch\x12\x15.remy.v1.WatchRequest\x1a\x0f.remy.v1.Change0\x01B<\n" +
+	"\x1bcom.github.klauern.remy.rpcP\x01Z\x1bgithub.com/klauern/remy/rpcb\x06proto3"
+
+var (
+	file_remy_proto_rawDescOnce sync.Once
+	file_remy_proto_rawDescData []byte
+)
+
+func file_remy_proto_rawDescGZIP() []byte {
+	file_remy_proto_rawDescOnce.Do(func() {
+		file_remy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_remy_proto_rawDesc), len(file_remy_proto_rawDesc)))
+	})
+	return file_remy_proto_rawDescData
+}
+
+var file_remy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_remy_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_remy_proto_goTypes = []any{
+	(Change_Type)(0),                 // 0: remy.v1.Change.Type
+	(*GetRequest)(nil),               // 1: remy.v1.GetRequest
+	(*ListRequest)(nil),              // 2: remy.v1.ListRequest
+	(*ListServersResponse)(nil),      // 3: remy.v1.ListServersResponse
+	(*ListClustersResponse)(nil),     // 4: remy.v1.ListClustersResponse
+	(*ListDataSourcesResponse)(nil),  // 5: remy.v1.ListDataSourcesResponse
+	(*ListApplicationsResponse)(nil), // 6: remy.v1.ListApplicationsResponse
+	(*WatchRequest)(nil),             // 7: remy.v1.WatchRequest
+	(*Change)(nil),                   // 8: remy.v1.Change
+	(*Server)(nil),                   // 9: remy.v1.Server
+	(*Cluster)(nil),                  // 10: remy.v1.Cluster
+	(*ClusterServer)(nil),            // 11: remy.v1.ClusterServer
+	(*DataSource)(nil),               // 12: remy.v1.DataSource
+	(*DataSourceInstance)(nil),       // 13: remy.v1.DataSourceInstance
+	(*RacInstance)(nil),              // 14: remy.v1.RacInstance
+	(*Application)(nil),              // 15: remy.v1.Application
+	(*TargetState)(nil),              // 16: remy.v1.TargetState
+	(*AppDataSource)(nil),            // 17: remy.v1.AppDataSource
+	(*WorkManager)(nil),              // 18: remy.v1.WorkManager
+	(*MinThreadsConstraint)(nil),     // 19: remy.v1.MinThreadsConstraint
+	(*MaxThreadsConstraint)(nil),     // 20: remy.v1.MaxThreadsConstraint
+	(*RequestClass)(nil),             // 21: remy.v1.RequestClass
+	(*durationpb.Duration)(nil),      // 22: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 23: google.protobuf.Timestamp
+}
+var file_remy_proto_depIdxs = []int32{
+	9,  // 0: remy.v1.ListServersResponse.servers:type_name -> remy.v1.Server
+	10, // 1: remy.v1.ListClustersResponse.clusters:type_name -> remy.v1.Cluster
+	12, // 2: remy.v1.ListDataSourcesResponse.data_sources:type_name -> remy.v1.DataSource
+	15, // 3: remy.v1.ListApplicationsResponse.applications:type_name -> remy.v1.Application
+	22, // 4: remy.v1.WatchRequest.interval:type_name -> google.protobuf.Duration
+	0,  // 5: remy.v1.Change.type:type_name -> remy.v1.Change.Type
+	23, // 6: remy.v1.Change.time:type_name -> google.protobuf.Timestamp
+	9,  // 7: remy.v1.Change.server:type_name -> remy.v1.Server
+	10, // 8: remy.v1.Change.cluster:type_name -> remy.v1.Cluster
+	12, // 9: remy.v1.Change.data_source:type_name -> remy.v1.DataSource
+	15, // 10: remy.v1.Change.application:type_name -> remy.v1.Application
+	11, // 11: remy.v1.Cluster.servers:type_name -> remy.v1.ClusterServer
+	13, // 12: remy.v1.DataSource.instances:type_name -> remy.v1.DataSourceInstance
+	14, // 13: remy.v1.DataSourceInstance.rac_instances:type_name -> remy.v1.RacInstance
+	16, // 14: remy.v1.Application.target_states:type_name -> remy.v1.TargetState
+	17, // 15: remy.v1.Application.data_sources:type_name -> remy.v1.AppDataSource
+	18, // 16: remy.v1.Application.work_managers:type_name -> remy.v1.WorkManager
+	19, // 17: remy.v1.Application.min_threads_constraints:type_name -> remy.v1.MinThreadsConstraint
+	20, // 18: remy.v1.Application.max_threads_constraints:type_name -> remy.v1.MaxThreadsConstraint
+	21, // 19: remy.v1.Application.request_classes:type_name -> remy.v1.RequestClass
+	1,  // 20: remy.v1.Remy.GetServer:input_type -> remy.v1.GetRequest
+	2,  // 21: remy.v1.Remy.ListServers:input_type -> remy.v1.ListRequest
+	1,  // 22: remy.v1.Remy.GetCluster:input_type -> remy.v1.GetRequest
+	2,  // 23: remy.v1.Remy.ListClusters:input_type -> remy.v1.ListRequest
+	1,  // 24: remy.v1.Remy.GetDataSource:input_type -> remy.v1.GetRequest
+	2,  // 25: remy.v1.Remy.ListDataSources:input_type -> remy.v1.ListRequest
+	1,  // 26: remy.v1.Remy.GetApplication:input_type -> remy.v1.GetRequest
+	2,  // 27: remy.v1.Remy.ListApplications:input_type -> remy.v1.ListRequest
+	7,  // 28: remy.v1.Remy.Watch:input_type -> remy.v1.WatchRequest
+	9,  // 29: remy.v1.Remy.GetServer:output_type -> remy.v1.Server
+	3,  // 30: remy.v1.Remy.ListServers:output_type -> remy.v1.ListServersResponse
+	10, // 31: remy.v1.Remy.GetCluster:output_type -> remy.v1.Cluster
+	4,  // 32: remy.v1.Remy.ListClusters:output_type -> remy.v1.ListClustersResponse
+	12, // 33: remy.v1.Remy.GetDataSource:output_type -> remy.v1.DataSource
+	5,  // 34: remy.v1.Remy.ListDataSources:output_type -> remy.v1.ListDataSourcesResponse
+	15, // 35: remy.v1.Remy.GetApplication:output_type -> remy.v1.Application
+	6,  // 36: remy.v1.Remy.ListApplications:output_type -> remy.v1.ListApplicationsResponse
+	8,  // 37: remy.v1.Remy.Watch:output_type -> remy.v1.Change
+	29, // [29:38] is the sub-list for method output_type
+	20, // [20:29] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_remy_proto_init() }
+func file_remy_proto_init() {
+	if File_remy_proto != nil {
+		return
+	}
+	file_remy_proto_msgTypes[7].OneofWrappers = []any{
+		(*Change_Server)(nil),
+		(*Change_Cluster)(nil),
+		(*Change_DataSource)(nil),
+		(*Change_Application)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_remy_proto_rawDesc), len(file_remy_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_remy_proto_goTypes,
+		DependencyIndexes: file_remy_proto_depIdxs,
+		EnumInfos:         file_remy_proto_enumTypes,
+		MessageInfos:      file_remy_proto_msgTypes,
+	}.Build()
+	File_remy_proto = out.File
+	file_remy_proto_goTypes = nil
+	file_remy_proto_depIdxs = nil
+}
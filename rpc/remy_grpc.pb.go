@@ -0,0 +1,439 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: remy.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Remy_GetServer_FullMethodName        = "/remy.v1.Remy/GetServer"
+	Remy_ListServers_FullMethodName      = "/remy.v1.Remy/ListServers"
+	Remy_GetCluster_FullMethodName       = "/remy.v1.Remy/GetCluster"
+	Remy_ListClusters_FullMethodName     = "/remy.v1.Remy/ListClusters"
+	Remy_GetDataSource_FullMethodName    = "/remy.v1.Remy/GetDataSource"
+	Remy_ListDataSources_FullMethodName  = "/remy.v1.Remy/ListDataSources"
+	Remy_GetApplication_FullMethodName   = "/remy.v1.Remy/GetApplication"
+	Remy_ListApplications_FullMethodName = "/remy.v1.Remy/ListApplications"
+	Remy_Watch_FullMethodName            = "/remy.v1.Remy/Watch"
+)
+
+// RemyClient is the client API for Remy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The Remy service serves the run-time state of a WebLogic domain's servers, clusters, datasources and applications,
+// as read from its AdminServer's RESTful Management Extensions.
+type RemyClient interface {
+	GetServer(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Server, error)
+	ListServers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListServersResponse, error)
+	GetCluster(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Cluster, error)
+	ListClusters(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListClustersResponse, error)
+	GetDataSource(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*DataSource, error)
+	ListDataSources(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListDataSourcesResponse, error)
+	GetApplication(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Application, error)
+	ListApplications(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error)
+	// Watch polls the domain on an interval, sending every resource as ADDED on the first poll, then a Change for each
+	// resource added, modified or removed since the poll before.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Change], error)
+}
+
+type remyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemyClient(cc grpc.ClientConnInterface) RemyClient {
+	return &remyClient{cc}
+}
+
+func (c *remyClient) GetServer(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Server, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Server)
+	err := c.cc.Invoke(ctx, Remy_GetServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) ListServers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListServersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServersResponse)
+	err := c.cc.Invoke(ctx, Remy_ListServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) GetCluster(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, Remy_GetCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) ListClusters(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListClustersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClustersResponse)
+	err := c.cc.Invoke(ctx, Remy_ListClusters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) GetDataSource(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*DataSource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataSource)
+	err := c.cc.Invoke(ctx, Remy_GetDataSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) ListDataSources(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListDataSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataSourcesResponse)
+	err := c.cc.Invoke(ctx, Remy_ListDataSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) GetApplication(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Application, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Application)
+	err := c.cc.Invoke(ctx, Remy_GetApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) ListApplications(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApplicationsResponse)
+	err := c.cc.Invoke(ctx, Remy_ListApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remyClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Change], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Remy_ServiceDesc.Streams[0], Remy_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, Change]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Remy_WatchClient = grpc.ServerStreamingClient[Change]
+
+// RemyServer is the server API for Remy service.
+// All implementations must embed UnimplementedRemyServer
+// for forward compatibility.
+//
+// The Remy service serves the run-time state of a WebLogic domain's servers, clusters, datasources and applications,
+// as read from its AdminServer's RESTful Management Extensions.
+type RemyServer interface {
+	GetServer(context.Context, *GetRequest) (*Server, error)
+	ListServers(context.Context, *ListRequest) (*ListServersResponse, error)
+	GetCluster(context.Context, *GetRequest) (*Cluster, error)
+	ListClusters(context.Context, *ListRequest) (*ListClustersResponse, error)
+	GetDataSource(context.Context, *GetRequest) (*DataSource, error)
+	ListDataSources(context.Context, *ListRequest) (*ListDataSourcesResponse, error)
+	GetApplication(context.Context, *GetRequest) (*Application, error)
+	ListApplications(context.Context, *ListRequest) (*ListApplicationsResponse, error)
+	// Watch polls the domain on an interval, sending every resource as ADDED on the first poll, then a Change for each
+	// resource added, modified or removed since the poll before.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[Change]) error
+	mustEmbedUnimplementedRemyServer()
+}
+
+// UnimplementedRemyServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRemyServer struct{}
+
+func (UnimplementedRemyServer) GetServer(context.Context, *GetRequest) (*Server, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+}
+func (UnimplementedRemyServer) ListServers(context.Context, *ListRequest) (*ListServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+}
+func (UnimplementedRemyServer) GetCluster(context.Context, *GetRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCluster not implemented")
+}
+func (UnimplementedRemyServer) ListClusters(context.Context, *ListRequest) (*ListClustersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClusters not implemented")
+}
+func (UnimplementedRemyServer) GetDataSource(context.Context, *GetRequest) (*DataSource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataSource not implemented")
+}
+func (UnimplementedRemyServer) ListDataSources(context.Context, *ListRequest) (*ListDataSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDataSources not implemented")
+}
+func (UnimplementedRemyServer) GetApplication(context.Context, *GetRequest) (*Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplication not implemented")
+}
+func (UnimplementedRemyServer) ListApplications(context.Context, *ListRequest) (*ListApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplications not implemented")
+}
+func (UnimplementedRemyServer) Watch(*WatchRequest, grpc.ServerStreamingServer[Change]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedRemyServer) mustEmbedUnimplementedRemyServer() {}
+func (UnimplementedRemyServer) testEmbeddedByValue()              {}
+
+// UnsafeRemyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemyServer will
+// result in compilation errors.
+type UnsafeRemyServer interface {
+	mustEmbedUnimplementedRemyServer()
+}
+
+func RegisterRemyServer(s grpc.ServiceRegistrar, srv RemyServer) {
+	// If the following call pancis, it indicates UnimplementedRemyServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Remy_ServiceDesc, srv)
+}
+
+func _Remy_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).GetServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_GetServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).GetServer(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).ListServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_ListServers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).ListServers(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_GetCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).GetCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_GetCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).GetCluster(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_ListClusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).ListClusters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_ListClusters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).ListClusters(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_GetDataSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).GetDataSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_GetDataSource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).GetDataSource(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_ListDataSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).ListDataSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_ListDataSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).ListDataSources(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_GetApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).GetApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_GetApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).GetApplication(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_ListApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemyServer).ListApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remy_ListApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemyServer).ListApplications(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remy_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemyServer).Watch(m, &grpc.GenericServerStream[WatchRequest, Change]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Remy_WatchServer = grpc.ServerStreamingServer[Change]
+
+// Remy_ServiceDesc is the grpc.ServiceDesc for Remy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Remy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remy.v1.Remy",
+	HandlerType: (*RemyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServer",
+			Handler:    _Remy_GetServer_Handler,
+		},
+		{
+			MethodName: "ListServers",
+			Handler:    _Remy_ListServers_Handler,
+		},
+		{
+			MethodName: "GetCluster",
+			Handler:    _Remy_GetCluster_Handler,
+		},
+		{
+			MethodName: "ListClusters",
+			Handler:    _Remy_ListClusters_Handler,
+		},
+		{
+			MethodName: "GetDataSource",
+			Handler:    _Remy_GetDataSource_Handler,
+		},
+		{
+			MethodName: "ListDataSources",
+			Handler:    _Remy_ListDataSources_Handler,
+		},
+		{
+			MethodName: "GetApplication",
+			Handler:    _Remy_GetApplication_Handler,
+		},
+		{
+			MethodName: "ListApplications",
+			Handler:    _Remy_ListApplications_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Remy_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "remy.proto",
+}
@@ -0,0 +1,290 @@
+// Package rpc is a gRPC API over a WebLogic domain: the Remy service defined in remy.proto, with its generated Go
+// client and server, and a Service implementing it over a remy.Client.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative remy.proto
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/remy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Resources that can be watched.
+const (
+	ServersResource      = "servers"
+	ClustersResource     = "clusters"
+	DataSourcesResource  = "datasources"
+	ApplicationsResource = "applications"
+)
+
+// Resources lists every resource that can be watched, in the order their changes are sent.
+var Resources = []string{ServersResource, ClustersResource, DataSourcesResource, ApplicationsResource}
+
+// DefaultWatchInterval is the time between polls of a Watch that doesn't set one.
+const DefaultWatchInterval = 10 * time.Second
+
+// Service implements the Remy service by calling the AdminServer of a remy.Client.
+type Service struct {
+	UnimplementedRemyServer
+
+	// MinWatchInterval is the shortest interval a Watch may poll the AdminServer at.
+	MinWatchInterval time.Duration
+
+	client *remy.Client
+}
+
+// NewService creates a Service over client, with a MinWatchInterval of one second.
+func NewService(client *remy.Client) *Service {
+	return &Service{client: client, MinWatchInterval: time.Second}
+}
+
+// GetServer gets a server in full format.
+func (s *Service) GetServer(ctx context.Context, req *GetRequest) (*Server, error) {
+	server, err := s.client.Server(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newServer(*server), nil
+}
+
+// ListServers lists every server.
+func (s *Service) ListServers(ctx context.Context, req *ListRequest) (*ListServersResponse, error) {
+	servers, err := s.client.Servers(ctx, remy.QueryOptions{Full: req.GetFullFormat()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &ListServersResponse{}
+	for _, server := range servers {
+		resp.Servers = append(resp.Servers, newServer(server))
+	}
+	return resp, nil
+}
+
+// GetCluster gets a cluster in full format.
+func (s *Service) GetCluster(ctx context.Context, req *GetRequest) (*Cluster, error) {
+	cluster, err := s.client.Cluster(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newCluster(*cluster), nil
+}
+
+// ListClusters lists every cluster.
+func (s *Service) ListClusters(ctx context.Context, req *ListRequest) (*ListClustersResponse, error) {
+	clusters, err := s.client.Clusters(ctx, remy.QueryOptions{Full: req.GetFullFormat()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &ListClustersResponse{}
+	for _, cluster := range clusters {
+		resp.Clusters = append(resp.Clusters, newCluster(cluster))
+	}
+	return resp, nil
+}
+
+// GetDataSource gets a datasource in full format.
+func (s *Service) GetDataSource(ctx context.Context, req *GetRequest) (*DataSource, error) {
+	ds, err := s.client.DataSource(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newDataSource(*ds), nil
+}
+
+// ListDataSources lists every datasource.
+func (s *Service) ListDataSources(ctx context.Context, req *ListRequest) (*ListDataSourcesResponse, error) {
+	dataSources, err := s.client.DataSources(ctx, remy.QueryOptions{Full: req.GetFullFormat()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &ListDataSourcesResponse{}
+	for _, ds := range dataSources {
+		resp.DataSources = append(resp.DataSources, newDataSource(ds))
+	}
+	return resp, nil
+}
+
+// GetApplication gets an application in full format.
+func (s *Service) GetApplication(ctx context.Context, req *GetRequest) (*Application, error) {
+	app, err := s.client.Application(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newApplication(*app), nil
+}
+
+// ListApplications lists every application.
+func (s *Service) ListApplications(ctx context.Context, req *ListRequest) (*ListApplicationsResponse, error) {
+	applications, err := s.client.Applications(ctx, remy.QueryOptions{Full: req.GetFullFormat()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &ListApplicationsResponse{}
+	for _, app := range applications {
+		resp.Applications = append(resp.Applications, newApplication(app))
+	}
+	return resp, nil
+}
+
+// Watch polls the full format resources requested every interval until the client goes away, sending a Change for
+// everything added, modified or removed since the last poll.  The stream ends with the first error polling the
+// AdminServer; clients can simply watch again, as every resource is sent as ADDED on the first poll.
+func (s *Service) Watch(req *WatchRequest, stream grpc.ServerStreamingServer[Change]) error {
+	resources := req.GetResources()
+	if len(resources) == 0 {
+		resources = Resources
+	}
+	for _, r := range resources {
+		if !isResource(r) {
+			return status.Errorf(codes.InvalidArgument, "unknown resource %q, expected one of %v", r, strings.Join(Resources, ", "))
+		}
+	}
+	interval := DefaultWatchInterval
+	if req.GetInterval() != nil {
+		interval = req.GetInterval().AsDuration()
+	}
+	if interval < s.MinWatchInterval {
+		return status.Errorf(codes.InvalidArgument, "interval %v is shorter than the minimum of %v", interval, s.MinWatchInterval)
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	seen := make(map[string]map[string]proto.Message)
+	for {
+		now := time.Now()
+		for _, r := range Resources {
+			if !contains(resources, r) {
+				continue
+			}
+			current, err := s.poll(ctx, r)
+			if err != nil {
+				return toStatus(err)
+			}
+			for _, c := range changes(r, seen[r], current, timestamppb.New(now)) {
+				if err := stream.Send(c); err != nil {
+					return err
+				}
+			}
+			seen[r] = current
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll gets every resource of a kind in full format, keyed by name.
+func (s *Service) poll(ctx context.Context, resource string) (map[string]proto.Message, error) {
+	current := make(map[string]proto.Message)
+	switch resource {
+	case ServersResource:
+		servers, err := s.client.Servers(ctx, remy.QueryOptions{Full: true})
+		for _, server := range servers {
+			current[server.Name] = newServer(server)
+		}
+		return current, err
+	case ClustersResource:
+		clusters, err := s.client.Clusters(ctx, remy.QueryOptions{Full: true})
+		for _, cluster := range clusters {
+			current[cluster.Name] = newCluster(cluster)
+		}
+		return current, err
+	case DataSourcesResource:
+		dataSources, err := s.client.DataSources(ctx, remy.QueryOptions{Full: true})
+		for _, ds := range dataSources {
+			current[ds.Name] = newDataSource(ds)
+		}
+		return current, err
+	default:
+		applications, err := s.client.Applications(ctx, remy.QueryOptions{Full: true})
+		for _, app := range applications {
+			current[app.Name] = newApplication(app)
+		}
+		return current, err
+	}
+}
+
+// changes compares the resources of a kind seen by the last poll with the current poll, in order of name.
+func changes(resource string, seen, current map[string]proto.Message, now *timestamppb.Timestamp) []*Change {
+	var names []string
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range seen {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []*Change
+	for _, name := range names {
+		before, wasSeen := seen[name]
+		after, ok := current[name]
+		c := &Change{Time: now, Resource: resource, Name: name}
+		switch {
+		case !wasSeen:
+			c.Type = Change_ADDED
+		case !ok:
+			c.Type, after = Change_REMOVED, before
+		case !proto.Equal(before, after):
+			c.Type = Change_MODIFIED
+		default:
+			continue
+		}
+		switch v := after.(type) {
+		case *Server:
+			c.Value = &Change_Server{Server: v}
+		case *Cluster:
+			c.Value = &Change_Cluster{Cluster: v}
+		case *DataSource:
+			c.Value = &Change_DataSource{DataSource: v}
+		case *Application:
+			c.Value = &Change_Application{Application: v}
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// toStatus reports resources the AdminServer doesn't have as NotFound, and any other failure to reach it as
+// Unavailable, without the response body the client includes in its errors.
+func toStatus(err error) error {
+	if s, ok := status.FromError(err); ok {
+		return s.Err()
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.FromContextError(err).Err()
+	}
+	msg := strings.SplitN(err.Error(), "\n", 2)[0]
+	if remy.IsNotFound(err) {
+		return status.Error(codes.NotFound, msg)
+	}
+	return status.Error(codes.Unavailable, fmt.Sprintf("AdminServer request failed: %v", msg))
+}
+
+func isResource(resource string) bool {
+	return contains(Resources, resource)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// newTestClient serves a Service over the fake AdminServer on an in-memory listener, returning a client for it.
+func newTestClient(t *testing.T) (RemyClient, *remytest.Server, func()) {
+	fake := remytest.New()
+	ts := fake.Start()
+	service := NewService(remy.NewClient(fake.AdminServer(ts.URL)))
+	service.MinWatchInterval = time.Millisecond
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterRemyServer(server, service)
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRemyClient(conn), fake, func() {
+		conn.Close()
+		server.Stop()
+		ts.Close()
+	}
+}
+
+func TestGetAndList(t *testing.T) {
+	client, _, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	servers, err := client.ListServers(ctx, &ListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, servers.GetServers(), 3)
+
+	server, err := client.GetServer(ctx, &GetRequest{Name: "ms1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster1", server.GetClusterName())
+
+	clusters, err := client.ListClusters(ctx, &ListRequest{FullFormat: true})
+	assert.NoError(t, err)
+	assert.Len(t, clusters.GetClusters()[0].GetServers(), 2)
+
+	ds, err := client.GetDataSource(ctx, &GetRequest{Name: "ds1"})
+	assert.NoError(t, err)
+	assert.Len(t, ds.GetInstances(), 2)
+
+	app, err := client.GetApplication(ctx, &GetRequest{Name: "app1"})
+	assert.NoError(t, err)
+	assert.Len(t, app.GetDataSources(), 2)
+	assert.Len(t, app.GetWorkManagers(), 2)
+}
+
+func TestErrors(t *testing.T) {
+	client, fake, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := client.GetServer(ctx, &GetRequest{Name: "nope"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	fake.InjectError("datasources", http.StatusInternalServerError)
+	_, err = client.ListDataSources(ctx, &ListRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	stream, err := client.Watch(ctx, &WatchRequest{Resources: []string{"widgets"}})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatch(t *testing.T) {
+	client, fake, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &WatchRequest{Resources: []string{ServersResource}, Interval: durationpb.New(50 * time.Millisecond)})
+	assert.NoError(t, err)
+	for _, name := range []string{"AdminServer", "ms1", "ms2"} {
+		c, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, Change_ADDED, c.GetType())
+		assert.Equal(t, name, c.GetName())
+		assert.Equal(t, name, c.GetServer().GetName())
+	}
+
+	fake.Update(func(d *remytest.Domain) {
+		d.Servers[2].State = "SHUTDOWN"
+		d.Servers = d.Servers[1:]
+	})
+	c, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, Change_REMOVED, c.GetType())
+	assert.Equal(t, "AdminServer", c.GetName())
+	c, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, Change_MODIFIED, c.GetType())
+	assert.Equal(t, "SHUTDOWN", c.GetServer().GetState())
+}
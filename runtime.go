@@ -0,0 +1,610 @@
+package remy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RuntimePath is the REST resource path from the root / to the run-time beans of the domain, as of WLS 12.2.1.
+const RuntimePath string = VersionPath + "/domainRuntime"
+
+// Searches of RuntimePath asking for only the beans and fields each resource needs, in a single request.  Every
+// server appears in serverLifeCycleRuntimes, but only running servers have serverRuntimes.
+const (
+	serversSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "serverLifeCycleRuntimes": {"fields": ["name", "state"], "links": []},
+    "serverRuntimes": {
+      "fields": ["name", "healthState", "currentMachine", "weblogicVersion", "openSocketsCurrentCount"], "links": [],
+      "children": {
+        "JVMRuntime": {"fields": ["heapSizeCurrent", "heapFreeCurrent", "javaVersion", "OSName", "OSVersion", "processCpuLoad"], "links": []},
+        "clusterRuntime": {"fields": ["name"], "links": []}
+      }
+    }
+  }
+}`
+
+	clustersSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "serverLifeCycleRuntimes": {"fields": ["name", "state"], "links": []},
+    "serverRuntimes": {
+      "fields": ["name", "healthState"], "links": [],
+      "children": {
+        "clusterRuntime": {"fields": ["name", "resendRequestsCount", "fragmentsSentCount", "fragmentsReceivedCount"], "links": []},
+        "serverMigrationRuntime": {"fields": ["clusterMaster"], "links": []}
+      }
+    }
+  }
+}`
+
+	dataSourcesSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "serverRuntimes": {
+      "fields": ["name"], "links": [],
+      "children": {"JDBCServiceRuntime": {"fields": [], "links": [], "children": {
+        "JDBCDataSourceRuntimeMBeans": {"links": []}
+      }}}
+    }
+  }
+}`
+
+	applicationsSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "serverRuntimes": {
+      "fields": ["name"], "links": [],
+      "children": {"applicationRuntimes": {"fields": ["name", "healthState"], "links": [], "children": {
+        "workManagerRuntimes": {"links": []},
+        "minThreadsConstraintRuntimes": {"links": []},
+        "maxThreadsConstraintRuntimes": {"links": []},
+        "requestClassRuntimes": {"links": []}
+      }}}
+    }
+  }
+}`
+
+	partitionsSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "domainPartitionRuntimes": {"fields": ["name"], "links": [], "children": {
+      "partitionLifeCycleRuntime": {"fields": ["state"], "links": [], "children": {
+        "resourceGroupLifeCycleRuntimes": {"fields": ["name", "state"], "links": []}
+      }}
+    }},
+    "serverRuntimes": {
+      "fields": ["name"], "links": [],
+      "children": {"partitionRuntimes": {"fields": ["name", "state"], "links": [], "children": {
+        "partitionResourceMetricsRuntime": {
+          "fields": ["cpuUtilization", "cpuTimeNanos", "heapAllocatedTotal", "threadCount", "currentOpenFileCount", "currentOpenSocketCount"],
+          "links": []
+        }
+      }}}
+    }
+  }
+}`
+
+	// partitionScopeSearch asks for everything runtimeServers, runtimeDataSources and runtimeApplications need from
+	// the run-time beans of a partition on each server.
+	partitionScopeSearch = `{
+  "fields": [], "links": [],
+  "children": {
+    "domainPartitionRuntimes": {"fields": ["name"], "links": []},
+    "serverLifeCycleRuntimes": {"fields": ["name", "state"], "links": []},
+    "serverRuntimes": {
+      "fields": ["name", "healthState", "currentMachine", "weblogicVersion", "openSocketsCurrentCount"], "links": [],
+      "children": {
+        "JVMRuntime": {"fields": ["heapSizeCurrent", "heapFreeCurrent", "javaVersion", "OSName", "OSVersion", "processCpuLoad"], "links": []},
+        "clusterRuntime": {"fields": ["name"], "links": []},
+        "partitionRuntimes": {"fields": ["name", "state"], "links": [], "children": {
+          "JDBCPartitionRuntime": {"fields": [], "links": [], "children": {
+            "JDBCDataSourceRuntimeMBeans": {"links": []}
+          }},
+          "applicationRuntimes": {"fields": ["name", "healthState"], "links": [], "children": {
+            "workManagerRuntimes": {"links": []},
+            "minThreadsConstraintRuntimes": {"links": []},
+            "maxThreadsConstraintRuntimes": {"links": []},
+            "requestClassRuntimes": {"links": []}
+          }}
+        }}
+      }
+    }
+  }
+}`
+)
+
+// The beans and bean fields of the searches each resource's fields are read from, so the searches can be narrowed to
+// the fields a QueryOptions wants.  A partition's run-time beans are always read in full.
+var (
+	serverBeans = beans{
+		"Health":                  {"serverRuntimes.healthState"},
+		"ClusterName":             {"serverRuntimes.clusterRuntime"},
+		"CurrentMachine":          {"serverRuntimes.currentMachine"},
+		"WebLogicVersion":         {"serverRuntimes.weblogicVersion"},
+		"OpenSocketsCurrentCount": {"serverRuntimes.openSocketsCurrentCount"},
+		"HeapSizeCurrent":         {"serverRuntimes.JVMRuntime.heapSizeCurrent"},
+		"HeapFreeCurrent":         {"serverRuntimes.JVMRuntime.heapFreeCurrent"},
+		"JavaVersion":             {"serverRuntimes.JVMRuntime.javaVersion"},
+		"OsName":                  {"serverRuntimes.JVMRuntime.OSName"},
+		"OsVersion":               {"serverRuntimes.JVMRuntime.OSVersion"},
+		"JvmProcessorLoad":        {"serverRuntimes.JVMRuntime.processCpuLoad"},
+	}
+
+	clusterBeans = beans{
+		"Servers": {"serverLifeCycleRuntimes", "serverRuntimes"},
+	}
+
+	dataSourceBeans = beans{
+		"Instances": {"serverRuntimes.JDBCServiceRuntime.JDBCDataSourceRuntimeMBeans.*"},
+	}
+
+	applicationBeans = beans{
+		"Health":                {"serverRuntimes.applicationRuntimes.healthState"},
+		"WorkManagers":          {"serverRuntimes.applicationRuntimes.workManagerRuntimes"},
+		"MinThreadsConstraints": {"serverRuntimes.applicationRuntimes.minThreadsConstraintRuntimes"},
+		"MaxThreadsConstraints": {"serverRuntimes.applicationRuntimes.maxThreadsConstraintRuntimes"},
+		"RequestClasses":        {"serverRuntimes.applicationRuntimes.requestClassRuntimes"},
+	}
+
+	partitionBeans = beans{
+		"State":          {"domainPartitionRuntimes.partitionLifeCycleRuntime.state"},
+		"ResourceGroups": {"domainPartitionRuntimes.partitionLifeCycleRuntime.resourceGroupLifeCycleRuntimes"},
+		"Servers":        {"serverRuntimes"},
+	}
+)
+
+// healthState is how run-time beans report their health, e.g., {"state": "ok"}.
+type healthState struct {
+	State string `json:"state"`
+}
+
+// String formats the health as tenant-monitoring does, e.g., HEALTH_OK.
+func (h healthState) String() string {
+	if h.State == "" {
+		return ""
+	}
+	return "HEALTH_" + strings.ToUpper(h.State)
+}
+
+// runtimeSearchResult is the tree of beans returned by the searches of RuntimePath.  The run-time statistics are
+// decoded straight into the tenant-monitoring types, whose fields are named for the same bean attributes.
+type runtimeSearchResult struct {
+	DomainPartitionRuntimes struct {
+		Items []struct {
+			Name                      string `json:"name"`
+			PartitionLifeCycleRuntime struct {
+				State                          string `json:"state"`
+				ResourceGroupLifeCycleRuntimes struct {
+					Items []ResourceGroup `json:"items"`
+				} `json:"resourceGroupLifeCycleRuntimes"`
+			} `json:"partitionLifeCycleRuntime"`
+		} `json:"items"`
+	} `json:"domainPartitionRuntimes"`
+	ServerLifeCycleRuntimes struct {
+		Items []struct {
+			Name  string `json:"name"`
+			State string `json:"state"`
+		} `json:"items"`
+	} `json:"serverLifeCycleRuntimes"`
+	ServerRuntimes struct {
+		Items []serverRuntime `json:"items"`
+	} `json:"serverRuntimes"`
+}
+
+type serverRuntime struct {
+	Name                    string      `json:"name"`
+	HealthState             healthState `json:"healthState"`
+	CurrentMachine          string      `json:"currentMachine"`
+	WebLogicVersion         string      `json:"weblogicVersion"`
+	OpenSocketsCurrentCount float64     `json:"openSocketsCurrentCount"`
+	JVMRuntime              struct {
+		HeapSizeCurrent int     `json:"heapSizeCurrent"`
+		HeapFreeCurrent int     `json:"heapFreeCurrent"`
+		JavaVersion     string  `json:"javaVersion"`
+		OSName          string  `json:"OSName"`
+		OSVersion       string  `json:"OSVersion"`
+		ProcessCPULoad  float64 `json:"processCpuLoad"`
+	} `json:"JVMRuntime"`
+	ClusterRuntime *struct {
+		Name                   string `json:"name"`
+		ResendRequestsCount    int    `json:"resendRequestsCount"`
+		FragmentsSentCount     int    `json:"fragmentsSentCount"`
+		FragmentsReceivedCount int    `json:"fragmentsReceivedCount"`
+	} `json:"clusterRuntime"`
+	ServerMigrationRuntime struct {
+		ClusterMaster bool `json:"clusterMaster"`
+	} `json:"serverMigrationRuntime"`
+	JDBCServiceRuntime struct {
+		JDBCDataSourceRuntimeMBeans struct {
+			Items []dataSourceRuntime `json:"items"`
+		} `json:"JDBCDataSourceRuntimeMBeans"`
+	} `json:"JDBCServiceRuntime"`
+	ApplicationRuntimes struct {
+		Items []applicationRuntime `json:"items"`
+	} `json:"applicationRuntimes"`
+	PartitionRuntimes struct {
+		Items []partitionRuntime `json:"items"`
+	} `json:"partitionRuntimes"`
+}
+
+// partitionRuntime is a partition running on one server.
+type partitionRuntime struct {
+	Name                            string             `json:"name"`
+	State                           string             `json:"state"`
+	PartitionResourceMetricsRuntime PartitionResources `json:"partitionResourceMetricsRuntime"`
+	JDBCPartitionRuntime            struct {
+		JDBCDataSourceRuntimeMBeans struct {
+			Items []dataSourceRuntime `json:"items"`
+		} `json:"JDBCDataSourceRuntimeMBeans"`
+	} `json:"JDBCPartitionRuntime"`
+	ApplicationRuntimes struct {
+		Items []applicationRuntime `json:"items"`
+	} `json:"applicationRuntimes"`
+}
+
+// dataSourceRuntime is the instance of a data source on one server.
+type dataSourceRuntime struct {
+	Name string `json:"name"`
+	DataSourceInstance
+}
+
+type applicationRuntime struct {
+	Name                string      `json:"name"`
+	HealthState         healthState `json:"healthState"`
+	WorkManagerRuntimes struct {
+		Items []WorkManager `json:"items"`
+	} `json:"workManagerRuntimes"`
+	MinThreadsConstraintRuntimes struct {
+		Items []MinThreadsConstraint `json:"items"`
+	} `json:"minThreadsConstraintRuntimes"`
+	MaxThreadsConstraintRuntimes struct {
+		Items []MaxThreadsConstraint `json:"items"`
+	} `json:"maxThreadsConstraintRuntimes"`
+	RequestClassRuntimes struct {
+		Items []RequestClass `json:"items"`
+	} `json:"requestClassRuntimes"`
+}
+
+// searchRuntime POSTs a search of RuntimePath.
+func (c *Client) searchRuntime(ctx context.Context, search string) (*runtimeSearchResult, error) {
+	data, err := c.send(ctx, http.MethodPost, c.server.AdminURL+RuntimePath+"/search", []byte(search))
+	if err != nil {
+		return nil, err
+	}
+	var result runtimeSearchResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// searchScoped is searchRuntime for servers, data sources and applications, narrowed to the Client's partition, if it
+// has one.
+func (c *Client) searchScoped(ctx context.Context, search string) (*runtimeSearchResult, error) {
+	if c.partition == "" {
+		return c.searchRuntime(ctx, search)
+	}
+	result, err := c.searchRuntime(ctx, partitionScopeSearch)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.scope(c.partition); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scope narrows the result to the servers the partition is running on, with the partition's data sources and
+// applications in place of the server's own.
+func (r *runtimeSearchResult) scope(partition string) error {
+	found := false
+	for _, p := range r.DomainPartitionRuntimes.Items {
+		found = found || p.Name == partition
+	}
+	if !found {
+		return notFound("partitions", partition)
+	}
+
+	hosts := make(map[string]bool)
+	var runtimes []serverRuntime
+	for _, rt := range r.ServerRuntimes.Items {
+		for _, pr := range rt.PartitionRuntimes.Items {
+			if pr.Name != partition {
+				continue
+			}
+			rt.JDBCServiceRuntime.JDBCDataSourceRuntimeMBeans = pr.JDBCPartitionRuntime.JDBCDataSourceRuntimeMBeans
+			rt.ApplicationRuntimes = pr.ApplicationRuntimes
+			hosts[rt.Name] = true
+			runtimes = append(runtimes, rt)
+		}
+	}
+	r.ServerRuntimes.Items = runtimes
+
+	lifeCycles := r.ServerLifeCycleRuntimes.Items[:0]
+	for _, lc := range r.ServerLifeCycleRuntimes.Items {
+		if hosts[lc.Name] {
+			lifeCycles = append(lifeCycles, lc)
+		}
+	}
+	r.ServerLifeCycleRuntimes.Items = lifeCycles
+	return nil
+}
+
+// notFound is the error for a resource the run-time beans don't have, a 404 like the AdminServer's own.
+func notFound(resource, name string) error {
+	return &ResponseError{StatusCode: http.StatusNotFound, Body: fmt.Sprintf("no %v named %v", resource, name)}
+}
+
+// runtimeServers lists every server, in the order WebLogic lists their life cycles, or only those running the
+// Client's partition.  Only Name, State and Health are set unless opts.Full; servers that aren't running have no
+// run-time statistics.
+func (c *Client) runtimeServers(ctx context.Context, opts QueryOptions) ([]Server, error) {
+	result, err := c.searchScoped(ctx, opts.search(serversSearch, serverBeans, "Health"))
+	if err != nil {
+		return nil, err
+	}
+	running := make(map[string]serverRuntime)
+	for _, rt := range result.ServerRuntimes.Items {
+		running[rt.Name] = rt
+	}
+	var servers []Server
+	for _, lc := range result.ServerLifeCycleRuntimes.Items {
+		s := Server{Name: lc.Name, State: lc.State}
+		rt, ok := running[lc.Name]
+		if ok {
+			s.Health = rt.HealthState.String()
+		}
+		if ok && opts.Full {
+			if rt.ClusterRuntime != nil {
+				s.ClusterName = rt.ClusterRuntime.Name
+			}
+			s.CurrentMachine = rt.CurrentMachine
+			s.WebLogicVersion = rt.WebLogicVersion
+			s.OpenSocketsCurrentCount = rt.OpenSocketsCurrentCount
+			s.HeapSizeCurrent = rt.JVMRuntime.HeapSizeCurrent
+			s.HeapFreeCurrent = rt.JVMRuntime.HeapFreeCurrent
+			s.JavaVersion = rt.JVMRuntime.JavaVersion
+			s.OsName = rt.JVMRuntime.OSName
+			s.OsVersion = rt.JVMRuntime.OSVersion
+			s.JvmProcessorLoad = rt.JVMRuntime.ProcessCPULoad
+		}
+		servers = append(servers, s)
+	}
+	return servers, nil
+}
+
+// runtimeServer gets a single server in full format.
+func (c *Client) runtimeServer(ctx context.Context, name string) (*Server, error) {
+	servers, err := c.runtimeServers(ctx, QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if servers[i].Name == name {
+			return &servers[i], nil
+		}
+	}
+	return nil, notFound("servers", name)
+}
+
+// runtimeClusters lists every cluster in the configuration, with each member's state from its life cycle and the
+// rest from its run-time beans when it is running.  Only Name, State and Health are set unless opts.Full, and
+// DropOutFrequency is never set, as no run-time bean reports it.
+func (c *Client) runtimeClusters(ctx context.Context, opts QueryOptions) ([]Cluster, error) {
+	cfg, err := c.Configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.searchRuntime(ctx, opts.search(clustersSearch, clusterBeans, "Servers"))
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]string)
+	for _, lc := range result.ServerLifeCycleRuntimes.Items {
+		states[lc.Name] = lc.State
+	}
+	running := make(map[string]serverRuntime)
+	for _, rt := range result.ServerRuntimes.Items {
+		running[rt.Name] = rt
+	}
+
+	var clusters []Cluster
+	for _, name := range cfg.Clusters {
+		cluster := Cluster{Name: name}
+		for _, s := range cfg.Servers {
+			if s.Cluster.Name() != name {
+				continue
+			}
+			member := ClusterServer{Name: s.Name, State: states[s.Name]}
+			if rt, ok := running[s.Name]; ok {
+				member.Health = rt.HealthState.String()
+				if opts.Full && rt.ClusterRuntime != nil {
+					member.IsClusterMaster = rt.ServerMigrationRuntime.ClusterMaster
+					member.ResendRequestsCount = rt.ClusterRuntime.ResendRequestsCount
+					member.FragmentsSentCount = rt.ClusterRuntime.FragmentsSentCount
+					member.FragmentsReceivedCount = rt.ClusterRuntime.FragmentsReceivedCount
+				}
+			}
+			cluster.Servers = append(cluster.Servers, member)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+// runtimeCluster gets a single cluster in full format.
+func (c *Client) runtimeCluster(ctx context.Context, name string) (*Cluster, error) {
+	clusters, err := c.runtimeClusters(ctx, QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters {
+		if clusters[i].Name == name {
+			return &clusters[i], nil
+		}
+	}
+	return nil, notFound("clusters", name)
+}
+
+// runtimeDataSources lists every data source deployed to a running server, sorted by name, with an instance for each
+// server when opts.Full.  A Client scoped WithPartition lists the partition's data sources.  The run-time beans
+// don't say whether a data source is Generic or GridLink, so Type is blank, and Oracle RAC statistics aren't
+// requested.
+func (c *Client) runtimeDataSources(ctx context.Context, opts QueryOptions) ([]DataSource, error) {
+	result, err := c.searchScoped(ctx, opts.search(dataSourcesSearch, dataSourceBeans))
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*DataSource)
+	var names []string
+	for _, rt := range result.ServerRuntimes.Items {
+		for _, dsr := range rt.JDBCServiceRuntime.JDBCDataSourceRuntimeMBeans.Items {
+			ds := byName[dsr.Name]
+			if ds == nil {
+				ds = &DataSource{Name: dsr.Name}
+				byName[dsr.Name] = ds
+				names = append(names, dsr.Name)
+			}
+			if opts.Full {
+				inst := dsr.DataSourceInstance
+				inst.Server = rt.Name
+				ds.Instances = append(ds.Instances, inst)
+			}
+		}
+	}
+	sort.Strings(names)
+	dataSources := make([]DataSource, len(names))
+	for i, name := range names {
+		dataSources[i] = *byName[name]
+	}
+	return dataSources, nil
+}
+
+// runtimeDataSource gets a single data source in full format.
+func (c *Client) runtimeDataSource(ctx context.Context, name string) (*DataSource, error) {
+	dataSources, err := c.runtimeDataSources(ctx, QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := range dataSources {
+		if dataSources[i].Name == name {
+			return &dataSources[i], nil
+		}
+	}
+	return nil, notFound("datasources", name)
+}
+
+// runtimeApplications lists every application running on a server, sorted by name.  An application is targeted to,
+// and STATE_ACTIVE on, each server it runs on, and its Health is the worst of theirs.  The run-time beans don't give
+// the application's type or the data sources it uses.  Targets, work managers, constraints and request classes are
+// only set when opts.Full.  A Client scoped WithPartition lists the partition's applications.
+func (c *Client) runtimeApplications(ctx context.Context, opts QueryOptions) ([]Application, error) {
+	result, err := c.searchScoped(ctx, opts.search(applicationsSearch, applicationBeans, "Health"))
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*Application)
+	var names []string
+	for _, rt := range result.ServerRuntimes.Items {
+		for _, ar := range rt.ApplicationRuntimes.Items {
+			app := byName[ar.Name]
+			if app == nil {
+				app = &Application{Name: ar.Name, State: "STATE_ACTIVE"}
+				byName[ar.Name] = app
+				names = append(names, ar.Name)
+			}
+			if health := ar.HealthState.String(); healthRank(health) > healthRank(app.Health) {
+				app.Health = health
+			}
+			if !opts.Full {
+				continue
+			}
+			app.TargetStates = append(app.TargetStates, TargetState{Target: rt.Name, State: "STATE_ACTIVE"})
+			for _, wm := range ar.WorkManagerRuntimes.Items {
+				wm.Server = rt.Name
+				app.WorkManagers = append(app.WorkManagers, wm)
+			}
+			for _, mtc := range ar.MinThreadsConstraintRuntimes.Items {
+				mtc.Server = rt.Name
+				app.MinThreadsConstraints = append(app.MinThreadsConstraints, mtc)
+			}
+			for _, mtc := range ar.MaxThreadsConstraintRuntimes.Items {
+				mtc.Server = rt.Name
+				app.MaxThreadsConstraints = append(app.MaxThreadsConstraints, mtc)
+			}
+			for _, rc := range ar.RequestClassRuntimes.Items {
+				rc.Server = rt.Name
+				app.RequestClasses = append(app.RequestClasses, rc)
+			}
+		}
+	}
+	sort.Strings(names)
+	applications := make([]Application, len(names))
+	for i, name := range names {
+		applications[i] = *byName[name]
+	}
+	return applications, nil
+}
+
+// runtimeApplication gets a single application in full format.
+func (c *Client) runtimeApplication(ctx context.Context, name string) (*Application, error) {
+	applications, err := c.runtimeApplications(ctx, QueryOptions{Full: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := range applications {
+		if applications[i].Name == name {
+			return &applications[i], nil
+		}
+	}
+	return nil, notFound("applications", name)
+}
+
+// runtimePartitions lists every domain partition, in the order WebLogic lists them, with the resource groups and the
+// partition's run-time on each server when opts.Full.
+func (c *Client) runtimePartitions(ctx context.Context, opts QueryOptions) ([]Partition, error) {
+	result, err := c.searchRuntime(ctx, opts.search(partitionsSearch, partitionBeans, "State"))
+	if err != nil {
+		return nil, err
+	}
+	var partitions []Partition
+	for _, dp := range result.DomainPartitionRuntimes.Items {
+		p := Partition{Name: dp.Name, State: dp.PartitionLifeCycleRuntime.State}
+		if opts.Full {
+			p.ResourceGroups = dp.PartitionLifeCycleRuntime.ResourceGroupLifeCycleRuntimes.Items
+			for _, rt := range result.ServerRuntimes.Items {
+				for _, pr := range rt.PartitionRuntimes.Items {
+					if pr.Name == dp.Name {
+						p.Servers = append(p.Servers, PartitionServer{Server: rt.Name, State: pr.State, PartitionResources: pr.PartitionResourceMetricsRuntime})
+					}
+				}
+			}
+		}
+		partitions = append(partitions, p)
+	}
+	return partitions, nil
+}
+
+// healthRank orders health from best to worst, so the worst of several can be found.
+func healthRank(health string) int {
+	switch health {
+	case "":
+		return 0
+	case "HEALTH_OK":
+		return 1
+	case "HEALTH_WARN":
+		return 2
+	case "HEALTH_OVERLOADED":
+		return 3
+	case "HEALTH_CRITICAL":
+		return 4
+	default:
+		return 5
+	}
+}
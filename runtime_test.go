@@ -0,0 +1,170 @@
+package remy
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var runtimeJSON = `{
+  "serverLifeCycleRuntimes": {"items": [
+    {"name": "AdminServer", "state": "RUNNING"},
+    {"name": "ms1", "state": "RUNNING"},
+    {"name": "ms2", "state": "SHUTDOWN"}
+  ]},
+  "serverRuntimes": {"items": [
+    {
+      "name": "AdminServer",
+      "healthState": {"state": "ok", "subsystemName": null},
+      "weblogicVersion": "WebLogic Server 14.1.1.0.0 Thu Mar 26 03:15:09 GMT 2020 2000885",
+      "JVMRuntime": {"heapSizeCurrent": 536870912, "heapFreeCurrent": 268435456},
+      "JDBCServiceRuntime": {"JDBCDataSourceRuntimeMBeans": {"items": []}},
+      "applicationRuntimes": {"items": []}
+    },
+    {
+      "name": "ms1",
+      "healthState": {"state": "warn"},
+      "currentMachine": "machine-1",
+      "openSocketsCurrentCount": 3,
+      "JVMRuntime": {"heapSizeCurrent": 1073741824, "heapFreeCurrent": 536870912, "javaVersion": "11.0.6", "OSName": "Linux", "processCpuLoad": 0.25},
+      "clusterRuntime": {"name": "cluster1", "resendRequestsCount": 1, "fragmentsSentCount": 200, "fragmentsReceivedCount": 180},
+      "serverMigrationRuntime": {"clusterMaster": true},
+      "JDBCServiceRuntime": {"JDBCDataSourceRuntimeMBeans": {"items": [
+        {"name": "ds1", "state": "Running", "enabled": true, "activeConnectionsCurrentCount": 4, "versionJDBCDriver": "oracle.jdbc.OracleDriver"}
+      ]}},
+      "applicationRuntimes": {"items": [
+        {
+          "name": "app1",
+          "healthState": {"state": "ok"},
+          "workManagerRuntimes": {"items": [{"name": "default", "pendingRequests": 2, "completedRequests": 50}]},
+          "minThreadsConstraintRuntimes": {"items": []},
+          "maxThreadsConstraintRuntimes": {"items": [{"name": "max", "executingRequests": 5, "deferredRequests": 1}]},
+          "requestClassRuntimes": {"items": []}
+        }
+      ]}
+    }
+  ]}
+}`
+
+var domainConfigJSON = `{
+  "adminServerName": "AdminServer",
+  "clusters": {"items": [{"name": "cluster1"}]},
+  "servers": {"items": [
+    {"name": "AdminServer", "cluster": null},
+    {"name": "ms1", "cluster": ["clusters", "cluster1"]},
+    {"name": "ms2", "cluster": ["clusters", "cluster1"]}
+  ]},
+  "JDBCSystemResources": {"items": []},
+  "appDeployments": {"items": []}
+}`
+
+// newRuntimeClient is a Client for an AdminServer serving only the WLS 14.1.1 REST trees, recording the searches made.
+func newRuntimeClient(t *testing.T) (*Client, *[]string, func()) {
+	var searches []string
+	r := latestRouter("14.1.1.0.0", false)
+	r.HandleFunc(RuntimePath+"/search", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		searches = append(searches, string(body))
+		assert.Equal(t, "remy", req.Header.Get("X-Requested-By"))
+		w.Write([]byte(runtimeJSON))
+	}).Methods("POST")
+	r.HandleFunc(ConfigPath+"/search", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(domainConfigJSON))
+	}).Methods("POST")
+	ts := httptest.NewServer(r)
+	return NewClient(&AdminServer{AdminURL: ts.URL}), &searches, ts.Close
+}
+
+func TestRuntimeServers(t *testing.T) {
+	client, searches, cleanup := newRuntimeClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	servers, err := client.Servers(ctx, QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Server{
+		{Name: "AdminServer", State: "RUNNING", Health: "HEALTH_OK"},
+		{Name: "ms1", State: "RUNNING", Health: "HEALTH_WARN"},
+		{Name: "ms2", State: "SHUTDOWN"},
+	}, servers)
+	assert.Contains(t, (*searches)[0], "serverLifeCycleRuntimes")
+
+	ms1, err := client.Server(ctx, "ms1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Server{Name: "ms1", State: "RUNNING", Health: "HEALTH_WARN", ClusterName: "cluster1", CurrentMachine: "machine-1",
+		OpenSocketsCurrentCount: 3, HeapSizeCurrent: 1073741824, HeapFreeCurrent: 536870912, JavaVersion: "11.0.6", OsName: "Linux",
+		JvmProcessorLoad: 0.25}, ms1)
+
+	_, err = client.Server(ctx, "ms9")
+	assert.True(t, strings.HasPrefix(err.Error(), "Invalid Response Code: 404"))
+	assert.True(t, IsNotFound(err))
+}
+
+func TestRuntimeSearchRetried(t *testing.T) {
+	attempts := 0
+	r := latestRouter("14.1.1.0.0", false)
+	r.HandleFunc(RuntimePath+"/search", func(w http.ResponseWriter, req *http.Request) {
+		if attempts++; attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(runtimeJSON))
+	}).Methods("POST")
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(1))
+	servers, err := client.Servers(context.Background(), QueryOptions{})
+	assert.NoError(t, err, "searches only read, so they are retried like GETs")
+	assert.Len(t, servers, 3)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRuntimeClusters(t *testing.T) {
+	client, _, cleanup := newRuntimeClient(t)
+	defer cleanup()
+
+	cluster, err := client.Cluster(context.Background(), "cluster1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Cluster{Name: "cluster1", Servers: []ClusterServer{
+		{Name: "ms1", State: "RUNNING", Health: "HEALTH_WARN", IsClusterMaster: true, ResendRequestsCount: 1, FragmentsSentCount: 200, FragmentsReceivedCount: 180},
+		{Name: "ms2", State: "SHUTDOWN"},
+	}}, cluster)
+}
+
+func TestRuntimeDataSources(t *testing.T) {
+	client, _, cleanup := newRuntimeClient(t)
+	defer cleanup()
+
+	dataSources, err := client.DataSources(context.Background(), QueryOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []DataSource{{Name: "ds1"}}, dataSources)
+
+	ds, err := client.DataSource(context.Background(), "ds1")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, ds.Instances[0].ActiveConnectionsCurrentCount)
+	assert.Equal(t, "oracle.jdbc.OracleDriver", ds.Instances[0].VersionJDBCDriver)
+}
+
+func TestRuntimeApplications(t *testing.T) {
+	client, _, cleanup := newRuntimeClient(t)
+	defer cleanup()
+
+	app, err := client.Application(context.Background(), "app1")
+	assert.NoError(t, err)
+	assert.Equal(t, "STATE_ACTIVE", app.State)
+	assert.Equal(t, "HEALTH_OK", app.Health)
+	assert.Equal(t, []TargetState{{Target: "ms1", State: "STATE_ACTIVE"}}, app.TargetStates)
+	assert.Equal(t, []WorkManager{{Name: "default", Server: "ms1", PendingRequests: 2, CompletedRequests: 50}}, app.WorkManagers)
+	assert.Equal(t, []MaxThreadsConstraint{{Name: "max", Server: "ms1", ExecutingRequests: 5, DeferredRequests: 1}}, app.MaxThreadsConstraints)
+
+	apps, err := client.Applications(context.Background(), QueryOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, apps, 1)
+	assert.Equal(t, Application{Name: "app1", State: "STATE_ACTIVE", Health: "HEALTH_OK"}, apps[0])
+}
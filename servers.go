@@ -2,6 +2,7 @@ package remy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -45,13 +46,26 @@ func (s *Server) GoString() string {
 }
 
 // Servers returns all servers configured in a domain and provides run-time information for each server, including the server state and health.
-// isFullFormat determines whether to return a fully-filled out list of Servers, or only a shortened version of the Servers list.
-func (a *AdminServer) Servers(isFullFormat bool) ([]Server, error) {
-	url := fmt.Sprintf("%v%v/servers", a.AdminURL, MonitorPath)
-	if isFullFormat {
+// opts determine whether to return a fully-filled out list of Servers, or only a shortened version of the Servers list, and which fields and page of it to return.
+func (c *Client) Servers(ctx context.Context, opts QueryOptions) (list []Server, err error) {
+	ctx, span := c.startSpan(ctx, "Servers", "servers", "")
+	defer func() { endSpan(span, err) }()
+	if opts, err = opts.resolve(Server{}); err != nil {
+		return nil, err
+	}
+	defer func() { opts.apply(&list) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeServers(ctx, opts)
+	}
+	url := c.resourceURL("servers")
+	if opts.Full {
 		url = url + "?format=full"
 	}
-	w, err := requestAndUnmarshal(url, a)
+	w, err := c.requestAndUnmarshal(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -63,9 +77,17 @@ func (a *AdminServer) Servers(isFullFormat bool) ([]Server, error) {
 }
 
 // Server returns information for a specified server in a domain, including the server state, health, and JVM heap availability.
-func (a *AdminServer) Server(serverName string) (*Server, error) {
-	url := fmt.Sprintf("%v%v/servers/%v", a.AdminURL, MonitorPath, serverName)
-	w, err := requestAndUnmarshal(url, a)
+func (c *Client) Server(ctx context.Context, serverName string) (_ *Server, err error) {
+	ctx, span := c.startSpan(ctx, "Server", "servers", serverName)
+	defer func() { endSpan(span, err) }()
+	tree, err := c.resourceTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tree == WebLogic {
+		return c.runtimeServer(ctx, serverName)
+	}
+	w, err := c.requestAndUnmarshal(ctx, c.resourceURL("servers", serverName))
 	if err != nil {
 		return nil, err
 	}
@@ -75,3 +97,16 @@ func (a *AdminServer) Server(serverName string) (*Server, error) {
 	}
 	return &server, nil
 }
+
+// Servers returns all servers configured in the domain using a default Client.  See Client.Servers.
+//
+// Deprecated: Servers only chooses between the short and full format.  Use Client.Servers, whose QueryOptions also
+// select fields and page through the servers.
+func (a *AdminServer) Servers(isFullFormat bool) ([]Server, error) {
+	return NewClient(a).Servers(context.Background(), QueryOptions{Full: isFullFormat})
+}
+
+// Server returns information for a specified server in the domain using a default Client.  See Client.Server.
+func (a *AdminServer) Server(serverName string) (*Server, error) {
+	return NewClient(a).Server(context.Background(), serverName)
+}
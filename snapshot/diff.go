@@ -0,0 +1,181 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ChangeKind describes how a resource differs between two snapshots.
+type ChangeKind string
+
+// The kinds of Change reported by Diff.
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Change is a single difference between two snapshots.  Nested resources are named for their parent, e.g., the
+// instance of data source ds1 on ms1 is "ds1/ms1", and application app1's target cluster1 is "app1/cluster1".
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Resource string     `json:"resource"`
+	Name     string     `json:"name"`
+	Field    string     `json:"field,omitempty"`
+	From     string     `json:"from,omitempty"`
+	To       string     `json:"to,omitempty"`
+}
+
+// Report is every Change between two snapshots, ordered by resource and then name.
+type Report struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Changes []Change  `json:"changes"`
+}
+
+// Resources compared by Diff.
+const (
+	ServerResource             = "server"
+	ClusterResource            = "cluster"
+	ClusterMemberResource      = "cluster member"
+	DataSourceResource         = "datasource"
+	DataSourceInstanceResource = "datasource instance"
+	ApplicationResource        = "application"
+	ApplicationTargetResource  = "application target"
+)
+
+// The fields compared for each resource that exists in both snapshots.
+var (
+	serverFields             = []string{"State", "Health", "ClusterName", "CurrentMachine", "WebLogicVersion", "JavaVersion"}
+	clusterMemberFields      = []string{"State", "Health", "IsClusterMaster"}
+	dataSourceFields         = []string{"Type"}
+	dataSourceInstanceFields = []string{"State", "Enabled", "VersionJDBCDriver"}
+	applicationFields        = []string{"AppType", "State", "Health"}
+	applicationTargetFields  = []string{"State"}
+)
+
+// Diff reports the servers, clusters, datasources and applications added, removed or changed going from a to b:
+// server state and health, cluster membership, datasource instances, and the targets applications are deployed to.
+func Diff(a, b *Snapshot) *Report {
+	r := &Report{From: a.Taken, To: b.Taken}
+
+	servers := func(s *Snapshot) map[string]interface{} {
+		m := make(map[string]interface{})
+		for _, srv := range s.Servers {
+			m[srv.Name] = srv
+		}
+		return m
+	}
+	r.compare(ServerResource, servers(a), servers(b), serverFields)
+
+	clusters := func(s *Snapshot) (map[string]interface{}, map[string]interface{}) {
+		cs, members := make(map[string]interface{}), make(map[string]interface{})
+		for _, c := range s.Clusters {
+			cs[c.Name] = c
+			for _, m := range c.Servers {
+				members[c.Name+"/"+m.Name] = m
+			}
+		}
+		return cs, members
+	}
+	ac, am := clusters(a)
+	bc, bm := clusters(b)
+	r.compare(ClusterResource, ac, bc, nil)
+	r.compare(ClusterMemberResource, am, bm, clusterMemberFields)
+
+	dataSources := func(s *Snapshot) (map[string]interface{}, map[string]interface{}) {
+		ds, instances := make(map[string]interface{}), make(map[string]interface{})
+		for _, d := range s.DataSources {
+			ds[d.Name] = d
+			for _, inst := range d.Instances {
+				instances[d.Name+"/"+inst.Server] = inst
+			}
+		}
+		return ds, instances
+	}
+	ad, ai := dataSources(a)
+	bd, bi := dataSources(b)
+	r.compare(DataSourceResource, ad, bd, dataSourceFields)
+	r.compare(DataSourceInstanceResource, ai, bi, dataSourceInstanceFields)
+
+	applications := func(s *Snapshot) (map[string]interface{}, map[string]interface{}) {
+		apps, targets := make(map[string]interface{}), make(map[string]interface{})
+		for _, app := range s.Applications {
+			apps[app.Name] = app
+			for _, t := range app.TargetStates {
+				targets[app.Name+"/"+t.Target] = t
+			}
+		}
+		return apps, targets
+	}
+	aa, at := applications(a)
+	ba, bt := applications(b)
+	r.compare(ApplicationResource, aa, ba, applicationFields)
+	r.compare(ApplicationTargetResource, at, bt, applicationTargetFields)
+
+	return r
+}
+
+// compare adds a Change for every name only in a (removed), only in b (added), and for every field that differs
+// between the two.
+func (r *Report) compare(resource string, a, b map[string]interface{}, fields []string) {
+	names := make(map[string]bool)
+	for name := range a {
+		names[name] = true
+	}
+	for name := range b {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		av, inA := a[name]
+		bv, inB := b[name]
+		switch {
+		case !inA:
+			r.Changes = append(r.Changes, Change{Kind: Added, Resource: resource, Name: name})
+		case !inB:
+			r.Changes = append(r.Changes, Change{Kind: Removed, Resource: resource, Name: name})
+		default:
+			for _, field := range fields {
+				from := fmt.Sprint(reflect.ValueOf(av).FieldByName(field).Interface())
+				to := fmt.Sprint(reflect.ValueOf(bv).FieldByName(field).Interface())
+				if from != to {
+					r.Changes = append(r.Changes, Change{Kind: Changed, Resource: resource, Name: name, Field: field, From: from, To: to})
+				}
+			}
+		}
+	}
+}
+
+// Empty reports whether the two snapshots were the same.
+func (r *Report) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// String formats the Report for the console, one line per Change.
+func (r *Report) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Changes from %v to %v\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339)))
+	if r.Empty() {
+		buffer.WriteString("No changes\n")
+	}
+	for _, c := range r.Changes {
+		switch c.Kind {
+		case Added:
+			buffer.WriteString(fmt.Sprintf("+ %v %v\n", c.Resource, c.Name))
+		case Removed:
+			buffer.WriteString(fmt.Sprintf("- %v %v\n", c.Resource, c.Name))
+		case Changed:
+			buffer.WriteString(fmt.Sprintf("~ %v %v %v: %q -> %q\n", c.Resource, c.Name, c.Field, c.From, c.To))
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,44 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func snapshotOf(d remytest.Domain) *Snapshot {
+	return &Snapshot{Version: FormatVersion, Servers: d.Servers, Clusters: d.Clusters, DataSources: d.DataSources, Applications: d.Applications}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	d := remytest.DefaultDomain()
+	r := Diff(snapshotOf(d), snapshotOf(d))
+	assert.True(t, r.Empty())
+	assert.Contains(t, r.String(), "No changes")
+}
+
+func TestDiff(t *testing.T) {
+	before := remytest.DefaultDomain()
+	after := remytest.GenerateDomain(remytest.DomainConfig{ManagedServers: 3, Clusters: 1, DataSources: 1, Applications: 1})
+	after.Servers[1].State = "SHUTDOWN"
+	after.Servers[1].Health = ""
+	after.DataSources[0].Instances[1].Enabled = false
+	after.Applications[0].TargetStates = []remy.TargetState{{Target: "ms3", State: "STATE_ACTIVE"}}
+
+	r := Diff(snapshotOf(before), snapshotOf(after))
+	assert.Contains(t, r.Changes, Change{Kind: Added, Resource: ServerResource, Name: "ms3"})
+	assert.Contains(t, r.Changes, Change{Kind: Changed, Resource: ServerResource, Name: "ms1", Field: "State", From: "RUNNING", To: "SHUTDOWN"})
+	assert.Contains(t, r.Changes, Change{Kind: Changed, Resource: ServerResource, Name: "ms1", Field: "Health", From: "HEALTH_OK", To: ""})
+	assert.Contains(t, r.Changes, Change{Kind: Added, Resource: ClusterMemberResource, Name: "cluster1/ms3"})
+	assert.Contains(t, r.Changes, Change{Kind: Added, Resource: DataSourceInstanceResource, Name: "ds1/ms3"})
+	assert.Contains(t, r.Changes, Change{Kind: Changed, Resource: DataSourceInstanceResource, Name: "ds1/ms2", Field: "Enabled", From: "true", To: "false"})
+	assert.Contains(t, r.Changes, Change{Kind: Removed, Resource: ApplicationTargetResource, Name: "app1/cluster1"})
+	assert.Contains(t, r.Changes, Change{Kind: Added, Resource: ApplicationTargetResource, Name: "app1/ms3"})
+
+	out := r.String()
+	assert.Contains(t, out, "+ server ms3")
+	assert.Contains(t, out, `~ server ms1 State: "RUNNING" -> "SHUTDOWN"`)
+	assert.Contains(t, out, "- application target app1/cluster1")
+}
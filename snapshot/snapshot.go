@@ -0,0 +1,71 @@
+// Package snapshot captures the full state of a domain, meaning every server, cluster, datasource and application in
+// full format, to a versioned JSON file, and reports the differences between two snapshots.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/klauern/remy"
+)
+
+// FormatVersion is the version of the snapshot file format written by Save.  Load refuses files with a newer version.
+const FormatVersion = 1
+
+// Snapshot is the state of a domain at a point in time.
+type Snapshot struct {
+	Version      int                `json:"version"`
+	Taken        time.Time          `json:"taken"`
+	AdminURL     string             `json:"adminURL"`
+	Servers      []remy.Server      `json:"servers"`
+	Clusters     []remy.Cluster     `json:"clusters"`
+	DataSources  []remy.DataSource  `json:"datasources"`
+	Applications []remy.Application `json:"applications"`
+}
+
+// Take requests the full format of every resource from the Client's AdminServer.
+func Take(ctx context.Context, client *remy.Client) (*Snapshot, error) {
+	s := &Snapshot{Version: FormatVersion, Taken: time.Now(), AdminURL: client.AdminServer().AdminURL}
+	var err error
+	if s.Servers, err = client.Servers(ctx, remy.QueryOptions{Full: true}); err != nil {
+		return nil, err
+	}
+	if s.Clusters, err = client.Clusters(ctx, remy.QueryOptions{Full: true}); err != nil {
+		return nil, err
+	}
+	if s.DataSources, err = client.DataSources(ctx, remy.QueryOptions{Full: true}); err != nil {
+		return nil, err
+	}
+	if s.Applications, err = client.Applications(ctx, remy.QueryOptions{Full: true}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the Snapshot to path as indented JSON.
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Load reads a Snapshot written by Save.
+func Load(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot %v: %v", path, err)
+	}
+	if s.Version > FormatVersion {
+		return nil, fmt.Errorf("snapshot %v is version %v, newer than the supported version %v", path, s.Version, FormatVersion)
+	}
+	return &s, nil
+}
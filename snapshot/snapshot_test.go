@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/remy"
+	"github.com/klauern/remy/remytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeSaveAndLoad(t *testing.T) {
+	fake := remytest.New()
+	ts := fake.Start()
+	defer ts.Close()
+
+	s, err := Take(context.Background(), remy.NewClient(fake.AdminServer(ts.URL)))
+	assert.NoError(t, err)
+	assert.Equal(t, FormatVersion, s.Version)
+	assert.Len(t, s.Servers, 3)
+	assert.Equal(t, 536870912, s.Servers[1].HeapSizeCurrent, "servers should be full format")
+	assert.Len(t, s.DataSources[0].Instances, 2)
+
+	dir, err := ioutil.TempDir("", "remy-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "before.json")
+	assert.NoError(t, s.Save(path))
+
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Servers, loaded.Servers)
+	assert.Equal(t, s.Applications, loaded.Applications)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"version": 99}`), 0644))
+	_, err = Load(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by this package.
+const tracerName = "github.com/klauern/remy"
+
+// Span attribute keys set by the Client.
+const (
+	// DomainAttribute is the host:port of the AdminServer a span's request was sent to.
+	DomainAttribute = attribute.Key("remy.domain")
+	// ResourceAttribute is the resource requested: servers, clusters, datasources or applications.
+	ResourceAttribute = attribute.Key("remy.resource")
+	// NameAttribute is the name of the single server, cluster, data source or application requested, if any.
+	NameAttribute = attribute.Key("remy.name")
+	// RetriesAttribute is the number of times a request was retried.  See WithRetries.
+	RetriesAttribute = attribute.Key("remy.retries")
+	// RateLimitWaitAttribute is the seconds an HTTP request waited for its AdminServer's rate limit.  See
+	// AdminServer.RateLimit.
+	RateLimitWaitAttribute = attribute.Key("remy.rate_limit.wait")
+)
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to create spans.  When not set, the global
+// TracerProvider from otel.GetTracerProvider() is used, which does nothing unless the application has configured it.
+//
+// Each resource call, e.g., Client.Servers, gets a span named remy.Servers, with a child span for each HTTP request
+// sent.  Spans are children of any span in the context passed to the resource call, and the trace context is
+// propagated to the AdminServer in the request headers using the global TextMapPropagator.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		c.tracerProvider = tp
+	}
+}
+
+func (c *Client) tracer() trace.Tracer {
+	tp := c.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// domain is the host:port of the AdminServer, used to tell spans for different domains apart.
+func (c *Client) domain() string {
+	u, err := url.Parse(c.server.AdminURL)
+	if err != nil || u.Host == "" {
+		return c.server.AdminURL
+	}
+	return u.Host
+}
+
+// startSpan starts the span for a resource call such as Servers or Cluster(name), and notes the resource in the
+// context for the TTLs of a Cache.
+func (c *Client) startSpan(ctx context.Context, method, resource, name string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{DomainAttribute.String(c.domain()), ResourceAttribute.String(resource)}
+	if name != "" {
+		attrs = append(attrs, NameAttribute.String(name))
+	}
+	ctx = context.WithValue(ctx, resourceKey{}, resource)
+	return c.tracer().Start(ctx, "remy."+method, trace.WithAttributes(attrs...))
+}
+
+// startHTTPSpan starts the span for a single HTTP request, and injects its trace context in to req's headers.
+func (c *Client) startHTTPSpan(req *http.Request) (*http.Request, trace.Span) {
+	ctx, span := c.tracer().Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+			DomainAttribute.String(c.domain()),
+		))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req.WithContext(ctx), span
+}
+
+// endHTTPSpan records the outcome of a single HTTP request and ends its span.
+func endHTTPSpan(span trace.Span, resp *http.Response, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	endSpan(span, err)
+}
+
+// endSpan records err, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,70 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestResourceAndHTTPSpans(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := NewClient(&AdminServer{AdminURL: ts.URL}, WithTracerProvider(tp), WithTree(TenantMonitoring))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.Server(ctx, "adminserver")
+	assert.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	httpSpan, resourceSpan := spans[0], spans[1]
+
+	assert.Equal(t, "HTTP GET", httpSpan.Name())
+	assert.Equal(t, resourceSpan.SpanContext().SpanID(), httpSpan.Parent().SpanID())
+	assert.Equal(t, int64(http.StatusOK), spanAttributes(httpSpan)["http.response.status_code"].AsInt64())
+
+	assert.Equal(t, "remy.Server", resourceSpan.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), resourceSpan.Parent().SpanID())
+	attrs := spanAttributes(resourceSpan)
+	assert.Equal(t, "servers", attrs[ResourceAttribute].AsString())
+	assert.Equal(t, "adminserver", attrs[NameAttribute].AsString())
+	assert.Equal(t, ts.Listener.Addr().String(), attrs[DomainAttribute].AsString())
+	assert.Equal(t, int64(0), attrs[RetriesAttribute].AsInt64())
+}
+
+func TestFailedRequestSpan(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := NewClient(&AdminServer{AdminURL: ts.URL}, WithTracerProvider(tp), WithTree(TenantMonitoring))
+
+	_, err := client.Server(context.Background(), "unknown")
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, int64(http.StatusBadRequest), spanAttributes(spans[1])["http.response.status_code"].AsInt64())
+}
@@ -0,0 +1,245 @@
+package remy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VersionPath is the REST resource path from the root / to the latest version of the WebLogic RESTful management
+// services, added in WLS 12.2.1.  Its domainRuntime, domainConfig and edit trees replace tenant-monitoring, which
+// WLS 14.1.1 removed.
+const VersionPath string = "/management/weblogic/latest"
+
+// Tree is a tree of REST resources an AdminServer may serve.
+type Tree string
+
+// Trees a Client can request resources from.
+const (
+	// TenantMonitoring is the MonitorPath tree of WLS 12.1.x and 12.2.x.
+	TenantMonitoring Tree = "tenant-monitoring"
+	// WebLogic is the VersionPath tree of WLS 12.2.1 and later, with run-time beans under RuntimePath.
+	WebLogic Tree = "weblogic"
+)
+
+// WithTree makes the Client request resources from the given Tree, rather than probing the AdminServer for the
+// trees it serves.
+func WithTree(t Tree) ClientOption {
+	return func(c *Client) {
+		c.tree = t
+	}
+}
+
+// Version is a WebLogic release, e.g., 12.2.1.3.0.
+type Version []int
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// ParseVersion finds the release in s, either a bare version like "12.2.1.3.0" or a server's WebLogicVersion, such
+// as "WebLogic Server 12.1.3.0.0 Wed May 21 18:53:34 PDT 2014 1604337".
+func ParseVersion(s string) (Version, error) {
+	match := versionPattern.FindString(s)
+	if match == "" {
+		return nil, fmt.Errorf("no WebLogic version found in %q", s)
+	}
+	var v Version
+	for _, part := range strings.Split(match, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WebLogic version %q: %v", match, err)
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
+
+// AtLeast reports whether v is the release given by parts, e.g., v.AtLeast(12, 2, 1), or later.
+func (v Version) AtLeast(parts ...int) bool {
+	for i, p := range parts {
+		var n int
+		if i < len(v) {
+			n = v[i]
+		}
+		if n != p {
+			return n > p
+		}
+	}
+	return true
+}
+
+// String formats v as a dotted release, or "unknown" when it isn't known.
+func (v Version) String() string {
+	if len(v) == 0 {
+		return "unknown"
+	}
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Capabilities are what an AdminServer was found to support: its WebLogic version, the REST trees it serves, and the
+// Tree resources are requested from.
+type Capabilities struct {
+	Version Version
+	Trees   []Tree
+	// Resources is the Tree the Servers, Clusters, DataSources and Applications methods request.
+	Resources Tree
+}
+
+// Has reports whether the AdminServer serves the Tree.
+func (c *Capabilities) Has(t Tree) bool {
+	for _, tree := range c.Trees {
+		if tree == t {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the Capabilities for the console.
+func (c *Capabilities) String() string {
+	var trees []string
+	for _, t := range []Tree{WebLogic, TenantMonitoring} {
+		if c.Has(t) {
+			trees = append(trees, string(t))
+		}
+	}
+	if len(trees) == 0 {
+		trees = []string{"none"}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "WebLogic version: %v\n", c.Version)
+	fmt.Fprintf(&b, "REST trees:       %v\n", strings.Join(trees, ", "))
+	fmt.Fprintf(&b, "Resources from:   %v\n", c.Resources)
+	fmt.Fprintf(&b, "Configuration:    %v\n", c.Has(WebLogic))
+	return b.String()
+}
+
+// probe is what a Client has learned about its AdminServer.
+type probe struct {
+	sync.Mutex
+	tree         Tree
+	capabilities *Capabilities
+}
+
+// resourceTree returns the Tree resources are requested from: the one given with WithTree, otherwise WebLogic when
+// the AdminServer serves VersionPath, falling back to TenantMonitoring when it answers with a 404.  The AdminServer is
+// only asked once, unless asking fails.  A Client scoped WithPartition fails unless the Tree is WebLogic.
+func (c *Client) resourceTree(ctx context.Context) (Tree, error) {
+	tree, err := c.probeTree(ctx)
+	if err == nil && c.partition != "" && tree != WebLogic {
+		return "", c.partitionsUnsupported(tree)
+	}
+	return tree, err
+}
+
+// probeTree finds the Tree for resourceTree.
+func (c *Client) probeTree(ctx context.Context) (Tree, error) {
+	if c.tree != "" {
+		return c.tree, nil
+	}
+	c.probe.Lock()
+	defer c.probe.Unlock()
+	if c.probe.tree != "" {
+		return c.probe.tree, nil
+	}
+	version, err := c.latestVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.probe.tree = TenantMonitoring
+	if version != nil {
+		c.probe.tree = WebLogic
+	}
+	return c.probe.tree, nil
+}
+
+// latestVersion asks VersionPath for the AdminServer's release, returning nil without an error when the AdminServer
+// answers that it doesn't serve it with a 404.  Any other failure, such as a 401 or 503, is returned, so it isn't
+// mistaken for an AdminServer without the WebLogic tree.
+func (c *Client) latestVersion(ctx context.Context) (Version, error) {
+	data, err := c.request(context.WithValue(ctx, notFoundExpectedKey{}, true), c.server.AdminURL+VersionPath+"?links=none")
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var latest struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &latest); err != nil {
+		return nil, fmt.Errorf("unable to read the WebLogic version from %v: %v", VersionPath, err)
+	}
+	return ParseVersion(latest.Version)
+}
+
+// Capabilities probes the AdminServer for its WebLogic version and the REST trees it serves.  The result is cached
+// for the life of the Client.
+func (c *Client) Capabilities(ctx context.Context) (_ *Capabilities, err error) {
+	ctx, span := c.startSpan(ctx, "Capabilities", "management", "")
+	defer func() { endSpan(span, err) }()
+	c.probe.Lock()
+	defer c.probe.Unlock()
+	if c.probe.capabilities != nil {
+		return c.probe.capabilities, nil
+	}
+
+	caps := &Capabilities{}
+	if caps.Version, err = c.latestVersion(ctx); err != nil {
+		return nil, err
+	}
+	if caps.Version != nil {
+		caps.Trees = append(caps.Trees, WebLogic)
+	}
+	// Full format servers both show tenant-monitoring is served, and carry the version of releases without VersionPath.
+	// As with VersionPath, only a 404 means the tree isn't served.
+	data, err := c.request(context.WithValue(ctx, notFoundExpectedKey{}, true), c.resourceURL("servers")+"?format=full")
+	switch {
+	case err == nil:
+		caps.Trees = append(caps.Trees, TenantMonitoring)
+		if caps.Version == nil {
+			caps.Version = versionOf(data)
+		}
+	case !IsNotFound(err):
+		return nil, err
+	}
+
+	switch {
+	case c.tree != "":
+		caps.Resources = c.tree
+	case caps.Has(WebLogic):
+		caps.Resources = WebLogic
+	default:
+		caps.Resources = TenantMonitoring
+	}
+	if c.probe.tree == "" {
+		c.probe.tree = caps.Resources
+	}
+	c.probe.capabilities = caps
+	return caps, nil
+}
+
+// versionOf finds the release of the first server in a tenant-monitoring servers response that reports one.
+func versionOf(data []byte) Version {
+	w, err := unmarshalWrapper(data)
+	if err != nil {
+		return nil
+	}
+	var servers []Server
+	if err := json.Unmarshal(w.Body.Items, &servers); err != nil {
+		return nil
+	}
+	for _, s := range servers {
+		if v, err := ParseVersion(s.WebLogicVersion); err == nil {
+			return v
+		}
+	}
+	return nil
+}
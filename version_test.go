@@ -0,0 +1,162 @@
+package remy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("WebLogic Server 12.1.3.0.0 Wed May 21 18:53:34 PDT 2014 1604337")
+	assert.NoError(t, err)
+	assert.Equal(t, Version{12, 1, 3, 0, 0}, v)
+	assert.Equal(t, "12.1.3.0.0", v.String())
+	assert.False(t, v.AtLeast(12, 2, 1))
+	assert.True(t, v.AtLeast(12, 1, 3))
+	assert.True(t, v.AtLeast(12))
+
+	v, err = ParseVersion("14.1.1.0.0")
+	assert.NoError(t, err)
+	assert.True(t, v.AtLeast(12, 2, 1))
+	assert.True(t, v.AtLeast(14, 1, 1, 0, 0))
+
+	_, err = ParseVersion("WebLogic Server")
+	assert.Error(t, err)
+	assert.Equal(t, "unknown", Version(nil).String())
+}
+
+// latestRouter serves VersionPath for release, and the tenant-monitoring servers unless tenantMonitoring is false.
+func latestRouter(release string, tenantMonitoring bool) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc(VersionPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": "` + release + `", "isLatest": true, "lifecycle": "active"}`))
+	})
+	if tenantMonitoring {
+		r.HandleFunc(MonitorPath+"/servers", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(serversJSON))
+		})
+	}
+	return r
+}
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.Handler
+		version   string
+		trees     []Tree
+		resources Tree
+	}{
+		{"12.1.x", CreateTestServerResourceRouters(), "unknown", []Tree{TenantMonitoring}, TenantMonitoring},
+		{"12.2.x", latestRouter("12.2.1.3.0", true), "12.2.1.3.0", []Tree{WebLogic, TenantMonitoring}, WebLogic},
+		{"14.1.x", latestRouter("14.1.1.0.0", false), "14.1.1.0.0", []Tree{WebLogic}, WebLogic},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(test.handler)
+			defer ts.Close()
+			client := NewClient(&AdminServer{AdminURL: ts.URL})
+			caps, err := client.Capabilities(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.version, caps.Version.String())
+			assert.Equal(t, test.trees, caps.Trees)
+			assert.Equal(t, test.resources, caps.Resources)
+			tree, err := client.resourceTree(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.resources, tree)
+		})
+	}
+}
+
+func TestCapabilitiesProbeFailures(t *testing.T) {
+	status := http.StatusUnauthorized
+	r := latestRouter("12.2.1.3.0", true)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == MonitorPath+"/servers" && status != http.StatusOK {
+			w.WriteHeader(status)
+			status = http.StatusOK
+			return
+		}
+		r.ServeHTTP(w, req)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+	_, err := client.Capabilities(context.Background())
+	assert.Error(t, err, "a 401 isn't an AdminServer without the tenant-monitoring tree")
+	assert.Nil(t, client.probe.capabilities)
+	caps, err := client.Capabilities(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Tree{WebLogic, TenantMonitoring}, caps.Trees)
+
+	status = http.StatusServiceUnavailable
+	caps, err = NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(1)).Capabilities(context.Background())
+	assert.NoError(t, err, "the probe is retried")
+	assert.Equal(t, []Tree{WebLogic, TenantMonitoring}, caps.Trees)
+}
+
+func TestResourceTreeProbedOnce(t *testing.T) {
+	probes := 0
+	r := latestRouter("12.2.1.3.0", true)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == VersionPath {
+			probes++
+		}
+		r.ServeHTTP(w, req)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+	for i := 0; i < 2; i++ {
+		tree, err := client.resourceTree(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, WebLogic, tree)
+	}
+	assert.Equal(t, 1, probes)
+
+	pinned := NewClient(&AdminServer{AdminURL: ts.URL}, WithTree(TenantMonitoring))
+	tree, err := pinned.resourceTree(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, TenantMonitoring, tree)
+	assert.Equal(t, 1, probes)
+}
+
+func TestResourceTreeUnreachable(t *testing.T) {
+	ts := httptest.NewServer(CreateTestServerResourceRouters())
+	ts.Close()
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+	_, err := client.Servers(context.Background(), QueryOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, Tree(""), client.probe.tree)
+}
+
+func TestResourceTreeProbeFailures(t *testing.T) {
+	status := http.StatusUnauthorized
+	r := latestRouter("12.2.1.3.0", true)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == VersionPath && status != http.StatusOK {
+			w.WriteHeader(status)
+			status = http.StatusOK
+			return
+		}
+		r.ServeHTTP(w, req)
+	}))
+	defer ts.Close()
+
+	client := NewClient(&AdminServer{AdminURL: ts.URL})
+	_, err := client.resourceTree(context.Background())
+	assert.Error(t, err, "a 401 isn't an AdminServer without the WebLogic tree")
+	assert.Equal(t, Tree(""), client.probe.tree)
+	tree, err := client.resourceTree(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, WebLogic, tree)
+
+	status = http.StatusServiceUnavailable
+	tree, err = NewClient(&AdminServer{AdminURL: ts.URL}, WithRetries(1)).resourceTree(context.Background())
+	assert.NoError(t, err, "the probe is retried")
+	assert.Equal(t, WebLogic, tree)
+}